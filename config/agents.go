@@ -0,0 +1,148 @@
+package config
+
+import "sort"
+
+// DefaultAgent is the agent profile used when neither the repository nor the worktree picks one
+const DefaultAgent = "claude"
+
+// AgentProfile describes the CLI agent started in a session's agent window
+// "{path}" in Args is replaced with the worktree path
+type AgentProfile struct {
+	Name       string            `json:"name"`
+	Command    string            `json:"command"`               // Executable, "" = plain shell
+	Args       []string          `json:"args,omitempty"`        // Arguments for every start
+	ResumeArgs []string          `json:"resume_args,omitempty"` // Inserted right after the command to resume the previous conversation
+	Env        map[string]string `json:"env,omitempty"`         // Extra environment variables
+}
+
+// AgentPresets are the built-in agent profiles
+var AgentPresets = []AgentProfile{
+	{
+		Name:       "claude",
+		Command:    "claude",
+		Args:       []string{"--add-dir", "{path}", "--permission-mode", "plan"},
+		ResumeArgs: []string{"--continue"},
+	},
+	{
+		Name:       "codex",
+		Command:    "codex",
+		ResumeArgs: []string{"resume", "--last"},
+	},
+	{
+		Name:    "gemini",
+		Command: "gemini",
+	},
+	{
+		Name:       "aider",
+		Command:    "aider",
+		ResumeArgs: []string{"--restore-chat-history"},
+	},
+	{
+		Name: "shell",
+	},
+}
+
+// GetAgentProfiles returns the built-in presets followed by the custom profiles from the config file
+// A custom profile with the name of a preset replaces it
+func (m *Manager) GetAgentProfiles() []AgentProfile {
+	var profiles []AgentProfile
+	for _, preset := range AgentPresets {
+		if custom, ok := m.config.AgentProfiles[preset.Name]; ok {
+			custom.Name = preset.Name
+			profiles = append(profiles, custom)
+		} else {
+			profiles = append(profiles, preset)
+		}
+	}
+
+	var names []string
+	for name := range m.config.AgentProfiles {
+		if !isAgentPreset(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		profile := m.config.AgentProfiles[name]
+		profile.Name = name
+		profiles = append(profiles, profile)
+	}
+	return profiles
+}
+
+// GetAgentProfile returns the agent profile for a worktree
+// The worktree's own choice wins over the repository default; unknown names fall back to DefaultAgent
+func (m *Manager) GetAgentProfile(repoPath, branch string) AgentProfile {
+	name := m.GetAgent(repoPath, branch)
+	profiles := m.GetAgentProfiles()
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	for _, profile := range profiles {
+		if profile.Name == DefaultAgent {
+			return profile
+		}
+	}
+	return AgentPresets[0]
+}
+
+// GetAgent returns the agent profile name for a worktree, or for the repository if branch is empty
+func (m *Manager) GetAgent(repoPath, branch string) string {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		if name := repo.WorktreeAgents[branch]; branch != "" && name != "" {
+			return name
+		}
+		if repo.Agent != "" {
+			return repo.Agent
+		}
+	}
+	return DefaultAgent
+}
+
+// GetWorktreeAgent returns the agent profile name chosen for a single worktree, "" = repository default
+func (m *Manager) GetWorktreeAgent(repoPath, branch string) string {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		return repo.WorktreeAgents[branch]
+	}
+	return ""
+}
+
+// SetAgent sets the agent profile for the repository, or for a single worktree if branch is set
+// An empty name resets the choice (worktrees use the repository default, the repository uses DefaultAgent)
+func (m *Manager) SetAgent(repoPath, branch, name string) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	repo := m.config.Repositories[repoPath]
+	if branch == "" {
+		repo.Agent = name
+		return m.save()
+	}
+
+	if repo.WorktreeAgents == nil {
+		repo.WorktreeAgents = make(map[string]string)
+	}
+	if name == "" {
+		delete(repo.WorktreeAgents, branch)
+	} else {
+		repo.WorktreeAgents[branch] = name
+	}
+	return m.save()
+}
+
+// isAgentPreset reports whether name is one of the built-in agent profiles
+func isAgentPreset(name string) bool {
+	for _, preset := range AgentPresets {
+		if preset.Name == name {
+			return true
+		}
+	}
+	return false
+}
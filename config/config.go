@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 	"github.com/coollabsio/jean-tui/openrouter"
 )
 
@@ -28,6 +29,14 @@ type Config struct {
 	AIPrompts           *AIPrompts             `json:"ai_prompts,omitempty"` // Customizable AI prompts
 	WrapperChecksums    map[string]string      `json:"wrapper_checksums,omitempty"` // Shell -> SHA256 checksum of installed wrapper
 	Onboarded           bool                   `json:"onboarded"` // Whether the user has completed the onboarding flow
+	ForgeTokens         map[string]string      `json:"forge_tokens,omitempty"` // Host -> API token (GitHub, Gitea/Forgejo)
+	AgentProfiles       map[string]AgentProfile `json:"agent_profiles,omitempty"` // Custom agent profiles by name (a preset name overrides the preset)
+	Multiplexer         string                 `json:"multiplexer,omitempty"` // "tmux" or "zellij", "" = tmux
+	SessionScrollback   bool                   `json:"session_scrollback,omitempty"` // Capture session scrollback periodically and restore it with the session
+	WorktreePathTemplate string                `json:"worktree_path_template,omitempty"` // Where new worktrees go, e.g. "~/worktrees/{repo}/{branch}", "" = .workspaces
+	WorkerConcurrency   int                    `json:"worker_concurrency,omitempty"` // Worktrees whose status or pull runs at the same time, 0 = default (4)
+	StatusTimeout       int                    `json:"status_timeout,omitempty"` // in seconds, per git status command, 0 = default (10s)
+	PullTimeout         int                    `json:"pull_timeout,omitempty"` // in seconds, per git pull command, 0 = default (120s)
 }
 
 // PRInfo represents information about a pull request
@@ -39,6 +48,8 @@ type PRInfo struct {
 	PRNumber  int    `json:"pr_number,omitempty"` // GitHub PR number (e.g., 42 from github.com/owner/repo/pull/42)
 	Title     string `json:"title,omitempty"`     // PR title for display
 	Author    string `json:"author,omitempty"`    // Author login for display
+	AutoMerge string `json:"auto_merge,omitempty"` // Pending auto-merge: "squash", "merge", "rebase", or "queue", "" = none
+	SyncedCommit string `json:"synced_commit,omitempty"` // HEAD commit the PR description was last generated/synced for
 }
 
 // RepoConfig represents configuration for a specific repository
@@ -46,11 +57,34 @@ type RepoConfig struct {
 	BaseBranch         string            `json:"base_branch"`
 	LastSelectedBranch string            `json:"last_selected_branch,omitempty"`
 	Editor             string            `json:"editor,omitempty"`
-	AutoFetchInterval  int               `json:"auto_fetch_interval,omitempty"` // in seconds, 0 = use default (10s)
+	AutoFetchInterval  int               `json:"auto_fetch_interval,omitempty"` // in seconds, 0 = use default (10s), -1 = off
 	Theme              string            `json:"theme,omitempty"`               // Per-repo theme override, "" = use global default
 	PRDefaultState     string            `json:"pr_default_state,omitempty"`    // "draft" or "ready", "" = use default (ready)
+	Forge              string            `json:"forge,omitempty"`               // "github", "gitlab", or "gitea", "" = detect from remote URL
+	PRDefaults         *PRDefaults       `json:"pr_defaults,omitempty"`         // Reviewers, labels, assignees, and milestone prefilled for new PRs
+	PRDescriptionSync  string            `json:"pr_description_sync,omitempty"` // "regenerate" or "append" after each push, "" = off
+	PushRemote         string            `json:"push_remote,omitempty"`         // Remote branches are pushed to (e.g. your fork), "" = origin
+	UpstreamRemote     string            `json:"upstream_remote,omitempty"`     // Remote PRs are opened against, "" = origin
 	PRs                map[string][]PRInfo `json:"prs,omitempty"`                 // branch -> list of PRs
-	InitializedClaudes map[string]bool   `json:"initialized_claudes,omitempty"` // branch -> whether Claude has been started
+	Agent              string            `json:"agent,omitempty"`               // Agent profile for the repository, "" = claude
+	WorktreeAgents     map[string]string `json:"worktree_agents,omitempty"`     // branch -> agent profile overriding the repository default
+	InitializedAgents  map[string][]string `json:"initialized_agents,omitempty"` // branch -> agent profiles that have been started
+	InitializedClaudes map[string]bool   `json:"initialized_claudes,omitempty"` // branch -> whether Claude has been started (before agent profiles)
+	PromptHistory      map[string][]string `json:"prompt_history,omitempty"`      // branch -> prompts sent to Claude, most recent first
+	Sessions           map[string]SessionRecord `json:"sessions,omitempty"`      // session name -> session to restore after a reboot or crash
+	PRTranscriptSummary bool             `json:"pr_transcript_summary,omitempty"` // Add a summary of the latest agent transcript to generated PR descriptions
+	WorktreePathTemplate string          `json:"worktree_path_template,omitempty"` // Overrides the global worktree path template, "" = use global
+	BranchTemplate     string            `json:"branch_template,omitempty"`     // Template for new branch names, e.g. "{user}/{type}/{ticket}-{slug}", "" = random names
+	BranchPattern      string            `json:"branch_pattern,omitempty"`      // Regex typed and AI-generated branch names must match, "" = any
+	DependencySeeding  string            `json:"dependency_seeding,omitempty"`  // Seed dependency dirs of new worktrees: "reflink" or "hardlink", "" = off
+}
+
+// PRDefaults holds the per-repository metadata prefilled in the PR content modal
+type PRDefaults struct {
+	Reviewers []string `json:"reviewers,omitempty"` // Usernames, or "org/team" for team reviewers
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone string   `json:"milestone,omitempty"` // Milestone title
 }
 
 // Manager handles configuration loading and saving
@@ -187,18 +221,24 @@ func (m *Manager) SetEditor(repoPath, editor string) error {
 	return m.save()
 }
 
+// AutoFetchIntervals are the auto-fetch intervals in seconds the setting cycles through, -1 = off
+var AutoFetchIntervals = []int{10, 30, 60, 300, 900, -1}
+
 // GetAutoFetchInterval returns the auto-fetch interval for a repository
-// Returns the configured interval in seconds, or 10 if not set
+// Returns the configured interval in seconds, 10 if not set, or 0 if auto-fetch is off
 func (m *Manager) GetAutoFetchInterval(repoPath string) int {
 	if repo, ok := m.config.Repositories[repoPath]; ok {
 		if repo.AutoFetchInterval > 0 {
 			return repo.AutoFetchInterval
 		}
+		if repo.AutoFetchInterval < 0 {
+			return 0
+		}
 	}
 	return 10 // Default to 10 seconds
 }
 
-// SetAutoFetchInterval sets the auto-fetch interval for a repository
+// SetAutoFetchInterval sets the auto-fetch interval for a repository, in seconds, -1 = off
 func (m *Manager) SetAutoFetchInterval(repoPath string, interval int) error {
 	if m.config.Repositories == nil {
 		m.config.Repositories = make(map[string]*RepoConfig)
@@ -321,6 +361,69 @@ func (m *Manager) SetAIBranchNameEnabled(enabled bool) error {
 	return m.save()
 }
 
+// GetMultiplexer returns the terminal multiplexer hosting worktree sessions ("" = tmux)
+func (m *Manager) GetMultiplexer() string {
+	return m.config.Multiplexer
+}
+
+// SetMultiplexer sets the terminal multiplexer hosting worktree sessions
+func (m *Manager) SetMultiplexer(name string) error {
+	m.config.Multiplexer = name
+	return m.save()
+}
+
+// GetConcurrency returns how many worktrees are worked on at once and the per-command timeouts
+// for status and pull operations, 0 = default
+func (m *Manager) GetConcurrency() (workers int, statusTimeout, pullTimeout time.Duration) {
+	return m.config.WorkerConcurrency, time.Duration(m.config.StatusTimeout) * time.Second, time.Duration(m.config.PullTimeout) * time.Second
+}
+
+// GetWorktreePathTemplate returns the template for new worktree paths of a repository
+// The repository's own template wins over the global one; "" = the .workspaces default
+func (m *Manager) GetWorktreePathTemplate(repoPath string) string {
+	if repo, ok := m.config.Repositories[repoPath]; ok && repo.WorktreePathTemplate != "" {
+		return repo.WorktreePathTemplate
+	}
+	return m.config.WorktreePathTemplate
+}
+
+// SetWorktreePathTemplate sets the template for new worktree paths of a repository, "" = use the global template
+func (m *Manager) SetWorktreePathTemplate(repoPath, template string) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].WorktreePathTemplate = template
+	return m.save()
+}
+
+// GetBranchNaming returns the branch name template and the regex branch names must match for a repository
+func (m *Manager) GetBranchNaming(repoPath string) (template, pattern string) {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		return repo.BranchTemplate, repo.BranchPattern
+	}
+	return "", ""
+}
+
+// SetBranchNaming sets the branch name template and the regex branch names must match for a repository
+func (m *Manager) SetBranchNaming(repoPath, template, pattern string) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].BranchTemplate = template
+	m.config.Repositories[repoPath].BranchPattern = pattern
+	return m.save()
+}
+
 // GetDebugLoggingEnabled returns whether debug logging is enabled
 func (m *Manager) GetDebugLoggingEnabled() bool {
 	return m.config.DebugLoggingEnabled
@@ -405,6 +508,41 @@ func (m *Manager) UpdatePRStatus(repoPath, branch, url, status string) error {
 	return nil
 }
 
+// SetPRAutoMerge records the pending auto-merge state of a pull request
+// autoMerge is the merge method, "queue" for a merge queue, or "" to clear it
+func (m *Manager) SetPRAutoMerge(repoPath, branch, url, autoMerge string) error {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		if repo.PRs != nil {
+			if prs, ok := repo.PRs[branch]; ok {
+				for i, pr := range prs {
+					if pr.URL == url {
+						prs[i].AutoMerge = autoMerge
+						return m.save()
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SetPRSyncedCommit records the commit a PR's description was last generated or synced for
+func (m *Manager) SetPRSyncedCommit(repoPath, branch, url, commit string) error {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		if repo.PRs != nil {
+			if prs, ok := repo.PRs[branch]; ok {
+				for i, pr := range prs {
+					if pr.URL == url {
+						prs[i].SyncedCommit = commit
+						return m.save()
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // RemovePR removes a pull request
 func (m *Manager) RemovePR(repoPath, branch, url string) error {
 	if repo, ok := m.config.Repositories[repoPath]; ok {
@@ -430,18 +568,60 @@ func (m *Manager) HasPRs(repoPath, branch string) bool {
 	return len(prs) > 0
 }
 
-// IsClaudeInitialized checks if a Claude session has been initialized for a branch
-func (m *Manager) IsClaudeInitialized(repoPath, branch string) bool {
+// IsAgentInitialized checks if an agent has been started before for a branch, so it can resume
+func (m *Manager) IsAgentInitialized(repoPath, branch, agent string) bool {
+	repo, ok := m.config.Repositories[repoPath]
+	if !ok {
+		return false
+	}
+	for _, name := range repo.InitializedAgents[branch] {
+		if name == agent {
+			return true
+		}
+	}
+	// Claude sessions recorded before agent profiles existed
+	return agent == DefaultAgent && repo.InitializedClaudes[branch]
+}
+
+// SetAgentInitialized marks a branch as having started an agent
+func (m *Manager) SetAgentInitialized(repoPath, branch, agent string) error {
+	if m.IsAgentInitialized(repoPath, branch, agent) {
+		return nil
+	}
+
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	repo := m.config.Repositories[repoPath]
+	if repo.InitializedAgents == nil {
+		repo.InitializedAgents = make(map[string][]string)
+	}
+
+	repo.InitializedAgents[branch] = append(repo.InitializedAgents[branch], agent)
+	return m.save()
+}
+
+// maxPromptHistory is the number of sent prompts remembered per branch
+const maxPromptHistory = 20
+
+// GetPromptHistory returns the prompts sent to a branch's Claude session, most recent first
+func (m *Manager) GetPromptHistory(repoPath, branch string) []string {
 	if repo, ok := m.config.Repositories[repoPath]; ok {
-		if repo.InitializedClaudes != nil {
-			return repo.InitializedClaudes[branch]
+		if repo.PromptHistory != nil {
+			return repo.PromptHistory[branch]
 		}
 	}
-	return false
+	return nil
 }
 
-// SetClaudeInitialized marks a branch as having an initialized Claude session
-func (m *Manager) SetClaudeInitialized(repoPath, branch string) error {
+// AddPromptHistory records a prompt sent to a branch's Claude session
+// A prompt sent again moves to the front instead of being duplicated
+func (m *Manager) AddPromptHistory(repoPath, branch, prompt string) error {
 	if m.config.Repositories == nil {
 		m.config.Repositories = make(map[string]*RepoConfig)
 	}
@@ -451,18 +631,29 @@ func (m *Manager) SetClaudeInitialized(repoPath, branch string) error {
 	}
 
 	repo := m.config.Repositories[repoPath]
-	if repo.InitializedClaudes == nil {
-		repo.InitializedClaudes = make(map[string]bool)
+	if repo.PromptHistory == nil {
+		repo.PromptHistory = make(map[string][]string)
 	}
 
-	repo.InitializedClaudes[branch] = true
+	history := []string{prompt}
+	for _, p := range repo.PromptHistory[branch] {
+		if p != prompt {
+			history = append(history, p)
+		}
+	}
+	if len(history) > maxPromptHistory {
+		history = history[:maxPromptHistory]
+	}
+	repo.PromptHistory[branch] = history
 	return m.save()
 }
 
 // CleanupBranch removes all branch-specific data from config when a worktree is deleted
 // This includes:
 // - All pull requests for the branch
-// - Claude initialization flag
+// - Agent initialization flags and per-worktree agent choice
+// - Prompt history
+// - Session records and their captured scrollback
 // - Last selected branch reference (if it matches the deleted branch)
 func (m *Manager) CleanupBranch(repoPath, branch string) error {
 	repo, ok := m.config.Repositories[repoPath]
@@ -475,10 +666,29 @@ func (m *Manager) CleanupBranch(repoPath, branch string) error {
 		delete(repo.PRs, branch)
 	}
 
-	// Remove Claude initialization flag for this branch
+	// Remove agent initialization flags and agent choice for this branch
 	if repo.InitializedClaudes != nil {
 		delete(repo.InitializedClaudes, branch)
 	}
+	if repo.InitializedAgents != nil {
+		delete(repo.InitializedAgents, branch)
+	}
+	if repo.WorktreeAgents != nil {
+		delete(repo.WorktreeAgents, branch)
+	}
+
+	// Remove prompt history for this branch
+	if repo.PromptHistory != nil {
+		delete(repo.PromptHistory, branch)
+	}
+
+	// Remove session records for this branch (the worktree session and its terminal session)
+	for name, record := range repo.Sessions {
+		if record.Branch == branch {
+			_ = os.RemoveAll(m.ScrollbackDir(name))
+			delete(repo.Sessions, name)
+		}
+	}
 
 	// Clear last selected branch if it matches the deleted branch
 	if repo.LastSelectedBranch == branch {
@@ -533,6 +743,15 @@ func (m *Manager) GetPRPrompt() string {
 	return openrouter.GetDefaultPRPrompt()
 }
 
+// GetCustomPRPrompt returns the PR content prompt the user customized, "" if it's the default
+// Unlike GetPRPrompt, this lets the default prompt for repositories with a PR template be used
+func (m *Manager) GetCustomPRPrompt() string {
+	if m.config.AIPrompts == nil || m.config.AIPrompts.PRContent == openrouter.GetDefaultPRPrompt() {
+		return ""
+	}
+	return m.config.AIPrompts.PRContent
+}
+
 // SetPRPrompt sets the custom PR content prompt
 func (m *Manager) SetPRPrompt(prompt string) error {
 	if m.config.AIPrompts == nil {
@@ -601,3 +820,113 @@ func (m *Manager) SetPRDefaultState(repoPath, state string) error {
 	m.config.Repositories[repoPath].PRDefaultState = state
 	return m.save()
 }
+
+// GetPRDescriptionSync returns how PR descriptions are kept in sync after pushing
+// Returns "regenerate", "append", or "" (off, the default)
+func (m *Manager) GetPRDescriptionSync(repoPath string) string {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		if repo.PRDescriptionSync == "regenerate" || repo.PRDescriptionSync == "append" {
+			return repo.PRDescriptionSync
+		}
+	}
+	return ""
+}
+
+// SetPRDescriptionSync sets how PR descriptions are kept in sync after pushing
+func (m *Manager) SetPRDescriptionSync(repoPath, mode string) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].PRDescriptionSync = mode
+	return m.save()
+}
+
+// GetForge returns the forge override for a repository
+// Returns "" if not set, meaning the forge is detected from the remote URL
+func (m *Manager) GetForge(repoPath string) string {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		return repo.Forge
+	}
+	return ""
+}
+
+// SetForge sets the forge override for a repository
+func (m *Manager) SetForge(repoPath, forge string) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].Forge = forge
+	return m.save()
+}
+
+// GetRemotes returns the push and upstream remotes for a repository
+// Empty values mean "origin"
+func (m *Manager) GetRemotes(repoPath string) (pushRemote, upstreamRemote string) {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		return repo.PushRemote, repo.UpstreamRemote
+	}
+	return "", ""
+}
+
+// SetRemotes sets the push and upstream remotes for a repository (fork-based workflow)
+func (m *Manager) SetRemotes(repoPath, pushRemote, upstreamRemote string) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].PushRemote = pushRemote
+	m.config.Repositories[repoPath].UpstreamRemote = upstreamRemote
+	return m.save()
+}
+
+// GetPRDefaults returns the PR metadata defaults for a repository
+// Returns an empty PRDefaults if none are configured
+func (m *Manager) GetPRDefaults(repoPath string) PRDefaults {
+	if repo, ok := m.config.Repositories[repoPath]; ok && repo.PRDefaults != nil {
+		return *repo.PRDefaults
+	}
+	return PRDefaults{}
+}
+
+// SetPRDefaults sets the PR metadata defaults for a repository
+func (m *Manager) SetPRDefaults(repoPath string, defaults PRDefaults) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].PRDefaults = &defaults
+	return m.save()
+}
+
+// GetForgeToken returns the configured API token for a forge host
+// Returns "" if not set
+func (m *Manager) GetForgeToken(host string) string {
+	return m.config.ForgeTokens[host]
+}
+
+// SetForgeToken sets the API token for a forge host
+func (m *Manager) SetForgeToken(host, token string) error {
+	if m.config.ForgeTokens == nil {
+		m.config.ForgeTokens = make(map[string]string)
+	}
+	m.config.ForgeTokens[host] = token
+	return m.save()
+}
@@ -0,0 +1,56 @@
+package config
+
+// Dependency seeding modes: how dependency directories of a new worktree are seeded from another checkout
+const (
+	SeedReflink  = "reflink"  // Copy-on-write clones only
+	SeedHardlink = "hardlink" // Copy-on-write clones, or hardlinks where the filesystem has none
+)
+
+// SeedModes are the dependency seeding modes in the order the setting cycles through them, "" = off
+var SeedModes = []string{"", SeedReflink, SeedHardlink}
+
+// DependencyRule is a dependency directory and the lockfiles it's installed from
+// A directory is only seeded from a checkout whose lockfile is identical
+type DependencyRule struct {
+	Dir       string   `json:"dir"`       // e.g. "node_modules"
+	Lockfiles []string `json:"lockfiles"` // The first one present is compared, e.g. ["package-lock.json", "yarn.lock"]
+}
+
+// DefaultDependencies are the dependency directories seeded when jean.json declares none
+// Only directories that work from another path are included: a Python .venv has absolute paths into the
+// checkout it was created in (scripts, activate, editable installs), so a seeded one runs that checkout's code
+var DefaultDependencies = []DependencyRule{
+	{Dir: "node_modules", Lockfiles: []string{"package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb", "bun.lock"}},
+	{Dir: "target", Lockfiles: []string{"Cargo.lock"}},
+	{Dir: "vendor", Lockfiles: []string{"composer.lock"}},
+}
+
+// GetDependencies returns the dependency directories declared in jean.json, or the defaults
+func (s *ScriptConfig) GetDependencies() []DependencyRule {
+	if s == nil || s.Dependencies == nil {
+		return DefaultDependencies
+	}
+	return s.Dependencies
+}
+
+// GetDependencySeeding returns how dependency directories of new worktrees are seeded, "" = off
+func (m *Manager) GetDependencySeeding(repoPath string) string {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		return repo.DependencySeeding
+	}
+	return ""
+}
+
+// SetDependencySeeding sets how dependency directories of new worktrees are seeded, "" = off
+func (m *Manager) SetDependencySeeding(repoPath, mode string) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].DependencySeeding = mode
+	return m.save()
+}
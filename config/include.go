@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+)
+
+// Include modes: how a file from the root checkout gets into a worktree
+const (
+	IncludeCopy     = "copy"
+	IncludeSymlink  = "symlink"
+	IncludeHardlink = "hardlink"
+)
+
+// IncludeRule is a gitignored file or directory of the root checkout put into each new worktree
+// In jean.json a rule is either a glob (".env*", copied) or {"path": "certs/", "mode": "symlink"}
+type IncludeRule struct {
+	Path string `json:"path"`           // Glob relative to the root checkout, e.g. ".env*" or "config/*.local"
+	Mode string `json:"mode,omitempty"` // "copy", "symlink", or "hardlink", "" = copy
+}
+
+// UnmarshalJSON accepts a plain glob as well as a rule object
+func (r *IncludeRule) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*r = IncludeRule{Path: path}
+		return nil
+	}
+
+	type rule IncludeRule
+	var parsed rule
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("include rule must be a path or {\"path\", \"mode\"}: %w", err)
+	}
+	*r = IncludeRule(parsed)
+	return nil
+}
+
+// IncludeMode returns the rule's mode, "" = copy
+func (r IncludeRule) IncludeMode() string {
+	if r.Mode == "" {
+		return IncludeCopy
+	}
+	return r.Mode
+}
+
+// Validate checks that the rule has a path inside the root checkout and a known mode
+func (r IncludeRule) Validate() error {
+	if r.Path == "" {
+		return fmt.Errorf("include rule without a path")
+	}
+	// Targets outside the worktree would be replaced, e.g. the source itself with a ../{repo}-{branch} path template
+	if !filepath.IsLocal(r.Path) {
+		return fmt.Errorf("include %q: path must be relative to the root checkout, without \"..\"", r.Path)
+	}
+	switch r.IncludeMode() {
+	case IncludeCopy, IncludeSymlink, IncludeHardlink:
+		return nil
+	}
+	return fmt.Errorf("include %q: mode must be \"copy\", \"symlink\", or \"hardlink\", got %q", r.Path, r.Mode)
+}
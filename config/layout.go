@@ -0,0 +1,92 @@
+package config
+
+import "fmt"
+
+// Layout is a tmux session layout declared in jean.json
+type Layout struct {
+	Focus   string         `json:"focus,omitempty"` // Window selected when the session opens, "" = first window
+	Windows []LayoutWindow `json:"windows"`
+}
+
+// LayoutWindow is a named tmux window with one or more panes
+type LayoutWindow struct {
+	Name  string       `json:"name"`
+	Panes []LayoutPane `json:"panes,omitempty"` // No panes = a single shell
+}
+
+// LayoutPane is a pane of a layout window
+// Each pane after the first is split from the pane before it
+type LayoutPane struct {
+	Command string `json:"command,omitempty"` // Typed into the pane's shell (e.g. "npm run dev"), "" = just a shell
+	Agent   bool   `json:"agent,omitempty"`   // Run the worktree's agent instead of a shell
+	Split   string `json:"split,omitempty"`   // "right" or "below" (default), ignored for the first pane
+	Size    string `json:"size,omitempty"`    // Size of the new pane, as cells ("20") or percentage ("30%")
+}
+
+// DefaultLayout is the layout used when jean.json declares none: a terminal window and the agent window
+var DefaultLayout = Layout{
+	Windows: []LayoutWindow{
+		{Name: "terminal"},
+		{Name: "claude", Panes: []LayoutPane{{Agent: true}}},
+	},
+}
+
+// LayoutFromWindows builds a layout from window names: the claude window runs the agent, the others a shell
+func LayoutFromWindows(windows []string) Layout {
+	if len(windows) == 0 {
+		return DefaultLayout
+	}
+	layout := Layout{}
+	for _, name := range windows {
+		window := LayoutWindow{Name: name}
+		if name == "claude" {
+			window.Panes = []LayoutPane{{Agent: true}}
+		}
+		layout.Windows = append(layout.Windows, window)
+	}
+	return layout
+}
+
+// Validate checks that window names are unique and set, and that focus and splits are valid
+func (l Layout) Validate() error {
+	if len(l.Windows) == 0 {
+		return fmt.Errorf("layout has no windows")
+	}
+	names := make(map[string]bool)
+	for _, window := range l.Windows {
+		if window.Name == "" {
+			return fmt.Errorf("layout window without a name")
+		}
+		if names[window.Name] {
+			return fmt.Errorf("duplicate layout window %q", window.Name)
+		}
+		names[window.Name] = true
+		for _, pane := range window.Panes {
+			if pane.Split != "" && pane.Split != "right" && pane.Split != "below" {
+				return fmt.Errorf("window %q: split must be \"right\" or \"below\", got %q", window.Name, pane.Split)
+			}
+		}
+	}
+	if l.Focus != "" && !names[l.Focus] {
+		return fmt.Errorf("focus window %q is not in the layout", l.Focus)
+	}
+	return nil
+}
+
+// HasWindow reports whether the layout has a window with the given name
+func (l Layout) HasWindow(name string) bool {
+	for _, window := range l.Windows {
+		if window.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FocusWindow returns the window selected when the session opens
+func (l Layout) FocusWindow() string {
+	if l.Focus != "" || len(l.Windows) == 0 {
+		return l.Focus
+	}
+	return l.Windows[0].Name
+}
@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+// TestLayoutValidate tests layout validation and the focus window fallback
+func TestLayoutValidate(t *testing.T) {
+	if err := DefaultLayout.Validate(); err != nil {
+		t.Errorf("Default layout should be valid: %v", err)
+	}
+	if got := DefaultLayout.FocusWindow(); got != "terminal" {
+		t.Errorf("Expected first window as focus, got %q", got)
+	}
+
+	invalid := map[string]Layout{
+		"no windows":     {},
+		"unnamed window": {Windows: []LayoutWindow{{}}},
+		"duplicate":      {Windows: []LayoutWindow{{Name: "dev"}, {Name: "dev"}}},
+		"bad split":      {Windows: []LayoutWindow{{Name: "dev", Panes: []LayoutPane{{}, {Split: "left"}}}}},
+		"unknown focus":  {Focus: "logs", Windows: []LayoutWindow{{Name: "dev"}}},
+	}
+	for name, layout := range invalid {
+		if err := layout.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
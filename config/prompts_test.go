@@ -0,0 +1,37 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coollabsio/jean-tui/openrouter"
+)
+
+// TestPRPromptWithTemplate tests that repositories with a PR template get the template prompt unless the
+// PR prompt was customized, in which case the template is appended to the custom prompt
+func TestPRPromptWithTemplate(t *testing.T) {
+	m := &Manager{configPath: filepath.Join(t.TempDir(), "config.json"), config: &Config{}}
+	template := "## Summary\n\n## Testing"
+
+	prompt := openrouter.BuildPRPrompt("diff", m.GetCustomPRPrompt(), template)
+	if !strings.HasPrefix(prompt, "Generate a pull request title and a description for these changes by filling in") || strings.Contains(prompt, "What's Changed") {
+		t.Errorf("Expected the template prompt without a custom prompt, got %q", prompt)
+	}
+
+	// Settings save the default prompt when it's edited without changes
+	if err := m.SetPRPrompt(m.GetPRPrompt()); err != nil {
+		t.Fatal(err)
+	}
+	if got := openrouter.BuildPRPrompt("diff", m.GetCustomPRPrompt(), template); got != prompt {
+		t.Errorf("Expected a saved default prompt to count as not customized, got %q", got)
+	}
+
+	if err := m.SetPRPrompt("Describe these changes as a haiku: {diff}"); err != nil {
+		t.Fatal(err)
+	}
+	prompt = openrouter.BuildPRPrompt("diff", m.GetCustomPRPrompt(), template)
+	if !strings.HasPrefix(prompt, "Describe these changes as a haiku: diff") || !strings.Contains(prompt, template) {
+		t.Errorf("Expected the custom prompt with the template appended, got %q", prompt)
+	}
+}
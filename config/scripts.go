@@ -8,7 +8,10 @@ import (
 
 // ScriptConfig represents the jean.json configuration file
 type ScriptConfig struct {
-	Scripts map[string]string `json:"scripts"`
+	Scripts      map[string]string `json:"scripts"`
+	Layout       *Layout           `json:"layout,omitempty"`       // tmux session layout, nil = terminal and agent windows
+	Include      []IncludeRule     `json:"include,omitempty"`      // Gitignored files copied or linked from the root checkout into new worktrees
+	Dependencies []DependencyRule  `json:"dependencies,omitempty"` // Dependency directories seeded into new worktrees, nil = DefaultDependencies
 }
 
 // LoadScripts loads the jean.json file from a repository path
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// SessionRecord is what jean remembers about a running worktree session, so the session can be
+// recreated after a reboot or a multiplexer crash
+type SessionRecord struct {
+	Branch           string   `json:"branch"`
+	Path             string   `json:"path"`                  // Worktree the session runs in
+	Multiplexer      string   `json:"multiplexer,omitempty"` // "tmux" or "zellij", "" = tmux
+	Windows          []string `json:"windows,omitempty"`     // Window names, in order
+	Layout           *Layout  `json:"layout,omitempty"`      // jean.json layout the session was created from, nil = built from Windows
+	Agent            string   `json:"agent,omitempty"`       // Agent profile running in the claude window
+	AgentInitialized bool     `json:"agent_initialized,omitempty"`
+}
+
+// GetSessionRecords returns the recorded sessions of a repository by session name
+func (m *Manager) GetSessionRecords(repoPath string) map[string]SessionRecord {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		return repo.Sessions
+	}
+	return nil
+}
+
+// SetSessionRecord records a session, the config is only written if the record changed
+func (m *Manager) SetSessionRecord(repoPath, sessionName string, record SessionRecord) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	repo := m.config.Repositories[repoPath]
+	if existing, ok := repo.Sessions[sessionName]; ok && reflect.DeepEqual(existing, record) {
+		return nil
+	}
+	if repo.Sessions == nil {
+		repo.Sessions = make(map[string]SessionRecord)
+	}
+	repo.Sessions[sessionName] = record
+	return m.save()
+}
+
+// RemoveSessionRecord forgets a session and deletes its captured scrollback
+func (m *Manager) RemoveSessionRecord(repoPath, sessionName string) error {
+	_ = os.RemoveAll(m.ScrollbackDir(sessionName))
+
+	repo, ok := m.config.Repositories[repoPath]
+	if !ok || repo.Sessions == nil {
+		return nil
+	}
+	if _, ok := repo.Sessions[sessionName]; !ok {
+		return nil
+	}
+	delete(repo.Sessions, sessionName)
+	return m.save()
+}
+
+// ScrollbackDir returns the directory holding the captured scrollback of a session
+func (m *Manager) ScrollbackDir(sessionName string) string {
+	return filepath.Join(filepath.Dir(m.configPath), "scrollback", sessionName)
+}
+
+// GetSessionScrollback returns whether session scrollback is captured periodically and restored with the session
+func (m *Manager) GetSessionScrollback() bool {
+	return m.config.SessionScrollback
+}
+
+// SetSessionScrollback sets whether session scrollback is captured periodically and restored with the session
+func (m *Manager) SetSessionScrollback(enabled bool) error {
+	m.config.SessionScrollback = enabled
+	return m.save()
+}
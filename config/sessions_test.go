@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSessionRecords tests recording sessions and forgetting them when the worktree is deleted
+func TestSessionRecords(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manager{configPath: filepath.Join(dir, "config.json"), config: &Config{}}
+
+	record := SessionRecord{Branch: "feature/login", Path: "/tmp/login", Windows: []string{"terminal", "claude"}, Agent: "claude"}
+	if err := m.SetSessionRecord("/repo", "jean-repo-feature-login", record); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetSessionRecord("/repo", "jean-repo-feature-other", SessionRecord{Branch: "feature/other", Path: "/tmp/other"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.GetSessionRecords("/repo")["jean-repo-feature-login"]; got.Agent != "claude" || len(got.Windows) != 2 {
+		t.Errorf("Unexpected record %+v", got)
+	}
+
+	// Deleting the worktree forgets its session and scrollback
+	scrollback := m.ScrollbackDir("jean-repo-feature-login")
+	if err := os.MkdirAll(scrollback, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CleanupBranch("/repo", "feature/login"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.GetSessionRecords("/repo")["jean-repo-feature-login"]; ok {
+		t.Error("Expected the record to be removed with the branch")
+	}
+	if _, err := os.Stat(scrollback); !os.IsNotExist(err) {
+		t.Error("Expected the captured scrollback to be removed with the branch")
+	}
+
+	if err := m.RemoveSessionRecord("/repo", "jean-repo-feature-other"); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.GetSessionRecords("/repo")) != 0 {
+		t.Errorf("Expected no records left, got %+v", m.GetSessionRecords("/repo"))
+	}
+}
+
+// TestLayoutFromWindows tests building a layout from recorded window names
+func TestLayoutFromWindows(t *testing.T) {
+	layout := LayoutFromWindows([]string{"terminal", "claude", "logs"})
+	if len(layout.Windows) != 3 || !layout.Windows[1].Panes[0].Agent || len(layout.Windows[2].Panes) != 0 {
+		t.Errorf("Unexpected layout %+v", layout)
+	}
+	if got := LayoutFromWindows(nil); len(got.Windows) != len(DefaultLayout.Windows) {
+		t.Errorf("Expected the default layout without windows, got %+v", got)
+	}
+}
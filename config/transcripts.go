@@ -0,0 +1,158 @@
+package config
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// transcriptHeader starts every capture in a transcript file, followed by the time and the reason
+const transcriptHeader = "===== jean capture "
+
+// Transcript is the captured agent output of a worktree branch
+// Each capture is appended to the branch's file, so a transcript outlives its session and worktree
+type Transcript struct {
+	Branch   string
+	Path     string
+	Captures int
+	Updated  time.Time
+}
+
+// TranscriptsDir returns the directory holding the transcripts of a repository
+func (m *Manager) TranscriptsDir(repoPath string) string {
+	sum := sha1.Sum([]byte(repoPath))
+	return filepath.Join(filepath.Dir(m.configPath), "transcripts", filepath.Base(repoPath)+"-"+hex.EncodeToString(sum[:4]))
+}
+
+// TranscriptPath returns the transcript file of a branch
+func (m *Manager) TranscriptPath(repoPath, branch string) string {
+	return filepath.Join(m.TranscriptsDir(repoPath), url.PathEscape(branch)+".log")
+}
+
+// latestCapturePath returns the file holding the most recent full capture of a branch, which later
+// captures are compared against
+func (m *Manager) latestCapturePath(repoPath, branch string) string {
+	return filepath.Join(m.TranscriptsDir(repoPath), url.PathEscape(branch)+".last")
+}
+
+// AppendTranscript adds a capture of a branch's agent output to its transcript
+// reason says what triggered the capture (e.g. "kill" or "manual"); a capture identical to the previous one is skipped.
+// Captures hold the whole scrollback, so when one starts with the previous capture only the new output is appended
+func (m *Manager) AppendTranscript(repoPath, branch, reason, content string) error {
+	content = strings.TrimRight(content, "\n \t")
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	latest, _ := m.LatestTranscript(repoPath, branch)
+	if latest == content {
+		return nil
+	}
+	entry := content
+	if latest != "" && strings.HasPrefix(content, latest) {
+		entry = strings.TrimLeft(content[len(latest):], "\n")
+		reason += ", continued"
+	}
+
+	if err := os.MkdirAll(m.TranscriptsDir(repoPath), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(m.TranscriptPath(repoPath, branch), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := fmt.Fprintf(file, "%s%s (%s) =====\n%s\n", transcriptHeader, time.Now().Format(time.RFC3339), reason, entry); err != nil {
+		return err
+	}
+	return os.WriteFile(m.latestCapturePath(repoPath, branch), []byte(content), 0600)
+}
+
+// LatestTranscript returns the most recent capture of a branch's transcript
+func (m *Manager) LatestTranscript(repoPath, branch string) (string, error) {
+	if data, err := os.ReadFile(m.latestCapturePath(repoPath, branch)); err == nil {
+		return string(data), nil
+	}
+
+	// Transcripts written before the latest capture was kept separately end with a full capture
+	data, err := os.ReadFile(m.TranscriptPath(repoPath, branch))
+	if err != nil {
+		return "", err
+	}
+	content := string(data)
+	if i := strings.LastIndex(content, transcriptHeader); i >= 0 {
+		content = content[i:]
+		if nl := strings.Index(content, "\n"); nl >= 0 {
+			content = content[nl+1:]
+		}
+	}
+	return strings.TrimRight(content, "\n"), nil
+}
+
+// ListTranscripts returns the transcripts of a repository, most recently updated first
+func (m *Manager) ListTranscripts(repoPath string) ([]Transcript, error) {
+	entries, err := os.ReadDir(m.TranscriptsDir(repoPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var transcripts []Transcript
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		branch, err := url.PathUnescape(strings.TrimSuffix(name, ".log"))
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(m.TranscriptsDir(repoPath), name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		transcripts = append(transcripts, Transcript{
+			Branch:   branch,
+			Path:     path,
+			Captures: strings.Count(string(data), transcriptHeader),
+			Updated:  info.ModTime(),
+		})
+	}
+	sort.Slice(transcripts, func(i, j int) bool { return transcripts[i].Updated.After(transcripts[j].Updated) })
+	return transcripts, nil
+}
+
+// GetPRTranscriptSummary returns whether generated PR descriptions get a summary of the branch's latest transcript
+func (m *Manager) GetPRTranscriptSummary(repoPath string) bool {
+	if repo, ok := m.config.Repositories[repoPath]; ok {
+		return repo.PRTranscriptSummary
+	}
+	return false
+}
+
+// SetPRTranscriptSummary sets whether generated PR descriptions get a summary of the branch's latest transcript
+func (m *Manager) SetPRTranscriptSummary(repoPath string, enabled bool) error {
+	if m.config.Repositories == nil {
+		m.config.Repositories = make(map[string]*RepoConfig)
+	}
+
+	if _, ok := m.config.Repositories[repoPath]; !ok {
+		m.config.Repositories[repoPath] = &RepoConfig{}
+	}
+
+	m.config.Repositories[repoPath].PRTranscriptSummary = enabled
+	return m.save()
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTranscripts tests appending captures to a branch's transcript and listing transcripts
+func TestTranscripts(t *testing.T) {
+	m := &Manager{configPath: filepath.Join(t.TempDir(), "config.json"), config: &Config{}}
+
+	if err := m.AppendTranscript("/repo", "feature/login", "manual", "first\n"); err != nil {
+		t.Fatal(err)
+	}
+	// Identical and empty captures are skipped
+	if err := m.AppendTranscript("/repo", "feature/login", "kill", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AppendTranscript("/repo", "feature/login", "kill", "  \n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AppendTranscript("/repo", "feature/login", "kill", "first\nsecond"); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := m.LatestTranscript("/repo", "feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != "first\nsecond" {
+		t.Errorf("Expected the latest capture, got %q", latest)
+	}
+
+	// Only the output after the previous capture is appended, a capture that doesn't continue it is kept whole
+	if err := m.AppendTranscript("/repo", "feature/login", "kill", "first\nsecond\nthird"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AppendTranscript("/repo", "feature/login", "kill", "new session"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(m.TranscriptPath("/repo", "feature/login"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := strings.Count(string(data), "first"); count != 1 {
+		t.Errorf("Expected the first line to be written once, got %d times in %q", count, data)
+	}
+	if !strings.Contains(string(data), "(kill, continued) =====\nthird\n") {
+		t.Errorf("Expected only the new line to be appended, got %q", data)
+	}
+	if latest, _ := m.LatestTranscript("/repo", "feature/login"); latest != "new session" {
+		t.Errorf("Expected the latest full capture, got %q", latest)
+	}
+
+	transcripts, err := m.ListTranscripts("/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transcripts) != 1 || transcripts[0].Branch != "feature/login" || transcripts[0].Captures != 4 {
+		t.Errorf("Unexpected transcripts %+v", transcripts)
+	}
+
+	// Other repositories have their own transcripts
+	if other, _ := m.ListTranscripts("/other/repo"); len(other) != 0 {
+		t.Errorf("Expected no transcripts for another repository, got %+v", other)
+	}
+}
@@ -0,0 +1,71 @@
+package forge
+
+import "strings"
+
+// Markers delimiting the jean-generated region of a PR description
+// Text outside the markers is left untouched when the description is synced
+const (
+	GeneratedStartMarker = "<!-- jean:generated:start -->"
+	GeneratedEndMarker   = "<!-- jean:generated:end -->"
+)
+
+// WrapGeneratedDescription marks a generated description so it can be replaced later
+// Returns "" for an empty description
+func WrapGeneratedDescription(description string) string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return ""
+	}
+	return GeneratedStartMarker + "\n" + description + "\n" + GeneratedEndMarker
+}
+
+// generatedRegion returns the byte offsets of the content between the markers
+// ok is false if body has no complete generated region
+func generatedRegion(body string) (start, end int, ok bool) {
+	i := strings.Index(body, GeneratedStartMarker)
+	if i < 0 {
+		return 0, 0, false
+	}
+	start = i + len(GeneratedStartMarker)
+	j := strings.Index(body[start:], GeneratedEndMarker)
+	if j < 0 {
+		return 0, 0, false
+	}
+	return start, start + j, true
+}
+
+// ReplaceGeneratedDescription replaces the generated region of body with description
+// If body has no generated region, the new region is added after the existing text
+func ReplaceGeneratedDescription(body, description string) string {
+	description = strings.TrimSpace(description)
+	start, end, ok := generatedRegion(body)
+	if !ok {
+		return joinSections(body, WrapGeneratedDescription(description))
+	}
+	return body[:start] + "\n" + description + "\n" + body[end:]
+}
+
+// AppendGeneratedDescription appends addition to the end of the generated region of body
+// If body has no generated region, addition is added as a new region after the existing text
+func AppendGeneratedDescription(body, addition string) string {
+	addition = strings.TrimSpace(addition)
+	start, end, ok := generatedRegion(body)
+	if !ok {
+		return joinSections(body, WrapGeneratedDescription(addition))
+	}
+	return body[:start] + "\n" + joinSections(body[start:end], addition) + "\n" + body[end:]
+}
+
+// joinSections joins two markdown sections with a blank line, skipping empty ones
+func joinSections(first, second string) string {
+	first = strings.TrimSpace(first)
+	second = strings.TrimSpace(second)
+	switch {
+	case first == "":
+		return second
+	case second == "":
+		return first
+	default:
+		return first + "\n\n" + second
+	}
+}
@@ -0,0 +1,38 @@
+package forge
+
+import "testing"
+
+// TestReplaceGeneratedDescription tests that only the generated region is replaced
+func TestReplaceGeneratedDescription(t *testing.T) {
+	body := "Fixes #12\n\n" + WrapGeneratedDescription("Old summary") + "\n\n## Notes from reviewer\nKeep this"
+
+	got := ReplaceGeneratedDescription(body, "New summary\n")
+	want := "Fixes #12\n\n" + GeneratedStartMarker + "\nNew summary\n" + GeneratedEndMarker + "\n\n## Notes from reviewer\nKeep this"
+	if got != want {
+		t.Errorf("ReplaceGeneratedDescription() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+// TestReplaceGeneratedDescription_NoMarkers tests that text written without markers is preserved
+func TestReplaceGeneratedDescription_NoMarkers(t *testing.T) {
+	got := ReplaceGeneratedDescription("Written by hand", "Summary")
+	want := "Written by hand\n\n" + WrapGeneratedDescription("Summary")
+	if got != want {
+		t.Errorf("ReplaceGeneratedDescription() = %q, want %q", got, want)
+	}
+
+	if got := ReplaceGeneratedDescription("", "Summary"); got != WrapGeneratedDescription("Summary") {
+		t.Errorf("Expected a wrapped description for an empty body, got %q", got)
+	}
+}
+
+// TestAppendGeneratedDescription tests appending inside the generated region
+func TestAppendGeneratedDescription(t *testing.T) {
+	body := WrapGeneratedDescription("Summary") + "\n\nHuman notes"
+
+	got := AppendGeneratedDescription(body, "- Add tests")
+	want := GeneratedStartMarker + "\nSummary\n\n- Add tests\n" + GeneratedEndMarker + "\n\nHuman notes"
+	if got != want {
+		t.Errorf("AppendGeneratedDescription() =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,268 @@
+package forge
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/coollabsio/jean-tui/github"
+)
+
+// Supported forge kinds
+const (
+	KindGitHub = "github"
+	KindGitLab = "gitlab"
+	KindGitea  = "gitea" // Also covers Forgejo and Codeberg
+)
+
+// PRInfo holds information about a pull/merge request
+// It is shared by all forges so the TUI can treat them uniformly
+type PRInfo = github.PRInfo
+
+// PRMetadata holds the reviewers, labels, assignees, and milestone applied to a pull/merge request
+type PRMetadata = github.PRMetadata
+
+// Forge is a code hosting platform that jean can manage pull/merge requests on
+type Forge interface {
+	// Name returns the human-readable platform name (e.g., "GitHub")
+	Name() string
+
+	// CreatePR creates a pull request (draft or ready for review) and returns its URL
+	// branch may be "owner:branch" for a branch pushed to a fork of the repository
+	CreatePR(worktreePath, branch, baseBranch, title, description string, isDraft bool) (string, error)
+
+	// UpdatePR updates the title and/or description of an existing PR
+	// prIdentifier may be a PR URL, a PR number, or the head branch name
+	UpdatePR(worktreePath, prIdentifier, title, description string) error
+
+	// GetPRStatus returns "open", "merged", or "closed" for the PR at prURL
+	GetPRStatus(prURL string) (string, error)
+
+	// GetPRBody returns the current description of the PR at prURL
+	GetPRBody(worktreePath, prURL string) (string, error)
+
+	// GetPRForBranch returns the latest PR for a branch, or nil if none exists
+	// branch may be "owner:branch" for a branch pushed to a fork of the repository
+	GetPRForBranch(worktreePath, branch string) (*PRInfo, error)
+
+	// ListPRs lists the most recent open PRs for the repository
+	ListPRs(worktreePath string) ([]PRInfo, error)
+
+	// MarkPRReady converts a draft PR to ready for review
+	MarkPRReady(worktreePath, prURL string) error
+
+	// MergePR merges a PR using "squash", "merge", or "rebase"
+	MergePR(worktreePath, prURL, mergeMethod string) error
+
+	// RepoURL returns the repository URL for opening in a browser
+	RepoURL() string
+
+	// BranchURL returns the browser URL for a branch
+	BranchURL(branch string) string
+}
+
+// AutoMerger is implemented by forges that can merge a PR once its checks pass
+type AutoMerger interface {
+	// EnableAutoMerge schedules the PR to merge with mergeMethod once required checks pass
+	EnableAutoMerge(worktreePath, prURL, mergeMethod string) error
+
+	// DisableAutoMerge cancels a scheduled auto-merge (or removes the PR from the merge queue)
+	DisableAutoMerge(worktreePath, prURL string) error
+
+	// AddToMergeQueue adds the PR to the base branch's merge queue
+	AddToMergeQueue(worktreePath, prURL string) error
+}
+
+// MetadataEditor is implemented by forges that can set reviewers, labels, assignees, and milestone
+type MetadataEditor interface {
+	// SetPRMetadata adds reviewers, labels, and assignees to the PR and sets its milestone
+	// Reviewers in "org/team" form are requested as team reviewers where the forge supports it
+	SetPRMetadata(worktreePath, prURL string, meta PRMetadata) error
+}
+
+// ForkCreator is implemented by forges that can fork the repository for fork-based contributions
+type ForkCreator interface {
+	// CreateFork forks the repository into the authenticated user's account and returns the fork's clone URL
+	// (SSH if useSSH is set)
+	CreateFork(worktreePath string, useSSH bool) (string, error)
+}
+
+// ErrBatchUnsupported is returned by BranchPRLookup when batching isn't available
+// (e.g., no API token); callers should fall back to GetPRForBranch per branch
+var ErrBatchUnsupported = errors.New("batched PR lookup is not supported")
+
+// BranchPRLookup is implemented by forges that can look up PRs for many branches at once
+type BranchPRLookup interface {
+	// GetPRsForBranches returns the latest PR per branch ("owner:branch" for a fork, like GetPRForBranch);
+	// branches without a PR are omitted
+	GetPRsForBranches(worktreePath string, branches []string) (map[string]*PRInfo, error)
+}
+
+// Remote describes a parsed git remote URL
+type Remote struct {
+	Scheme string // "https" or "http" (SSH remotes are mapped to https)
+	Host   string // Host name, including a port for HTTP(S) remotes
+	Path   string // Repository path without .git suffix (e.g., "owner/repo" or "group/sub/repo")
+}
+
+// ParseRemoteURL parses SSH (git@host:owner/repo.git, ssh://git@host/owner/repo.git)
+// and HTTP(S) remote URLs
+func ParseRemoteURL(remoteURL string) (*Remote, error) {
+	raw := strings.TrimSpace(remoteURL)
+	if raw == "" {
+		return nil, fmt.Errorf("empty remote URL")
+	}
+
+	var remote Remote
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote URL %q: %w", raw, err)
+		}
+		switch u.Scheme {
+		case "http", "https":
+			remote.Scheme = u.Scheme
+			remote.Host = u.Host
+		default:
+			// ssh://, git:// - the port (if any) is not the web port
+			remote.Scheme = "https"
+			remote.Host = u.Hostname()
+		}
+		remote.Path = u.Path
+	} else {
+		// scp-like syntax: [user@]host:owner/repo.git
+		hostPart, pathPart, ok := strings.Cut(raw, ":")
+		if !ok {
+			return nil, fmt.Errorf("unsupported remote URL: %s", raw)
+		}
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		remote.Scheme = "https"
+		remote.Host = hostPart
+		remote.Path = pathPart
+	}
+
+	remote.Path = strings.TrimSuffix(strings.Trim(remote.Path, "/"), ".git")
+	if remote.Host == "" || !strings.Contains(remote.Path, "/") {
+		return nil, fmt.Errorf("unsupported remote URL: %s", raw)
+	}
+
+	return &remote, nil
+}
+
+// IsSSHRemoteURL reports whether a remote URL uses SSH (scp-like or ssh://)
+func IsSSHRemoteURL(remoteURL string) bool {
+	raw := strings.TrimSpace(remoteURL)
+	if strings.Contains(raw, "://") {
+		return strings.HasPrefix(raw, "ssh://")
+	}
+	return strings.Contains(raw, ":")
+}
+
+// WebURL returns the browser URL of the repository
+func (r *Remote) WebURL() string {
+	return fmt.Sprintf("%s://%s/%s", r.Scheme, r.Host, r.Path)
+}
+
+// OwnerAndRepo splits the repository path into owner (namespace) and repo name
+func (r *Remote) OwnerAndRepo() (string, string) {
+	i := strings.LastIndex(r.Path, "/")
+	return r.Path[:i], r.Path[i+1:]
+}
+
+// DetectKind guesses the forge kind from the remote host
+// Unknown hosts default to GitHub (gh handles GitHub Enterprise hosts)
+func DetectKind(host string) string {
+	host = strings.ToLower(host)
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return KindGitLab
+	case strings.Contains(host, "gitea"), strings.Contains(host, "forgejo"), strings.Contains(host, "codeberg"):
+		return KindGitea
+	default:
+		return KindGitHub
+	}
+}
+
+// New creates the forge for a remote
+// kind may be empty to detect it from the remote host; token is the configured API token
+// for the host (may be empty, in which case environment variables are consulted)
+func New(remote *Remote, kind, token string) (Forge, error) {
+	if kind == "" {
+		kind = DetectKind(remote.Host)
+	}
+
+	switch kind {
+	case KindGitHub:
+		return NewGitHub(remote, github.ResolveToken(remote.Host, token)), nil
+	case KindGitLab:
+		return NewGitLab(remote), nil
+	case KindGitea:
+		if token == "" {
+			token = os.Getenv("GITEA_TOKEN")
+		}
+		if token == "" {
+			token = os.Getenv("FORGEJO_TOKEN")
+		}
+		return NewGitea(remote, token), nil
+	default:
+		return nil, fmt.Errorf("unknown forge: %s. Must be one of: github, gitlab, gitea", kind)
+	}
+}
+
+// ParsePRNumber extracts the PR number from a PR/MR URL
+// Supports GitHub (/pull/N), Gitea (/pulls/N), and GitLab (/-/merge_requests/N)
+// Returns 0 if the URL doesn't contain a PR number
+func ParsePRNumber(prURL string) int {
+	for _, marker := range []string{"/merge_requests/", "/pulls/", "/pull/"} {
+		if i := strings.LastIndex(prURL, marker); i >= 0 {
+			number := 0
+			fmt.Sscanf(prURL[i+len(marker):], "%d", &number)
+			return number
+		}
+	}
+	return 0
+}
+
+// ParsePRReference extracts the PR number from a reference typed by the user: "#42", "!42" (GitLab),
+// "pr/42", "pr-42", or a PR/MR URL
+// Returns false if the input doesn't refer to a PR
+func ParsePRReference(input string) (int, bool) {
+	if number := ParsePRNumber(input); number > 0 {
+		return number, true
+	}
+	lower := strings.ToLower(strings.TrimSpace(input))
+	for _, prefix := range []string{"#", "!", "pr/", "pr-"} {
+		if digits, ok := strings.CutPrefix(lower, prefix); ok {
+			number, err := strconv.Atoi(digits)
+			return number, err == nil && number > 0
+		}
+	}
+	return 0, false
+}
+
+// PullRefs returns the refs a forge publishes a PR under, in the order to try them: the merge ref
+// (the PR merged into its base, as CI tests it) where there is one, then the head ref (the PR's own commits)
+func PullRefs(kind string, number int) []string {
+	switch kind {
+	case KindGitLab:
+		return []string{fmt.Sprintf("refs/merge-requests/%d/merge", number), fmt.Sprintf("refs/merge-requests/%d/head", number)}
+	case KindGitea:
+		return []string{fmt.Sprintf("refs/pull/%d/head", number)}
+	default:
+		return []string{fmt.Sprintf("refs/pull/%d/merge", number), fmt.Sprintf("refs/pull/%d/head", number)}
+	}
+}
+
+// isValidMergeMethod checks the merge method against the supported set
+func isValidMergeMethod(mergeMethod string) bool {
+	switch mergeMethod {
+	case "squash", "merge", "rebase":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package forge
+
+import (
+	"testing"
+)
+
+// TestParseRemoteURL tests SSH and HTTP(S) remote parsing
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remoteURL string
+		webURL    string
+	}{
+		{"git@github.com:owner/repo.git", "https://github.com/owner/repo"},
+		{"https://github.com/owner/repo.git", "https://github.com/owner/repo"},
+		{"ssh://git@gitlab.example.com:2222/group/sub/repo.git", "https://gitlab.example.com/group/sub/repo"},
+		{"https://user@gitea.example.com/owner/repo", "https://gitea.example.com/owner/repo"},
+		{"http://127.0.0.1:3000/owner/repo.git", "http://127.0.0.1:3000/owner/repo"},
+	}
+
+	for _, tt := range tests {
+		remote, err := ParseRemoteURL(tt.remoteURL)
+		if err != nil {
+			t.Errorf("ParseRemoteURL(%q) returned error: %v", tt.remoteURL, err)
+			continue
+		}
+		if remote.WebURL() != tt.webURL {
+			t.Errorf("ParseRemoteURL(%q).WebURL() = %q, expected %q", tt.remoteURL, remote.WebURL(), tt.webURL)
+		}
+	}
+
+	if _, err := ParseRemoteURL("/local/path/repo"); err == nil {
+		t.Errorf("Expected error for local path remote")
+	}
+}
+
+// TestOwnerAndRepo tests splitting nested GitLab group paths
+func TestOwnerAndRepo(t *testing.T) {
+	remote := &Remote{Scheme: "https", Host: "gitlab.com", Path: "group/sub/repo"}
+	owner, repo := remote.OwnerAndRepo()
+	if owner != "group/sub" || repo != "repo" {
+		t.Errorf("Expected group/sub and repo, got %s and %s", owner, repo)
+	}
+}
+
+// TestIsSSHRemoteURL tests telling SSH remotes apart from HTTP(S) remotes
+func TestIsSSHRemoteURL(t *testing.T) {
+	tests := map[string]bool{
+		"git@github.com:owner/repo.git":       true,
+		"ssh://git@gitlab.com/group/repo.git": true,
+		"https://github.com/owner/repo.git":   false,
+		"http://gitea.local:3000/owner/repo":  false,
+	}
+	for remoteURL, want := range tests {
+		if got := IsSSHRemoteURL(remoteURL); got != want {
+			t.Errorf("IsSSHRemoteURL(%q) = %v, want %v", remoteURL, got, want)
+		}
+	}
+}
+
+// TestDetectKind tests forge detection from the remote host
+func TestDetectKind(t *testing.T) {
+	tests := map[string]string{
+		"github.com":          KindGitHub,
+		"github.example.com":  KindGitHub,
+		"gitlab.com":          KindGitLab,
+		"gitlab.internal.net": KindGitLab,
+		"gitea.example.com":   KindGitea,
+		"codeberg.org":        KindGitea,
+		"git.example.com":     KindGitHub,
+	}
+
+	for host, expected := range tests {
+		if kind := DetectKind(host); kind != expected {
+			t.Errorf("DetectKind(%q) = %q, expected %q", host, kind, expected)
+		}
+	}
+}
+
+// TestParsePRNumber tests PR number extraction for each forge's URL format
+func TestParsePRNumber(t *testing.T) {
+	tests := map[string]int{
+		"https://github.com/owner/repo/pull/42":                   42,
+		"https://gitea.example.com/owner/repo/pulls/7":            7,
+		"https://gitlab.com/group/repo/-/merge_requests/13":       13,
+		"https://gitlab.com/group/repo/-/merge_requests/13/diffs": 13,
+		"feature-branch": 0,
+	}
+
+	for prURL, expected := range tests {
+		if number := ParsePRNumber(prURL); number != expected {
+			t.Errorf("ParsePRNumber(%q) = %d, expected %d", prURL, number, expected)
+		}
+	}
+}
+
+// TestParsePRReference tests PR numbers typed as #N, !N, pr/N, or a PR URL
+func TestParsePRReference(t *testing.T) {
+	tests := map[string]int{
+		"#42":                                   42,
+		"!13":                                   13,
+		"PR/7":                                  7,
+		"pr-7":                                  7,
+		"https://github.com/owner/repo/pull/42": 42,
+		"v1.2.0":                                0,
+		"abc1234":                               0,
+		"#":                                     0,
+	}
+
+	for input, expected := range tests {
+		number, ok := ParsePRReference(input)
+		if ok != (expected > 0) || number != expected {
+			t.Errorf("ParsePRReference(%q) = %d, %v, expected %d", input, number, ok, expected)
+		}
+	}
+}
+
+// TestPullRefs tests that the merge ref is tried before the head ref where the forge has one
+func TestPullRefs(t *testing.T) {
+	if refs := PullRefs(KindGitHub, 42); len(refs) != 2 || refs[0] != "refs/pull/42/merge" || refs[1] != "refs/pull/42/head" {
+		t.Errorf("Unexpected GitHub refs: %v", refs)
+	}
+	if refs := PullRefs(KindGitLab, 13); len(refs) != 2 || refs[0] != "refs/merge-requests/13/merge" {
+		t.Errorf("Unexpected GitLab refs: %v", refs)
+	}
+	if refs := PullRefs(KindGitea, 7); len(refs) != 1 || refs[0] != "refs/pull/7/head" {
+		t.Errorf("Unexpected Gitea refs: %v", refs)
+	}
+}
+
+// TestNew_UnknownKind tests that an unknown forge override is rejected
+func TestNew_UnknownKind(t *testing.T) {
+	remote := &Remote{Scheme: "https", Host: "example.com", Path: "owner/repo"}
+	if _, err := New(remote, "bitbucket", ""); err == nil {
+		t.Errorf("Expected error for unknown forge kind")
+	}
+}
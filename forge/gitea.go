@@ -0,0 +1,512 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// draftTitlePrefixes are the title prefixes Gitea/Forgejo use to mark a PR as work in progress
+var draftTitlePrefixes = []string{"WIP:", "[WIP]", "Draft:", "[Draft]"}
+
+// Gitea implements Forge using the Gitea/Forgejo REST API
+type Gitea struct {
+	remote *Remote
+	token  string
+	apiURL string
+	client *http.Client
+}
+
+// giteaPR is the JSON shape of a pull request returned by the Gitea API
+type giteaPR struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"` // "open" or "closed"
+	Merged  bool   `json:"merged"`
+	Head    struct {
+		Ref  string `json:"ref"`
+		Repo struct {
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"repo"`
+	} `json:"head"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// NewGitea creates a Gitea/Forgejo forge for a remote
+// token may be empty for read-only access to public repositories
+func NewGitea(remote *Remote, token string) *Gitea {
+	return &Gitea{
+		remote: remote,
+		token:  token,
+		apiURL: fmt.Sprintf("%s://%s/api/v1", remote.Scheme, remote.Host),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the platform name
+func (g *Gitea) Name() string {
+	return "Gitea"
+}
+
+// RepoURL returns the repository URL
+func (g *Gitea) RepoURL() string {
+	return g.remote.WebURL()
+}
+
+// BranchURL returns the browser URL for a branch
+func (g *Gitea) BranchURL(branch string) string {
+	return g.RepoURL() + "/src/branch/" + branch
+}
+
+// CreatePR creates a pull request; drafts are marked with a "WIP:" title prefix
+func (g *Gitea) CreatePR(worktreePath, branch, baseBranch, title, description string, isDraft bool) (string, error) {
+	if isDraft {
+		title = "WIP: " + title
+	}
+
+	body := map[string]string{
+		"head":  branch,
+		"base":  baseBranch,
+		"title": title,
+		"body":  description,
+	}
+
+	var pr giteaPR
+	if err := g.do("POST", g.repoPath("/pulls"), body, &pr); err != nil {
+		return "", fmt.Errorf("failed to create PR: %w", err)
+	}
+
+	return pr.HTMLURL, nil
+}
+
+// CreateFork forks the repository into the authenticated user's account
+func (g *Gitea) CreateFork(worktreePath string, useSSH bool) (string, error) {
+	var fork struct {
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+	}
+	if err := g.do("POST", g.repoPath("/forks"), map[string]string{}, &fork); err != nil {
+		return "", fmt.Errorf("failed to create fork: %w", err)
+	}
+
+	if useSSH {
+		return fork.SSHURL, nil
+	}
+	return fork.CloneURL, nil
+}
+
+// UpdatePR updates the title and/or description of an existing PR
+func (g *Gitea) UpdatePR(worktreePath, prIdentifier, title, description string) error {
+	number, err := g.resolvePRNumber(worktreePath, prIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to update PR: %w", err)
+	}
+
+	body := map[string]string{}
+	if title != "" {
+		body["title"] = title
+	}
+	if description != "" {
+		body["body"] = description
+	}
+
+	if err := g.do("PATCH", g.repoPath(fmt.Sprintf("/pulls/%d", number)), body, nil); err != nil {
+		return fmt.Errorf("failed to update PR: %w", err)
+	}
+
+	return nil
+}
+
+// GetPRStatus gets the current status of a pull request
+func (g *Gitea) GetPRStatus(prURL string) (string, error) {
+	pr, err := g.getPR(ParsePRNumber(prURL))
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR status: %w", err)
+	}
+
+	return pr.status(), nil
+}
+
+// GetPRBody gets the current description of a pull request
+func (g *Gitea) GetPRBody(worktreePath, prURL string) (string, error) {
+	number, err := g.resolvePRNumber(worktreePath, prURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR description: %w", err)
+	}
+
+	pr, err := g.getPR(number)
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR description: %w", err)
+	}
+	return pr.Body, nil
+}
+
+// GetPRForBranch gets the PR details for a given branch (if it exists)
+// branch may be "owner:branch" for a branch in a fork of the repository
+func (g *Gitea) GetPRForBranch(worktreePath, branch string) (*PRInfo, error) {
+	var prs []giteaPR
+	if err := g.do("GET", g.repoPath("/pulls?state=all&sort=recentupdate&limit=50"), nil, &prs); err != nil {
+		return nil, fmt.Errorf("failed to search for PR: %w", err)
+	}
+
+	owner, forkBranch, isFork := strings.Cut(branch, ":")
+	if isFork {
+		branch = forkBranch
+	}
+	for _, pr := range prs {
+		if pr.Head.Ref == branch && (!isFork || strings.EqualFold(pr.Head.Repo.Owner.Login, owner)) {
+			prInfo := pr.toPRInfo()
+			return &prInfo, nil
+		}
+	}
+
+	return nil, nil // No PR found
+}
+
+// ListPRs lists open pull requests for the repository
+func (g *Gitea) ListPRs(worktreePath string) ([]PRInfo, error) {
+	// Only 5 latest to avoid cluttering the screen
+	var prs []giteaPR
+	if err := g.do("GET", g.repoPath("/pulls?state=open&sort=recentupdate&limit=5"), nil, &prs); err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	result := make([]PRInfo, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, pr.toPRInfo())
+	}
+	return result, nil
+}
+
+// MarkPRReady removes the work-in-progress prefix from the PR title
+func (g *Gitea) MarkPRReady(worktreePath, prURL string) error {
+	number := ParsePRNumber(prURL)
+	pr, err := g.getPR(number)
+	if err != nil {
+		return fmt.Errorf("failed to mark PR as ready: %w", err)
+	}
+
+	title := stripDraftPrefix(pr.Title)
+	if title == pr.Title {
+		return nil // Already ready for review
+	}
+
+	body := map[string]string{"title": title}
+	if err := g.do("PATCH", g.repoPath(fmt.Sprintf("/pulls/%d", number)), body, nil); err != nil {
+		return fmt.Errorf("failed to mark PR as ready: %w", err)
+	}
+
+	return nil
+}
+
+// MergePR merges a pull request using the specified merge method
+func (g *Gitea) MergePR(worktreePath, prURL, mergeMethod string) error {
+	if !isValidMergeMethod(mergeMethod) {
+		return fmt.Errorf("invalid merge method: %s. Must be one of: squash, merge, rebase", mergeMethod)
+	}
+
+	body := map[string]string{"Do": mergeMethod}
+	path := g.repoPath(fmt.Sprintf("/pulls/%d/merge", ParsePRNumber(prURL)))
+	if err := g.do("POST", path, body, nil); err != nil {
+		return fmt.Errorf("failed to merge PR: %w", err)
+	}
+
+	return nil
+}
+
+// EnableAutoMerge schedules the PR to merge once all checks succeed
+func (g *Gitea) EnableAutoMerge(worktreePath, prURL, mergeMethod string) error {
+	if !isValidMergeMethod(mergeMethod) {
+		return fmt.Errorf("invalid merge method: %s. Must be one of: squash, merge, rebase", mergeMethod)
+	}
+
+	body := map[string]interface{}{
+		"Do":                        mergeMethod,
+		"merge_when_checks_succeed": true,
+	}
+	path := g.repoPath(fmt.Sprintf("/pulls/%d/merge", ParsePRNumber(prURL)))
+	if err := g.do("POST", path, body, nil); err != nil {
+		return fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// DisableAutoMerge cancels a scheduled auto-merge
+func (g *Gitea) DisableAutoMerge(worktreePath, prURL string) error {
+	path := g.repoPath(fmt.Sprintf("/pulls/%d/merge", ParsePRNumber(prURL)))
+	if err := g.do("DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// AddToMergeQueue is not available on Gitea/Forgejo
+func (g *Gitea) AddToMergeQueue(worktreePath, prURL string) error {
+	return fmt.Errorf("merge queues are not supported on Gitea. Use auto-merge instead")
+}
+
+// SetPRMetadata adds reviewers, labels, and assignees to a PR and sets its milestone
+// Reviewers in "org/team" form are requested as team reviewers
+func (g *Gitea) SetPRMetadata(worktreePath, prURL string, meta PRMetadata) error {
+	if meta.IsEmpty() {
+		return nil
+	}
+
+	number, err := g.resolvePRNumber(worktreePath, prURL)
+	if err != nil {
+		return fmt.Errorf("failed to set PR metadata: %w", err)
+	}
+
+	if len(meta.Reviewers) > 0 {
+		users, teams := []string{}, []string{}
+		for _, reviewer := range meta.Reviewers {
+			if _, team, ok := strings.Cut(reviewer, "/"); ok {
+				teams = append(teams, team)
+			} else {
+				users = append(users, reviewer)
+			}
+		}
+		body := map[string][]string{"reviewers": users, "team_reviewers": teams}
+		if err := g.do("POST", g.repoPath(fmt.Sprintf("/pulls/%d/requested_reviewers", number)), body, nil); err != nil {
+			return fmt.Errorf("failed to request reviewers: %w", err)
+		}
+	}
+
+	if len(meta.Labels) > 0 {
+		ids, err := g.labelIDs(meta.Labels)
+		if err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+		body := map[string][]int64{"labels": ids}
+		if err := g.do("POST", g.repoPath(fmt.Sprintf("/issues/%d/labels", number)), body, nil); err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+
+	// Assignees and milestone are set on the PR's issue; assignees replace the existing
+	// set there, so merge with the current assignees to keep "add" semantics
+	if len(meta.Assignees) > 0 || meta.Milestone != "" {
+		var issue struct {
+			Assignees []struct {
+				Login string `json:"login"`
+			} `json:"assignees"`
+		}
+		if err := g.do("GET", g.repoPath(fmt.Sprintf("/issues/%d", number)), nil, &issue); err != nil {
+			return fmt.Errorf("failed to set assignees: %w", err)
+		}
+
+		body := map[string]interface{}{}
+		if len(meta.Assignees) > 0 {
+			assignees := append([]string{}, meta.Assignees...)
+			for _, assignee := range issue.Assignees {
+				if !containsFold(assignees, assignee.Login) {
+					assignees = append(assignees, assignee.Login)
+				}
+			}
+			body["assignees"] = assignees
+		}
+		if meta.Milestone != "" {
+			milestone, err := g.findMilestone(meta.Milestone)
+			if err != nil {
+				return fmt.Errorf("failed to set milestone: %w", err)
+			}
+			body["milestone"] = milestone
+		}
+
+		if err := g.do("PATCH", g.repoPath(fmt.Sprintf("/issues/%d", number)), body, nil); err != nil {
+			return fmt.Errorf("failed to set assignees or milestone: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// labelIDs resolves label names to the repository's label IDs
+func (g *Gitea) labelIDs(names []string) ([]int64, error) {
+	var labels []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := g.do("GET", g.repoPath("/labels?limit=100"), nil, &labels); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, label := range labels {
+			if strings.EqualFold(label.Name, name) {
+				ids = append(ids, label.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("label %q not found", name)
+		}
+	}
+	return ids, nil
+}
+
+// findMilestone returns the ID of the open milestone with the given title
+func (g *Gitea) findMilestone(title string) (int64, error) {
+	var milestones []struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title"`
+	}
+	if err := g.do("GET", g.repoPath("/milestones?state=open&limit=100"), nil, &milestones); err != nil {
+		return 0, err
+	}
+
+	for _, milestone := range milestones {
+		if strings.EqualFold(milestone.Title, title) {
+			return milestone.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("milestone %q not found", title)
+}
+
+// getPR fetches a single pull request by number
+func (g *Gitea) getPR(number int) (*giteaPR, error) {
+	if number <= 0 {
+		return nil, fmt.Errorf("invalid PR number")
+	}
+
+	var pr giteaPR
+	if err := g.do("GET", g.repoPath(fmt.Sprintf("/pulls/%d", number)), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// resolvePRNumber accepts a PR URL, number, or head branch name
+func (g *Gitea) resolvePRNumber(worktreePath, prIdentifier string) (int, error) {
+	if number := ParsePRNumber(prIdentifier); number > 0 {
+		return number, nil
+	}
+	if number, err := strconv.Atoi(prIdentifier); err == nil {
+		return number, nil
+	}
+
+	pr, err := g.GetPRForBranch(worktreePath, prIdentifier)
+	if err != nil {
+		return 0, err
+	}
+	if pr == nil {
+		return 0, fmt.Errorf("no PR found for branch %s", prIdentifier)
+	}
+	return pr.Number, nil
+}
+
+// repoPath builds an API path under /repos/{owner}/{repo}
+func (g *Gitea) repoPath(suffix string) string {
+	owner, repo := g.remote.OwnerAndRepo()
+	return fmt.Sprintf("/repos/%s/%s%s", owner, repo, suffix)
+}
+
+// do sends an API request, encoding body as JSON and decoding the response into out (if not nil)
+func (g *Gitea) do(method, path string, body interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, g.apiURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.token != "" {
+		req.Header.Set("Authorization", "token "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s (HTTP %d)", apiErr.Message, resp.StatusCode)
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// status maps a Gitea PR to jean's "open", "merged", "closed"
+func (pr giteaPR) status() string {
+	if pr.Merged {
+		return "merged"
+	}
+	return strings.ToLower(pr.State)
+}
+
+// toPRInfo converts a Gitea PR to the shared PRInfo shape
+func (pr giteaPR) toPRInfo() PRInfo {
+	prInfo := PRInfo{
+		Number:      pr.Number,
+		Title:       pr.Title,
+		HeadRefName: pr.Head.Ref,
+		URL:         pr.HTMLURL,
+		Status:      strings.ToUpper(pr.status()),
+	}
+	prInfo.Author.Login = pr.User.Login
+	return prInfo
+}
+
+// stripDraftPrefix removes a work-in-progress marker from a PR title
+func stripDraftPrefix(title string) string {
+	for _, prefix := range draftTitlePrefixes {
+		if len(title) >= len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+			return strings.TrimSpace(title[len(prefix):])
+		}
+	}
+	return title
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
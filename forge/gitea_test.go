@@ -0,0 +1,190 @@
+package forge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestGitea starts a local HTTP stand-in for the Gitea API and returns a forge pointing at it
+func newTestGitea(t *testing.T, handler http.HandlerFunc) *Gitea {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	remote, err := ParseRemoteURL(server.URL + "/owner/repo.git")
+	if err != nil {
+		t.Fatalf("Failed to parse test remote: %v", err)
+	}
+	return NewGitea(remote, "secret")
+}
+
+// TestGitea_CreatePR tests PR creation, draft prefix, and token auth
+func TestGitea_CreatePR(t *testing.T) {
+	g := newTestGitea(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/repos/owner/repo/pulls" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "token secret" {
+			t.Errorf("Expected token auth header, got %q", r.Header.Get("Authorization"))
+		}
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["head"] != "feature" || body["base"] != "main" {
+			t.Errorf("Unexpected head/base: %s/%s", body["head"], body["base"])
+		}
+		if body["title"] != "WIP: Add feature" {
+			t.Errorf("Expected draft title prefix, got %q", body["title"])
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number": 3, "html_url": "https://gitea.example.com/owner/repo/pulls/3"}`))
+	})
+
+	prURL, err := g.CreatePR("", "feature", "main", "Add feature", "body", true)
+	if err != nil {
+		t.Fatalf("CreatePR returned error: %v", err)
+	}
+	if prURL != "https://gitea.example.com/owner/repo/pulls/3" {
+		t.Errorf("Unexpected PR URL: %s", prURL)
+	}
+}
+
+// TestGitea_CreateFork tests forking and picking the clone URL protocol
+func TestGitea_CreateFork(t *testing.T) {
+	g := newTestGitea(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/repos/owner/repo/forks" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"clone_url": "https://gitea.example.com/me/repo.git", "ssh_url": "git@gitea.example.com:me/repo.git"}`))
+	})
+
+	forkURL, err := g.CreateFork("", true)
+	if err != nil {
+		t.Fatalf("CreateFork returned error: %v", err)
+	}
+	if forkURL != "git@gitea.example.com:me/repo.git" {
+		t.Errorf("Expected SSH clone URL, got %s", forkURL)
+	}
+}
+
+// TestGitea_GetPRStatus tests status mapping for merged PRs
+func TestGitea_GetPRStatus(t *testing.T) {
+	g := newTestGitea(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/owner/repo/pulls/5" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"number": 5, "state": "closed", "merged": true}`))
+	})
+
+	status, err := g.GetPRStatus("https://gitea.example.com/owner/repo/pulls/5")
+	if err != nil {
+		t.Fatalf("GetPRStatus returned error: %v", err)
+	}
+	if status != "merged" {
+		t.Errorf("Expected status merged, got %s", status)
+	}
+}
+
+// TestGitea_GetPRForBranch tests finding a PR by head branch
+func TestGitea_GetPRForBranch(t *testing.T) {
+	g := newTestGitea(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"number": 1, "title": "Other", "state": "open", "head": {"ref": "other"}},
+			{"number": 3, "title": "Fork", "state": "open", "head": {"ref": "other", "repo": {"owner": {"login": "contributor"}}}},
+			{"number": 2, "title": "Mine", "state": "open", "html_url": "https://gitea.example.com/owner/repo/pulls/2", "head": {"ref": "feature"}, "user": {"login": "jean"}}
+		]`))
+	})
+
+	pr, err := g.GetPRForBranch("", "feature")
+	if err != nil {
+		t.Fatalf("GetPRForBranch returned error: %v", err)
+	}
+	if pr == nil || pr.Number != 2 || pr.Author.Login != "jean" || pr.Status != "OPEN" {
+		t.Errorf("Unexpected PR: %+v", pr)
+	}
+
+	// Only the PR from the contributor's fork, not the repository's own branch of that name
+	pr, err = g.GetPRForBranch("", "contributor:other")
+	if err != nil || pr == nil || pr.Number != 3 {
+		t.Errorf("Expected PR 3 from the contributor's fork, got %+v (err: %v)", pr, err)
+	}
+
+	pr, err = g.GetPRForBranch("", "missing")
+	if err != nil || pr != nil {
+		t.Errorf("Expected no PR for missing branch, got %+v (err: %v)", pr, err)
+	}
+}
+
+// TestGitea_UpdatePRByBranch tests resolving a branch name to a PR number before updating
+func TestGitea_UpdatePRByBranch(t *testing.T) {
+	patched := false
+	g := newTestGitea(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`[{"number": 9, "state": "open", "head": {"ref": "feature"}}]`))
+		case "PATCH":
+			if r.URL.Path != "/api/v1/repos/owner/repo/pulls/9" {
+				t.Errorf("Unexpected path: %s", r.URL.Path)
+			}
+			patched = true
+			_, _ = w.Write([]byte(`{"number": 9}`))
+		}
+	})
+
+	if err := g.UpdatePR("", "feature", "New title", ""); err != nil {
+		t.Fatalf("UpdatePR returned error: %v", err)
+	}
+	if !patched {
+		t.Errorf("Expected PR to be patched")
+	}
+}
+
+// TestGitea_MarkPRReady tests stripping the draft prefix from the title
+func TestGitea_MarkPRReady(t *testing.T) {
+	var newTitle string
+	g := newTestGitea(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`{"number": 4, "title": "WIP: Add feature", "state": "open"}`))
+		case "PATCH":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			newTitle = body["title"]
+			_, _ = w.Write([]byte(`{"number": 4}`))
+		}
+	})
+
+	if err := g.MarkPRReady("", "https://gitea.example.com/owner/repo/pulls/4"); err != nil {
+		t.Fatalf("MarkPRReady returned error: %v", err)
+	}
+	if newTitle != "Add feature" {
+		t.Errorf("Expected title without prefix, got %q", newTitle)
+	}
+}
+
+// TestGitea_MergePRError tests that API error messages are surfaced
+func TestGitea_MergePRError(t *testing.T) {
+	g := newTestGitea(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["Do"] != "squash" {
+			t.Errorf("Expected squash merge, got %q", body["Do"])
+		}
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_, _ = w.Write([]byte(`{"message": "Please try again later"}`))
+	})
+
+	err := g.MergePR("", "https://gitea.example.com/owner/repo/pulls/4", "squash")
+	if err == nil || !strings.Contains(err.Error(), "Please try again later") {
+		t.Errorf("Expected API error message, got %v", err)
+	}
+
+	if err := g.MergePR("", "https://gitea.example.com/owner/repo/pulls/4", "octopus"); err == nil {
+		t.Errorf("Expected error for invalid merge method")
+	}
+}
@@ -0,0 +1,169 @@
+package forge
+
+import (
+	"fmt"
+
+	"github.com/coollabsio/jean-tui/github"
+)
+
+// GitHub implements Forge using the GitHub API when a token is available,
+// falling back to the gh CLI otherwise
+type GitHub struct {
+	cli    *github.Manager
+	api    *github.Client // nil when no token is available
+	remote *Remote
+}
+
+// NewGitHub creates a GitHub forge for a remote
+// token may be empty to use the gh CLI for every operation
+func NewGitHub(remote *Remote, token string) *GitHub {
+	g := &GitHub{
+		cli:    github.NewManagerForRepo(remote.Host + "/" + remote.Path),
+		remote: remote,
+	}
+
+	if token != "" {
+		owner, repo := remote.OwnerAndRepo()
+		g.api = github.NewClient(remote.Host, owner, repo, token)
+	}
+
+	return g
+}
+
+// Name returns the platform name
+func (g *GitHub) Name() string {
+	return "GitHub"
+}
+
+// RepoURL returns the repository URL
+func (g *GitHub) RepoURL() string {
+	return g.remote.WebURL()
+}
+
+// BranchURL returns the browser URL for a branch
+func (g *GitHub) BranchURL(branch string) string {
+	return g.RepoURL() + "/tree/" + branch
+}
+
+// CreatePR creates a pull request (draft or ready for review)
+func (g *GitHub) CreatePR(worktreePath, branch, baseBranch, title, description string, isDraft bool) (string, error) {
+	if g.api != nil {
+		return g.api.CreatePR(branch, baseBranch, title, description, isDraft)
+	}
+	return g.cli.CreatePR(worktreePath, branch, baseBranch, title, description, isDraft)
+}
+
+// CreateFork forks the repository into the authenticated user's account
+func (g *GitHub) CreateFork(worktreePath string, useSSH bool) (string, error) {
+	if g.api != nil {
+		return g.api.CreateFork(useSSH)
+	}
+	owner, repo := g.remote.OwnerAndRepo()
+	return g.cli.CreateFork(g.remote.Host, owner, repo, useSSH)
+}
+
+// UpdatePR updates the title and/or description of an existing PR
+func (g *GitHub) UpdatePR(worktreePath, prIdentifier, title, description string) error {
+	if g.api != nil {
+		return g.api.UpdatePR(prIdentifier, title, description)
+	}
+	return g.cli.UpdatePR(worktreePath, prIdentifier, title, description)
+}
+
+// GetPRStatus gets the current status of a pull request
+func (g *GitHub) GetPRStatus(prURL string) (string, error) {
+	if g.api != nil {
+		return g.api.GetPRStatus(prURL)
+	}
+	return g.cli.GetPRStatus(prURL)
+}
+
+// GetPRBody gets the current description of a pull request
+func (g *GitHub) GetPRBody(worktreePath, prURL string) (string, error) {
+	if g.api != nil {
+		return g.api.GetPRBody(prURL)
+	}
+	return g.cli.GetPRBody(worktreePath, prURL)
+}
+
+// GetPRForBranch gets the PR details for a given branch (if it exists)
+func (g *GitHub) GetPRForBranch(worktreePath, branch string) (*PRInfo, error) {
+	if g.api != nil {
+		return g.api.GetPRForBranch(branch)
+	}
+	return g.cli.GetPRForBranch(worktreePath, branch)
+}
+
+// GetPRsForBranches looks up PRs for many branches in a single GraphQL query
+// Returns ErrBatchUnsupported without a token, since gh would need one call per branch
+func (g *GitHub) GetPRsForBranches(worktreePath string, branches []string) (map[string]*PRInfo, error) {
+	if g.api == nil {
+		return nil, ErrBatchUnsupported
+	}
+	return g.api.GetPRsForBranches(branches)
+}
+
+// ListPRs lists open pull requests for the repository
+func (g *GitHub) ListPRs(worktreePath string) ([]PRInfo, error) {
+	if g.api != nil {
+		return g.api.ListPRs()
+	}
+	return g.cli.ListPRs(worktreePath)
+}
+
+// MarkPRReady converts a draft PR to ready for review
+func (g *GitHub) MarkPRReady(worktreePath, prURL string) error {
+	if g.api != nil {
+		return g.api.MarkPRReady(prURL)
+	}
+	return g.cli.MarkPRReady(worktreePath, prURL)
+}
+
+// MergePR merges a pull request using the specified merge method
+func (g *GitHub) MergePR(worktreePath, prURL, mergeMethod string) error {
+	if !isValidMergeMethod(mergeMethod) {
+		return fmt.Errorf("invalid merge method: %s. Must be one of: squash, merge, rebase", mergeMethod)
+	}
+	if g.api != nil {
+		return g.api.MergePR(prURL, mergeMethod)
+	}
+	return g.cli.MergePR(worktreePath, prURL, mergeMethod)
+}
+
+// SetPRMetadata adds reviewers, labels, and assignees to a PR and sets its milestone
+func (g *GitHub) SetPRMetadata(worktreePath, prURL string, meta PRMetadata) error {
+	if meta.IsEmpty() {
+		return nil
+	}
+	if g.api != nil {
+		return g.api.SetPRMetadata(prURL, meta)
+	}
+	return g.cli.SetPRMetadata(worktreePath, prURL, meta)
+}
+
+// EnableAutoMerge enables auto-merge with the given method
+func (g *GitHub) EnableAutoMerge(worktreePath, prURL, mergeMethod string) error {
+	if !isValidMergeMethod(mergeMethod) {
+		return fmt.Errorf("invalid merge method: %s. Must be one of: squash, merge, rebase", mergeMethod)
+	}
+	if g.api != nil {
+		return g.api.EnableAutoMerge(prURL, mergeMethod)
+	}
+	return g.cli.EnableAutoMerge(worktreePath, prURL, mergeMethod)
+}
+
+// DisableAutoMerge cancels a pending auto-merge
+func (g *GitHub) DisableAutoMerge(worktreePath, prURL string) error {
+	if g.api != nil {
+		return g.api.DisableAutoMerge(prURL)
+	}
+	return g.cli.DisableAutoMerge(worktreePath, prURL)
+}
+
+// AddToMergeQueue adds the PR to the base branch's merge queue
+func (g *GitHub) AddToMergeQueue(worktreePath, prURL string) error {
+	if g.api != nil {
+		return g.api.AddToMergeQueue(prURL)
+	}
+	return g.cli.AddToMergeQueue(worktreePath, prURL)
+}
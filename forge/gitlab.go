@@ -0,0 +1,385 @@
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// GitLab implements Forge using the glab CLI
+type GitLab struct {
+	remote    *Remote
+	glabOnce  sync.Once // Looks up glab once (see IsGlabInstalled)
+	glabFound bool
+}
+
+// glabMR is the JSON shape of a merge request returned by glab
+type glabMR struct {
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	SourceBranch    string `json:"source_branch"`
+	SourceProjectID int    `json:"source_project_id"`
+	TargetProjectID int    `json:"target_project_id"`
+	WebURL          string `json:"web_url"`
+	State           string `json:"state"` // "opened", "merged", "closed", or "locked"
+	Author          struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// NewGitLab creates a GitLab forge for a remote
+func NewGitLab(remote *Remote) *GitLab {
+	return &GitLab{remote: remote}
+}
+
+// Name returns the platform name
+func (g *GitLab) Name() string {
+	return "GitLab"
+}
+
+// RepoURL returns the repository URL
+func (g *GitLab) RepoURL() string {
+	return g.remote.WebURL()
+}
+
+// BranchURL returns the browser URL for a branch
+func (g *GitLab) BranchURL(branch string) string {
+	return g.RepoURL() + "/-/tree/" + branch
+}
+
+// IsGlabInstalled checks if glab CLI is installed
+func (g *GitLab) IsGlabInstalled() bool {
+	g.glabOnce.Do(func() {
+		_, err := exec.LookPath("glab")
+		g.glabFound = err == nil
+	})
+	return g.glabFound
+}
+
+// run executes glab in the given directory and returns its combined output
+func (g *GitLab) run(dir string, args ...string) ([]byte, error) {
+	if !g.IsGlabInstalled() {
+		return nil, fmt.Errorf("glab CLI is not installed. Install it from https://gitlab.com/gitlab-org/cli")
+	}
+
+	cmd := exec.Command("glab", args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// CreatePR creates a merge request (draft or ready for review)
+func (g *GitLab) CreatePR(worktreePath, branch, baseBranch, title, description string, isDraft bool) (string, error) {
+	args := []string{"mr", "create"}
+
+	// A branch in a fork ("owner:branch") is opened from the fork against this project
+	// The fork is assumed to keep the project's name
+	if owner, forkBranch, ok := strings.Cut(branch, ":"); ok {
+		_, repo := g.remote.OwnerAndRepo()
+		args = append(args, "--repo", g.remote.Path, "--head", owner+"/"+repo)
+		branch = forkBranch
+	}
+
+	args = append(args,
+		"--source-branch", branch,
+		"--target-branch", baseBranch,
+		"--title", title,
+		"--description", description,
+		"--yes",
+	)
+
+	if isDraft {
+		args = append(args, "--draft")
+	}
+
+	output, err := g.run(worktreePath, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create MR: %s", string(output))
+	}
+
+	// glab prints progress lines before the MR URL, so pick the URL line
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "http") && strings.Contains(line, "/merge_requests/") {
+			return line, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to find MR URL in glab output: %s", string(output))
+}
+
+// CreateFork forks the project into the authenticated user's namespace
+func (g *GitLab) CreateFork(worktreePath string, useSSH bool) (string, error) {
+	output, err := g.run(worktreePath, "api", "-X", "POST", "projects/"+url.PathEscape(g.remote.Path)+"/fork")
+	if err != nil {
+		return "", fmt.Errorf("failed to create fork: %s", string(output))
+	}
+
+	var fork struct {
+		HTTPURL string `json:"http_url_to_repo"`
+		SSHURL  string `json:"ssh_url_to_repo"`
+	}
+	if err := json.Unmarshal(output, &fork); err != nil {
+		return "", fmt.Errorf("failed to parse fork: %w", err)
+	}
+
+	if useSSH {
+		return fork.SSHURL, nil
+	}
+	return fork.HTTPURL, nil
+}
+
+// UpdatePR updates the title and/or description of an existing merge request
+func (g *GitLab) UpdatePR(worktreePath, prIdentifier, title, description string) error {
+	if strings.Contains(prIdentifier, ":") && !strings.Contains(prIdentifier, "://") {
+		// glab looks branches up in the current project only, so resolve a fork's head to its MR
+		mr, err := g.GetPRForBranch(worktreePath, prIdentifier)
+		if err != nil {
+			return fmt.Errorf("failed to update MR: %w", err)
+		}
+		if mr == nil {
+			return fmt.Errorf("failed to update MR: no MR found for branch %s", prIdentifier)
+		}
+		prIdentifier = mr.URL
+	}
+	args := []string{"mr", "update", g.mrIdentifier(prIdentifier)}
+
+	if title != "" {
+		args = append(args, "--title", title)
+	}
+
+	if description != "" {
+		args = append(args, "--description", description)
+	}
+
+	output, err := g.run(worktreePath, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update MR: %s", string(output))
+	}
+
+	return nil
+}
+
+// GetPRStatus gets the current status of a merge request
+func (g *GitLab) GetPRStatus(prURL string) (string, error) {
+	output, err := g.run("", "mr", "view", g.mrIdentifier(prURL), "--repo", g.projectURL(prURL), "--output", "json")
+	if err != nil {
+		return "", fmt.Errorf("failed to get MR status: %s", string(output))
+	}
+
+	var mr glabMR
+	if err := json.Unmarshal(output, &mr); err != nil {
+		return "", fmt.Errorf("failed to parse MR status: %w", err)
+	}
+
+	return normalizeGitLabState(mr.State), nil
+}
+
+// GetPRBody gets the current description of a merge request
+func (g *GitLab) GetPRBody(worktreePath, prURL string) (string, error) {
+	output, err := g.run(worktreePath, "mr", "view", g.mrIdentifier(prURL), "--repo", g.projectURL(prURL), "--output", "json")
+	if err != nil {
+		return "", fmt.Errorf("failed to get MR description: %s", string(output))
+	}
+
+	var mr glabMR
+	if err := json.Unmarshal(output, &mr); err != nil {
+		return "", fmt.Errorf("failed to parse MR description: %w", err)
+	}
+
+	return mr.Description, nil
+}
+
+// GetPRForBranch gets the MR details for a given branch (if it exists)
+// branch may be "owner:branch" for a branch in a fork, matching only MRs opened from a fork
+func (g *GitLab) GetPRForBranch(worktreePath, branch string) (*PRInfo, error) {
+	args := []string{"mr", "list"}
+	_, forkBranch, isFork := strings.Cut(branch, ":")
+	if isFork {
+		// The MR is in this project, not in the fork glab may pick from the remotes
+		args = append(args, "--repo", g.remote.Path)
+		branch = forkBranch
+	}
+	args = append(args, "--source-branch", branch, "--all", "--output", "json")
+	output, err := g.run(worktreePath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for MR: %s", string(output))
+	}
+
+	var mrs []glabMR
+	if err := json.Unmarshal(output, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to parse MR info: %w", err)
+	}
+
+	for _, mr := range mrs {
+		if !isFork || mr.SourceProjectID != mr.TargetProjectID {
+			prInfo := mr.toPRInfo()
+			return &prInfo, nil
+		}
+	}
+	return nil, nil // No MR found
+}
+
+// ListPRs lists open merge requests for the repository
+func (g *GitLab) ListPRs(worktreePath string) ([]PRInfo, error) {
+	// Only 5 latest to avoid cluttering the screen
+	output, err := g.run(worktreePath, "mr", "list", "--output", "json", "--per-page", "5")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MRs: %s", string(output))
+	}
+
+	var mrs []glabMR
+	if err := json.Unmarshal(output, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to parse MR list: %v", err)
+	}
+
+	prs := make([]PRInfo, 0, len(mrs))
+	for _, mr := range mrs {
+		prs = append(prs, mr.toPRInfo())
+	}
+	return prs, nil
+}
+
+// MarkPRReady removes the draft status of a merge request
+func (g *GitLab) MarkPRReady(worktreePath, prURL string) error {
+	output, err := g.run(worktreePath, "mr", "update", g.mrIdentifier(prURL), "--ready")
+	if err != nil {
+		return fmt.Errorf("failed to mark MR as ready: %s", string(output))
+	}
+	return nil
+}
+
+// MergePR merges a merge request using the specified merge method
+func (g *GitLab) MergePR(worktreePath, prURL, mergeMethod string) error {
+	if !isValidMergeMethod(mergeMethod) {
+		return fmt.Errorf("invalid merge method: %s. Must be one of: squash, merge, rebase", mergeMethod)
+	}
+
+	args := []string{"mr", "merge", g.mrIdentifier(prURL), "--yes"}
+	switch mergeMethod {
+	case "squash":
+		args = append(args, "--squash")
+	case "rebase":
+		args = append(args, "--rebase")
+	}
+
+	output, err := g.run(worktreePath, args...)
+	if err != nil {
+		return fmt.Errorf("failed to merge MR: %s", string(output))
+	}
+
+	return nil
+}
+
+// SetPRMetadata adds reviewers, labels, and assignees to a MR and sets its milestone
+// GitLab has no team reviewers, so "group/name" reviewers are passed through as-is
+func (g *GitLab) SetPRMetadata(worktreePath, prURL string, meta PRMetadata) error {
+	if meta.IsEmpty() {
+		return nil
+	}
+
+	args := []string{"mr", "update", g.mrIdentifier(prURL)}
+	if len(meta.Reviewers) > 0 {
+		args = append(args, "--reviewer", "+"+strings.Join(meta.Reviewers, ",+"))
+	}
+	if len(meta.Labels) > 0 {
+		args = append(args, "--label", strings.Join(meta.Labels, ","))
+	}
+	if len(meta.Assignees) > 0 {
+		args = append(args, "--assignee", "+"+strings.Join(meta.Assignees, ",+"))
+	}
+	if meta.Milestone != "" {
+		args = append(args, "--milestone", meta.Milestone)
+	}
+
+	output, err := g.run(worktreePath, args...)
+	if err != nil {
+		return fmt.Errorf("failed to set MR metadata: %s", string(output))
+	}
+
+	return nil
+}
+
+// EnableAutoMerge sets the MR to merge once its pipeline succeeds
+func (g *GitLab) EnableAutoMerge(worktreePath, prURL, mergeMethod string) error {
+	if !isValidMergeMethod(mergeMethod) {
+		return fmt.Errorf("invalid merge method: %s. Must be one of: squash, merge, rebase", mergeMethod)
+	}
+
+	args := []string{"mr", "merge", g.mrIdentifier(prURL), "--auto-merge", "--yes"}
+	switch mergeMethod {
+	case "squash":
+		args = append(args, "--squash")
+	case "rebase":
+		args = append(args, "--rebase")
+	}
+
+	output, err := g.run(worktreePath, args...)
+	if err != nil {
+		return fmt.Errorf("failed to enable auto-merge: %s", string(output))
+	}
+
+	return nil
+}
+
+// DisableAutoMerge cancels a pending auto-merge (also removes the MR from a merge train)
+func (g *GitLab) DisableAutoMerge(worktreePath, prURL string) error {
+	endpoint := fmt.Sprintf("projects/:id/merge_requests/%s/cancel_merge_when_pipeline_succeeds", g.mrIdentifier(prURL))
+	output, err := g.run(worktreePath, "api", "--method", "POST", endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %s", string(output))
+	}
+
+	return nil
+}
+
+// AddToMergeQueue adds the MR to the merge train
+// On projects with merge trains enabled, auto-merge is what enqueues the MR
+func (g *GitLab) AddToMergeQueue(worktreePath, prURL string) error {
+	return g.EnableAutoMerge(worktreePath, prURL, "merge")
+}
+
+// mrIdentifier converts a MR URL into its IID; numbers and branch names are passed through
+func (g *GitLab) mrIdentifier(prIdentifier string) string {
+	if number := ParsePRNumber(prIdentifier); number > 0 {
+		return strconv.Itoa(number)
+	}
+	return prIdentifier
+}
+
+// projectURL returns the project URL a MR URL belongs to, falling back to the remote
+func (g *GitLab) projectURL(prURL string) string {
+	if project, _, ok := strings.Cut(prURL, "/-/merge_requests/"); ok {
+		return project
+	}
+	return g.RepoURL()
+}
+
+// toPRInfo converts a glab MR to the shared PRInfo shape
+func (mr glabMR) toPRInfo() PRInfo {
+	prInfo := PRInfo{
+		Number:      mr.IID,
+		Title:       mr.Title,
+		HeadRefName: mr.SourceBranch,
+		URL:         mr.WebURL,
+		Status:      strings.ToUpper(normalizeGitLabState(mr.State)),
+	}
+	prInfo.Author.Login = mr.Author.Username
+	return prInfo
+}
+
+// normalizeGitLabState maps GitLab MR states to jean's "open", "merged", "closed"
+func normalizeGitLabState(state string) string {
+	switch strings.ToLower(state) {
+	case "opened":
+		return "open"
+	case "locked":
+		return "closed"
+	default:
+		return strings.ToLower(state)
+	}
+}
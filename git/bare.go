@@ -0,0 +1,120 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// BarePathTemplate puts worktrees of a bare repository beside it, e.g. project/.bare -> project/<branch>
+const BarePathTemplate = "{root}/../{branch}"
+
+// ErrNoMainCheckout is returned for operations on the main checkout in a bare repository
+var ErrNoMainCheckout = errors.New("bare repository has no main checkout")
+
+// IsBare reports whether the repository is bare ("bare repo + worktrees" layout, e.g. repo.git or
+// project/.bare), where every branch is a worktree and there is no main checkout
+func (m *Manager) IsBare() bool {
+	m.bareOnce.Do(func() {
+		output, err := exec.Command("git", "-C", m.repoPath, "config", "--bool", "core.bare").Output()
+		m.bare = err == nil && strings.TrimSpace(string(output)) == "true"
+	})
+	return m.bare
+}
+
+// bareDir returns the directory of a bare repository
+func (m *Manager) bareDir() (string, error) {
+	output, err := exec.Command("git", "-C", m.repoPath, "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository or git is not installed")
+	}
+	dir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(m.repoPath, dir)
+	}
+	return filepath.Abs(dir)
+}
+
+// CheckoutRoot returns the checkout jean.json and include files are read from: the main checkout,
+// or in a bare repository the worktree of the default branch (the bare directory if it has none)
+func (m *Manager) CheckoutRoot() (string, error) {
+	root, err := m.GetRepoRoot()
+	if err != nil || !m.IsBare() {
+		return root, err
+	}
+	if path, ok := m.branchWorktree(m.defaultBranchName()); ok {
+		return path, nil
+	}
+	return root, nil
+}
+
+// defaultBranchName returns the default branch, "" if it can't be determined
+func (m *Manager) defaultBranchName() string {
+	branch, err := m.GetDefaultBranch()
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// branchWorktree returns the worktree a branch is checked out in
+func (m *Manager) branchWorktree(branch string) (string, bool) {
+	if branch == "" {
+		return "", false
+	}
+	worktrees, err := m.ListLightweight()
+	if err != nil {
+		return "", false
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branch {
+			return wt.Path, true
+		}
+	}
+	return "", false
+}
+
+// MergeIntoBaseBranch merges a branch into the base branch locally and returns the checkout it was merged in
+// ("" for a temporary one)
+// The main checkout is switched to the base branch first. A bare repository merges in the worktree of the
+// base branch, or in a temporary one that's removed afterwards (aborting the merge on conflicts, as nobody
+// could resolve them there)
+func (m *Manager) MergeIntoBaseBranch(branch, baseBranch string) (string, error) {
+	if !m.IsBare() {
+		root, err := m.GetRepoRoot()
+		if err != nil {
+			return "", fmt.Errorf("failed to get repo root: %w", err)
+		}
+		if err := m.CheckoutBranch(baseBranch); err != nil {
+			return root, fmt.Errorf("failed to checkout base branch: %w", err)
+		}
+		return root, m.MergeBranch(root, branch)
+	}
+
+	if path, ok := m.branchWorktree(baseBranch); ok {
+		return path, m.MergeBranch(path, branch)
+	}
+
+	tempDir, err := os.MkdirTemp("", "jean-merge-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary worktree: %w", err)
+	}
+	path := filepath.Join(tempDir, baseBranch)
+	defer os.RemoveAll(tempDir)
+	if output, err := exec.Command("git", "-C", m.repoPath, "worktree", "add", path, baseBranch).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to create temporary worktree: %s", string(output))
+	}
+	defer exec.Command("git", "-C", m.repoPath, "worktree", "remove", "--force", path).Run()
+
+	if err := m.MergeBranch(path, branch); err != nil {
+		if strings.Contains(err.Error(), "merge conflict") {
+			_ = m.AbortMerge(path)
+			return "", fmt.Errorf("%s conflicts with %s, merge aborted. Create a worktree for %s and merge there to resolve the conflicts", branch, baseBranch, baseBranch)
+		}
+		return "", err
+	}
+	return "", nil
+}
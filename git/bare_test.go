@@ -0,0 +1,78 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBareRepository tests the "bare repo + worktrees" layout: project/.bare with worktrees beside it
+func TestBareRepository(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source")
+	project := filepath.Join(dir, "project")
+	bare := filepath.Join(project, ".bare")
+	testGit(t, "", "init", "-q", "-b", "main", source)
+	testGit(t, source, "commit", "-q", "--allow-empty", "-m", "init")
+	testGit(t, source, "branch", "release")
+	testGit(t, source, "checkout", "-q", "-b", "feature")
+	testGit(t, source, "commit", "-q", "--allow-empty", "-m", "feature")
+	testGit(t, "", "clone", "-q", "--bare", source, bare)
+	if err := os.WriteFile(filepath.Join(project, ".git"), []byte("gitdir: ./.bare\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testGit(t, project, "worktree", "add", "-q", filepath.Join(project, "main"), "main")
+
+	// Launched from the project directory
+	m := NewManager(project)
+	if !m.IsBare() {
+		t.Fatal("Expected a bare repository")
+	}
+	if root, err := m.GetRepoRoot(); err != nil || root != bare {
+		t.Errorf("Expected the repo root to be %s, got %s (%v)", bare, root, err)
+	}
+	worktrees, err := m.ListLightweight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worktrees) != 1 || worktrees[0].Branch != "main" || worktrees[0].IsCurrent {
+		t.Errorf("Expected only the main worktree, not as the main checkout, got %+v", worktrees)
+	}
+	if len(worktrees) == 1 && !worktrees[0].Protected {
+		t.Errorf("Expected the default branch worktree to be protected from deletion, got %+v", worktrees[0])
+	}
+	if path, err := m.GetDefaultPath("feature/login"); err != nil || path != filepath.Join(project, "feature-login") {
+		t.Errorf("Expected new worktrees beside the bare directory, got %s (%v)", path, err)
+	}
+	if root, err := m.CheckoutRoot(); err != nil || root != filepath.Join(project, "main") {
+		t.Errorf("Expected jean.json to be read from the main worktree, got %s (%v)", root, err)
+	}
+	if err := m.CheckoutBranch("release"); err != ErrNoMainCheckout {
+		t.Errorf("Expected ErrNoMainCheckout, got %v", err)
+	}
+
+	// Merging into a branch without a worktree goes through a temporary one
+	if _, err := m.MergeIntoBaseBranch("feature", "release"); err != nil {
+		t.Fatal(err)
+	}
+	if commits := strings.Fields(testGit(t, bare, "rev-parse", "release", "feature")); len(commits) != 2 || commits[0] != commits[1] {
+		t.Errorf("Expected release to be fast-forwarded to feature, got %v", commits)
+	}
+	if worktrees, _ := m.ListLightweight(); len(worktrees) != 1 {
+		t.Errorf("Expected the temporary worktree to be removed, got %+v", worktrees)
+	}
+
+	// Launched from a worktree, which is protected as well
+	testGit(t, project, "worktree", "add", "-q", filepath.Join(project, "feature"), "feature")
+	testGit(t, project, "worktree", "add", "-q", filepath.Join(project, "release"), "release")
+	worktrees, err = NewManager(filepath.Join(project, "feature")).ListLightweight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, wt := range worktrees {
+		if wantProtected := wt.Branch != "release"; wt.Protected != wantProtected || wt.IsCurrent {
+			t.Errorf("Expected %s to be protected: %v, got %+v", wt.Branch, wantProtected, wt)
+		}
+	}
+}
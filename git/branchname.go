@@ -0,0 +1,208 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// branchTypes are the conventional branch types recognized in a typed branch name
+var branchTypes = []string{"feat", "feature", "fix", "bugfix", "hotfix", "chore", "docs", "refactor", "test", "perf", "ci", "build", "style", "release"}
+
+// ticketPattern matches ticket references like "PROJ-123" or "#42" in a typed branch name
+var ticketPattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9]*-[0-9]+|#?[0-9]+)$`)
+
+// branchPlaceholder matches the placeholders of a branch name template
+var branchPlaceholder = regexp.MustCompile(`\{(user|type|ticket|slug|date)\}`)
+
+// BranchTemplateValues are the values substituted into a branch name template
+type BranchTemplateValues struct {
+	User   string // {user}: git user.name, slugified
+	Type   string // {type}: e.g. "feat" or "fix"
+	Ticket string // {ticket}: e.g. "PROJ-123"
+	Slug   string // {slug}: short description, e.g. "add-login"
+	Date   string // {date}: YYYY-MM-DD
+}
+
+// SetBranchNaming sets the template for new branch names and the regex branch names must match
+// An empty template keeps random names, an empty pattern accepts any name
+func (m *Manager) SetBranchNaming(template, pattern string) error {
+	if template != "" && !strings.Contains(template, "{slug}") {
+		return fmt.Errorf("branch name template %q has no {slug}", template)
+	}
+	var compiled *regexp.Regexp
+	if pattern != "" {
+		var err error
+		if compiled, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid branch name pattern: %w", err)
+		}
+	}
+	m.branchTemplate = template
+	m.branchPattern = compiled
+
+	// The git user is read once, names are previewed as they're typed
+	m.branchUser = ""
+	if strings.Contains(template, "{user}") {
+		if output, err := exec.Command("git", "-C", m.repoPath, "config", "user.name").Output(); err == nil {
+			m.branchUser = strings.TrimSpace(string(output))
+		}
+	}
+	return nil
+}
+
+// BranchTemplate returns the template for new branch names, "" = random names
+func (m *Manager) BranchTemplate() string {
+	return m.branchTemplate
+}
+
+// ValidateBranchName checks a branch name against the repository's naming convention
+func (m *Manager) ValidateBranchName(name string) error {
+	if name == "" {
+		return fmt.Errorf("branch name is empty")
+	}
+	if m.branchPattern != nil && !m.branchPattern.MatchString(name) {
+		return fmt.Errorf("branch %q doesn't match the naming convention %s", name, m.branchPattern.String())
+	}
+	return nil
+}
+
+// BranchNameFromInput turns a typed name into a branch name
+// With a template, input without a "/" fills the template: a leading branch type and a ticket
+// reference are picked out and the rest is the slug, so "fix PROJ-12 login crash" becomes
+// "alice/fix/PROJ-12-login-crash" for "{user}/{type}/{ticket}-{slug}"
+// Input with a "/" is taken as a full branch name
+func (m *Manager) BranchNameFromInput(input string) string {
+	if m.branchTemplate == "" || strings.Contains(input, "/") {
+		return SanitizeBranchName(input)
+	}
+
+	values := m.templateValues()
+	var slug []string
+	for _, word := range strings.Fields(input) {
+		switch {
+		case values.Type == "" && len(slug) == 0 && isBranchType(word):
+			values.Type = strings.ToLower(word)
+		case values.Ticket == "" && ticketPattern.MatchString(word):
+			values.Ticket = strings.TrimPrefix(word, "#")
+		default:
+			slug = append(slug, word)
+		}
+	}
+	values.Slug = strings.Join(slug, " ")
+	return ExpandBranchTemplate(m.branchTemplate, values)
+}
+
+// ApplyGeneratedName puts an AI-generated name into a branch's place in the naming convention
+// The random slug of a templated branch is replaced, keeping its user, type, and ticket
+func (m *Manager) ApplyGeneratedName(branch, name string) string {
+	if m.branchTemplate == "" {
+		return name
+	}
+
+	// Only the description goes into the slug, not a type prefix the AI may have added
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	slug := slugify(name)
+
+	// A plain random name predates the template, so it gets the template's user, date, and separators
+	if loc := templateRegexp(m.branchTemplate).FindStringSubmatchIndex(branch); loc != nil && (loc[2] > 0 || loc[3] < len(branch)) {
+		return branch[:loc[2]] + slug + branch[loc[3]:]
+	}
+	values := m.templateValues()
+	values.Slug = slug
+	return ExpandBranchTemplate(m.branchTemplate, values)
+}
+
+// ExpandBranchTemplate returns the branch name a template gives for values
+// Separators around empty values are dropped, so "{user}/{type}/{ticket}-{slug}" without a type
+// or ticket gives "alice/add-login"
+func ExpandBranchTemplate(template string, values BranchTemplateValues) string {
+	name := branchPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		switch placeholder {
+		case "{user}":
+			return slugify(values.User)
+		case "{type}":
+			return slugify(values.Type)
+		case "{ticket}":
+			return SanitizeBranchName(values.Ticket)
+		case "{slug}":
+			return slugify(values.Slug)
+		default:
+			return SanitizeBranchName(values.Date)
+		}
+	})
+
+	for _, pair := range [][2]string{{"//", "/"}, {"/-", "/"}, {"-/", "/"}, {"--", "-"}} {
+		for strings.Contains(name, pair[0]) {
+			name = strings.ReplaceAll(name, pair[0], pair[1])
+		}
+	}
+	return strings.Trim(name, "/-")
+}
+
+// templateValues returns the template values known without input: the git user and today's date
+func (m *Manager) templateValues() BranchTemplateValues {
+	return BranchTemplateValues{User: m.branchUser, Date: time.Now().Format("2006-01-02")}
+}
+
+// templateRegexp returns a regex matching branches created from a template with a random slug
+// The slug is the first submatch; separators are optional since they're dropped around empty values
+func templateRegexp(template string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	slugSeen := false
+	last := 0
+	for _, loc := range branchPlaceholder.FindAllStringSubmatchIndex(template, -1) {
+		writeTemplateLiteral(&b, template[last:loc[0]])
+		switch template[loc[2]:loc[3]] {
+		case "slug":
+			if !slugSeen {
+				b.WriteString("(" + randomNamePattern() + ")")
+				slugSeen = true
+			} else {
+				b.WriteString(randomNamePattern())
+			}
+		case "date":
+			b.WriteString("[0-9-]*?")
+		default:
+			b.WriteString("[^/]*?")
+		}
+		last = loc[1]
+	}
+	writeTemplateLiteral(&b, template[last:])
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// writeTemplateLiteral writes the literal text of a template as a regex, with optional separators
+func writeTemplateLiteral(b *strings.Builder, literal string) {
+	for _, c := range literal {
+		b.WriteString(regexp.QuoteMeta(string(c)))
+		if c == '/' || c == '-' {
+			b.WriteString("?")
+		}
+	}
+}
+
+// randomNamePattern returns a regex matching random names like "happy-panda-42"
+func randomNamePattern() string {
+	return "(?:" + strings.Join(adjectives, "|") + ")-(?:" + strings.Join(nouns, "|") + ")-[0-9]+"
+}
+
+// isBranchType reports whether a word is a conventional branch type
+func isBranchType(word string) bool {
+	for _, branchType := range branchTypes {
+		if strings.EqualFold(word, branchType) {
+			return true
+		}
+	}
+	return false
+}
+
+// slugify lowercases a value and makes it safe for a single branch name segment
+func slugify(value string) string {
+	return SanitizeBranchName(strings.ReplaceAll(strings.ToLower(value), "/", "-"))
+}
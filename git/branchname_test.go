@@ -0,0 +1,74 @@
+package git
+
+import (
+	"testing"
+)
+
+// TestExpandBranchTemplate tests building branch names from templates, dropping separators around empty values
+func TestExpandBranchTemplate(t *testing.T) {
+	template := "{user}/{type}/{ticket}-{slug}"
+	tests := []struct {
+		values BranchTemplateValues
+		want   string
+	}{
+		{BranchTemplateValues{User: "Alice Smith", Type: "fix", Ticket: "PROJ-12", Slug: "Login crash"}, "alice-smith/fix/PROJ-12-login-crash"},
+		{BranchTemplateValues{User: "alice", Slug: "add-login"}, "alice/add-login"},
+		{BranchTemplateValues{Type: "feat", Slug: "add-login"}, "feat/add-login"},
+	}
+	for _, tt := range tests {
+		if got := ExpandBranchTemplate(template, tt.values); got != tt.want {
+			t.Errorf("%+v: expected %s, got %s", tt.values, tt.want, got)
+		}
+	}
+}
+
+// TestBranchNaming tests typed, random, and AI-generated names with a branch name template and pattern
+func TestBranchNaming(t *testing.T) {
+	m := &Manager{}
+	if err := m.SetBranchNaming("{user}/{slug}", "("); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+	if err := m.SetBranchNaming("{user}/{type}", ""); err == nil {
+		t.Error("Expected an error for a template without {slug}")
+	}
+	if err := m.SetBranchNaming("{user}/{type}/{ticket}-{slug}", `^[a-z]+/(feat|fix)/[A-Z]+-[0-9]+-[a-z0-9-]+$`); err != nil {
+		t.Fatal(err)
+	}
+	m.branchUser = "alice"
+
+	// A leading type and a ticket are picked out of typed names
+	if got := m.BranchNameFromInput("fix PROJ-12 login crash"); got != "alice/fix/PROJ-12-login-crash" {
+		t.Errorf("Unexpected name from input: %s", got)
+	}
+	if got := m.BranchNameFromInput("bob/chore/cleanup"); got != "bob/chore/cleanup" {
+		t.Errorf("Expected a full branch name to be kept, got %s", got)
+	}
+	if err := m.ValidateBranchName("alice/fix/PROJ-12-login-crash"); err != nil {
+		t.Errorf("Unexpected validation error %v", err)
+	}
+	if err := m.ValidateBranchName("alice/login-crash"); err == nil {
+		t.Error("Expected a name without type and ticket to fail validation")
+	}
+
+	// Random names fill the slug and are still recognized for auto-rename
+	random, _ := m.GenerateRandomName()
+	if !m.IsRandomBranchName(random) {
+		t.Errorf("Expected %s to be recognized as a random name", random)
+	}
+	for _, name := range []string{"happy-panda-42", "alice/fix/PROJ-12-happy-panda-42"} {
+		if !m.IsRandomBranchName(name) {
+			t.Errorf("Expected %s to be recognized as a random name", name)
+		}
+	}
+	if m.IsRandomBranchName("alice/fix/PROJ-12-login-crash") {
+		t.Error("Expected a descriptive name not to be recognized as a random name")
+	}
+
+	// AI names replace the random slug only
+	if got := m.ApplyGeneratedName("alice/fix/PROJ-12-happy-panda-42", "feat/login-crash"); got != "alice/fix/PROJ-12-login-crash" {
+		t.Errorf("Unexpected generated name: %s", got)
+	}
+	if got := m.ApplyGeneratedName("happy-panda-42", "login-crash"); got != "alice/login-crash" {
+		t.Errorf("Expected a plain random name to get the template, got %s", got)
+	}
+}
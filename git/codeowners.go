@@ -0,0 +1,130 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// codeownersPaths are the locations GitHub, GitLab, and Gitea read a CODEOWNERS file from, in priority order
+var codeownersPaths = []string{
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	".gitea/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// CodeownersRule maps a path pattern to its owners
+type CodeownersRule struct {
+	Pattern string
+	Owners  []string // Owners as written, e.g. "@user", "@org/team", or an email address
+	re      *regexp.Regexp
+}
+
+// ParseCodeowners parses the contents of a CODEOWNERS file
+// Comments, blank lines, GitLab section headers, and invalid patterns are skipped
+func ParseCodeowners(content string) []CodeownersRule {
+	var rules []CodeownersRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+			continue
+		}
+
+		re, err := regexp.Compile(codeownersPatternToRegexp(fields[0]))
+		if err != nil {
+			continue
+		}
+		rules = append(rules, CodeownersRule{Pattern: fields[0], Owners: fields[1:], re: re})
+	}
+	return rules
+}
+
+// codeownersPatternToRegexp converts a gitignore-style CODEOWNERS pattern to a regular expression
+func codeownersPatternToRegexp(pattern string) string {
+	// A pattern is anchored to the repository root if it starts with or contains a slash
+	// (a trailing slash only marks a directory)
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+
+	prefix := "^"
+	if !anchored {
+		prefix = "^(.*/)?"
+	}
+	// A pattern matching a directory also matches everything below it
+	return prefix + b.String() + "(/.*)?$"
+}
+
+// OwnersFor returns the owners of a path; as in CODEOWNERS, the last matching rule wins
+func OwnersFor(rules []CodeownersRule, path string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(path) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// SuggestReviewers returns reviewers from the CODEOWNERS file that own the files changed
+// since baseBranch, without the leading "@" (teams as "org/team")
+// Email owners are skipped since forges can't request reviews from them by address
+// Returns nil if the repository has no CODEOWNERS file
+func (m *Manager) SuggestReviewers(worktreePath, baseBranch string) ([]string, error) {
+	var content []byte
+	for _, path := range codeownersPaths {
+		data, err := os.ReadFile(filepath.Join(worktreePath, path))
+		if err == nil {
+			content = data
+			break
+		}
+	}
+	if content == nil {
+		return nil, nil
+	}
+
+	files, err := m.GetChangedFiles(worktreePath, baseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := ParseCodeowners(string(content))
+	seen := make(map[string]bool)
+	var reviewers []string
+	for _, file := range files {
+		for _, owner := range OwnersFor(rules, file) {
+			if !strings.HasPrefix(owner, "@") {
+				continue
+			}
+			owner = strings.TrimPrefix(owner, "@")
+			if !seen[owner] {
+				seen[owner] = true
+				reviewers = append(reviewers, owner)
+			}
+		}
+	}
+	return reviewers, nil
+}
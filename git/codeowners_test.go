@@ -0,0 +1,41 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestOwnersFor tests CODEOWNERS pattern matching and last-match-wins precedence
+func TestOwnersFor(t *testing.T) {
+	rules := ParseCodeowners(`
+# Default owners
+*                   @org/core
+*.go                @gopher   # Go code
+/docs/              @org/docs writer@example.com
+tui/**/view.go      @designer
+
+[Frontend]
+web/                @frontend
+`)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@org/core"}},
+		{"main.go", []string{"@gopher"}},
+		{"git/worktree.go", []string{"@gopher"}},
+		{"docs/setup.md", []string{"@org/docs", "writer@example.com"}},
+		{"sub/docs/setup.md", []string{"@org/core"}}, // /docs/ is anchored to the root
+		{"tui/view.go", []string{"@designer"}},
+		{"tui/modals/view.go", []string{"@designer"}},
+		{"web/app.js", []string{"@frontend"}},
+		{"src/web/app.js", []string{"@frontend"}}, // Unanchored directory pattern matches at any depth
+	}
+
+	for _, tt := range tests {
+		if got := OwnersFor(rules, tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("OwnersFor(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package git
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// SetDependencySeeding sets how dependency directories of new worktrees are seeded:
+// config.SeedReflink, config.SeedHardlink, or "" (off)
+func (m *Manager) SetDependencySeeding(mode string) {
+	m.dependencySeeding = mode
+}
+
+// SeedDependencies fills the dependency directories of a new worktree from the root checkout or a
+// sibling worktree whose lockfile is identical, so installing afterwards has little left to do
+// Directories are cloned copy-on-write; with config.SeedHardlink, filesystems without clones get
+// hardlinks instead (shared with the source, so in-place edits show in both)
+// Returns the seeded directories; a directory that can't be seeded is left for the install
+func (m *Manager) SeedDependencies(worktreePath string) ([]string, error) {
+	if m.dependencySeeding == "" {
+		return nil, nil
+	}
+	root, err := m.CheckoutRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo root: %w", err)
+	}
+	scriptConfig, err := config.LoadScripts(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jean.json: %w", err)
+	}
+
+	// The root checkout first, then the other worktrees
+	sources := []string{root}
+	if worktrees, err := m.ListLightweight(); err == nil {
+		for _, wt := range worktrees {
+			if wt.Path != root && wt.Path != worktreePath {
+				sources = append(sources, wt.Path)
+			}
+		}
+	}
+
+	var seeded []string
+	for _, rule := range scriptConfig.GetDependencies() {
+		target := filepath.Join(worktreePath, rule.Dir)
+		if _, err := os.Lstat(target); err == nil {
+			continue
+		}
+		lockfile, hash := lockfileHash(worktreePath, rule)
+		if hash == "" {
+			continue
+		}
+		for _, source := range sources {
+			if _, sourceHash := lockfileHash(source, config.DependencyRule{Lockfiles: []string{lockfile}}); sourceHash != hash {
+				continue
+			}
+			if info, err := os.Stat(filepath.Join(source, rule.Dir)); err != nil || !info.IsDir() {
+				continue
+			}
+			if err := m.seedDirectory(filepath.Join(source, rule.Dir), target); err != nil {
+				continue
+			}
+			seeded = append(seeded, rule.Dir)
+			break
+		}
+	}
+	return seeded, nil
+}
+
+// seedDirectory clones a directory copy-on-write, or hardlinks it if the seeding mode allows
+func (m *Manager) seedDirectory(source, target string) error {
+	err := cloneTree(source, target)
+	if err == nil {
+		return nil
+	}
+	_ = os.RemoveAll(target)
+	if m.dependencySeeding != config.SeedHardlink {
+		return err
+	}
+	if err := copyTree(source, target, os.Link); err != nil {
+		_ = os.RemoveAll(target)
+		return err
+	}
+	return nil
+}
+
+// cloneTree clones a directory copy-on-write with cp, failing on filesystems without clones
+// (Linux: btrfs, XFS, bcachefs; macOS: APFS)
+func cloneTree(source, target string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		cmd = exec.Command("cp", "-c", "-R", source, target)
+	} else {
+		cmd = exec.Command("cp", "-R", "--reflink=always", source, target)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone %s: %s", source, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// lockfileHash returns the first lockfile of a rule present in dir and the hash of its content
+func lockfileHash(dir string, rule config.DependencyRule) (string, string) {
+	for _, lockfile := range rule.Lockfiles {
+		data, err := os.ReadFile(filepath.Join(dir, lockfile))
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		return lockfile, fmt.Sprintf("%x", sum)
+	}
+	return "", ""
+}
+
+// DiskUsage is the disk space taken by a worktree
+type DiskUsage struct {
+	Size         int64 // Everything in the worktree, each file counted once
+	Dependencies int64 // Dependency directories (node_modules, target, ...)
+	Reclaimable  int64 // Freed by deleting the worktree: files not hardlinked from elsewhere
+}
+
+// DiskUsage measures the disk space taken by a worktree, skipping the worktrees nested in it
+// (the .workspaces of the root checkout)
+func (m *Manager) DiskUsage(worktreePath string, nested []string) (DiskUsage, error) {
+	rules := config.DefaultDependencies
+	if root, err := m.CheckoutRoot(); err == nil {
+		if scriptConfig, err := config.LoadScripts(root); err == nil {
+			rules = scriptConfig.GetDependencies()
+		}
+	}
+	dependencyDirs := make(map[string]bool)
+	for _, rule := range rules {
+		dependencyDirs[filepath.Join(worktreePath, rule.Dir)] = true
+	}
+	skip := make(map[string]bool)
+	for _, path := range nested {
+		skip[path] = true
+	}
+
+	// Hardlinked files are counted once; they're reclaimable if all their links are in the worktree
+	type inode struct {
+		size  int64
+		nlink uint64
+		links uint64
+	}
+	var usage DiskUsage
+	inodes := make(map[uint64]*inode)
+	var walk func(dir string, inDependencies bool) error
+	walk = func(dir string, inDependencies bool) error {
+		return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Unreadable files don't count
+			}
+			if entry.IsDir() && path != dir {
+				if skip[path] {
+					return filepath.SkipDir
+				}
+				if !inDependencies && dependencyDirs[path] {
+					if err := walk(path, true); err != nil {
+						return err
+					}
+					return filepath.SkipDir
+				}
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil
+			}
+			stat, ok := info.Sys().(*syscall.Stat_t)
+			if !ok {
+				return nil
+			}
+			if node, ok := inodes[uint64(stat.Ino)]; ok {
+				node.links++
+				return nil
+			}
+			size := int64(stat.Blocks) * 512
+			nlink := uint64(stat.Nlink)
+			if entry.IsDir() {
+				nlink = 1 // A directory's link count is its subdirectories
+			}
+			inodes[uint64(stat.Ino)] = &inode{size: size, nlink: nlink, links: 1}
+			usage.Size += size
+			if inDependencies {
+				usage.Dependencies += size
+			}
+			return nil
+		})
+	}
+	if err := walk(worktreePath, false); err != nil {
+		return usage, err
+	}
+	for _, node := range inodes {
+		if node.links >= node.nlink {
+			usage.Reclaimable += node.size
+		}
+	}
+	return usage, nil
+}
+
+// FormatBytes formats a size like "1.2 GB"
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
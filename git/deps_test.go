@@ -0,0 +1,53 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDiskUsage tests that files hardlinked from another checkout are not reclaimable
+func TestDiskUsage(t *testing.T) {
+	root := t.TempDir()
+	worktree := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(worktree, "node_modules"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 64*1024)
+	if err := os.WriteFile(filepath.Join(root, "shared.js"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(filepath.Join(root, "shared.js"), filepath.Join(worktree, "node_modules", "shared.js")); err != nil {
+		t.Skip("no hardlinks on this filesystem")
+	}
+	if err := os.WriteFile(filepath.Join(worktree, "own.js"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manager{repoPath: worktree}
+	usage, err := m.DiskUsage(worktree, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Dependencies < int64(len(data)) {
+		t.Errorf("Expected node_modules to count as dependencies, got %+v", usage)
+	}
+	if usage.Reclaimable >= usage.Size || usage.Reclaimable < int64(len(data)) {
+		t.Errorf("Expected only the worktree's own files to be reclaimable, got %+v", usage)
+	}
+}
+
+// TestFormatBytes tests human-readable sizes
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		1536:            "1.5 KB",
+		3 * 1024 * 1024: "3.0 MB",
+		1288490189:      "1.2 GB",
+	}
+	for size, want := range tests {
+		if got := FormatBytes(size); got != want {
+			t.Errorf("%d: expected %s, got %s", size, want, got)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DetachedBranchLabel is what the list shows as the branch of a worktree with a detached HEAD at commit
+func DetachedBranchLabel(commit string) string {
+	return fmt.Sprintf("(detached at %s)", commit[:min(7, len(commit))])
+}
+
+// ResolveCommit resolves a tag, commit, or other revision to a full commit SHA
+// Tags that aren't known locally are fetched from the upstream remote first
+func (m *Manager) ResolveCommit(ref string) (string, error) {
+	if commit, err := m.revParseCommit(ref); err == nil {
+		return commit, nil
+	}
+	m.fetchMu.Lock()
+	defer m.fetchMu.Unlock()
+	cmd := exec.Command("git", "-C", m.repoPath, "fetch", "--no-tags", m.UpstreamRemote(), "tag", ref)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if err := cmd.Run(); err == nil {
+		if commit, err := m.revParseCommit(ref); err == nil {
+			return commit, nil
+		}
+	}
+	return "", fmt.Errorf("'%s' is not a tag or commit in this repository", ref)
+}
+
+// revParseCommit resolves a revision to a commit SHA locally
+func (m *Manager) revParseCommit(ref string) (string, error) {
+	output, err := exec.Command("git", "-C", m.repoPath, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// FetchPullRef fetches a PR from the upstream remote and returns its commit
+// refs are tried in order (see forge.PullRefs), e.g. the merge ref before the head ref of a PR that
+// can't be merged cleanly
+func (m *Manager) FetchPullRef(refs []string) (string, error) {
+	// Also keeps a background fetch from overwriting FETCH_HEAD before it's read
+	m.fetchMu.Lock()
+	defer m.fetchMu.Unlock()
+	var message string
+	for _, ref := range refs {
+		cmd := exec.Command("git", "-C", m.repoPath, "fetch", "--no-tags", m.UpstreamRemote(), ref)
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			message = strings.TrimSpace(string(output))
+			continue
+		}
+		return m.revParseCommit("FETCH_HEAD")
+	}
+	return "", fmt.Errorf("failed to fetch PR from %s: %s", m.UpstreamRemote(), message)
+}
+
+// CreateDetached creates a worktree with a detached HEAD at a commit, for looking around or bisecting
+// without a branch; includes, dependency seeding, and the setup script are applied like for Create
+func (m *Manager) CreateDetached(path, commit string) error {
+	if output, err := exec.Command("git", "-C", m.repoPath, "worktree", "add", "--detach", path, commit).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create worktree: %s", string(output))
+	}
+	return m.prepareWorktree(path)
+}
+
+// PromoteToBranch creates a branch at the detached HEAD of a worktree and switches the worktree to it
+func (m *Manager) PromoteToBranch(worktreePath, branch string) error {
+	if err := m.ValidateBranchName(branch); err != nil {
+		return err
+	}
+	if output, err := exec.Command("git", "-C", worktreePath, "switch", "-c", branch).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create branch: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
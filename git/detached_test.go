@@ -0,0 +1,74 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDetachedWorktree tests creating worktrees at a tag and at a PR ref, and promoting one to a branch
+func TestDetachedWorktree(t *testing.T) {
+	dir := t.TempDir()
+	origin := filepath.Join(dir, "origin")
+	repo := filepath.Join(dir, "repo")
+	testGit(t, "", "init", "-q", "-b", "main", origin)
+	testGit(t, origin, "commit", "-q", "--allow-empty", "-m", "init")
+	testGit(t, "", "clone", "-q", origin, repo)
+	// Published after cloning, so only the remote knows them
+	testGit(t, origin, "tag", "v1.0.0")
+	testGit(t, origin, "commit", "-q", "--allow-empty", "-m", "fix")
+	testGit(t, origin, "update-ref", "refs/pull/42/head", "HEAD")
+	pullCommit := testGit(t, origin, "rev-parse", "HEAD")
+
+	m := NewManager(repo)
+	tagCommit, err := m.ResolveCommit("v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.ResolveCommit("no-such-ref"); err == nil {
+		t.Error("Expected an unknown ref to fail")
+	}
+	// The origin has no merge ref, the head ref is used instead
+	commit, err := m.FetchPullRef([]string{"refs/pull/42/merge", "refs/pull/42/head"})
+	if err != nil || commit != pullCommit {
+		t.Errorf("Expected PR 42 at %s, got %s (%v)", pullCommit, commit, err)
+	}
+
+	release := filepath.Join(dir, "v1.0.0")
+	if err := m.CreateDetached(release, tagCommit); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CreateDetached(filepath.Join(dir, "pr-42"), commit); err != nil {
+		t.Fatal(err)
+	}
+	worktrees, err := m.ListLightweight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byPath := make(map[string]Worktree)
+	for _, wt := range worktrees {
+		byPath[wt.Path] = wt
+	}
+	if wt := byPath[release]; !wt.Detached || wt.Branch != DetachedBranchLabel(tagCommit) {
+		t.Errorf("Expected a detached worktree at v1.0.0, got %+v", wt)
+	}
+	if wt := byPath[repo]; wt.Detached {
+		t.Errorf("Expected the main checkout to be on a branch, got %+v", wt)
+	}
+
+	if err := m.PromoteToBranch(release, "hotfix"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PromoteToBranch(filepath.Join(dir, "pr-42"), "hotfix"); err == nil {
+		t.Error("Expected promoting to an existing branch to fail")
+	}
+	worktrees, _ = m.ListLightweight()
+	for _, wt := range worktrees {
+		if wt.Path == release && (wt.Detached || wt.Branch != "hotfix") {
+			t.Errorf("Expected v1.0.0 to be on hotfix, got %+v", wt)
+		}
+	}
+	if _, err := os.Stat(release); err != nil {
+		t.Errorf("Expected the worktree to stay where it is: %v", err)
+	}
+}
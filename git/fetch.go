@@ -0,0 +1,100 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrOffline is returned (wrapped) by BackgroundFetch when a remote can't be reached
+var ErrOffline = errors.New("remote unreachable")
+
+// ErrFetchInProgress is returned by BackgroundFetch when it's skipped because another fetch is running
+var ErrFetchInProgress = errors.New("another fetch is in progress")
+
+// offlineMessages are what git and ssh print when the network or the remote host is down
+var offlineMessages = []string{
+	"could not resolve host",
+	"could not resolve hostname",
+	"temporary failure in name resolution",
+	"network is unreachable",
+	"no route to host",
+	"connection refused",
+	"connection timed out",
+	"operation timed out",
+	"failed to connect",
+}
+
+// BackgroundFetch fetches the remotes like FetchRemote, for fetching periodically in the background:
+// it never prompts for credentials, passphrases, or host keys, gives up after the pull timeout, and
+// reports an unreachable remote with ErrOffline
+// It's skipped with ErrFetchInProgress while another fetch runs, as concurrent fetches fail to lock refs
+// Returns whether any remote-tracking ref was updated
+func (m *Manager) BackgroundFetch() (bool, error) {
+	if !m.fetchMu.TryLock() {
+		return false, ErrFetchInProgress
+	}
+	defer m.fetchMu.Unlock()
+
+	timeout := m.pullTimeout
+	if timeout <= 0 {
+		timeout = DefaultPullTimeout
+	}
+	env := m.backgroundFetchEnv()
+
+	updated := false
+	for _, remote := range m.fetchRemotes() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		cmd := exec.CommandContext(ctx, "git", "-C", m.repoPath, "fetch", remote)
+		cmd.Env = env
+		cmd.WaitDelay = time.Second
+		output, err := cmd.CombinedOutput()
+		timedOut := ctx.Err() == context.DeadlineExceeded
+		cancel()
+
+		if timedOut {
+			return updated, fmt.Errorf("fetching %s %w after %s: %w", remote, ErrTimeout, timeout, ErrOffline)
+		}
+		if err != nil {
+			message := strings.TrimSpace(string(output))
+			if isOfflineMessage(message) {
+				return updated, fmt.Errorf("fetching %s: %w", remote, ErrOffline)
+			}
+			return updated, fmt.Errorf("failed to fetch %s: %s", remote, message)
+		}
+		// git fetch only prints the refs it updated
+		if strings.TrimSpace(string(output)) != "" {
+			updated = true
+		}
+	}
+	return updated, nil
+}
+
+// backgroundFetchEnv returns the environment for background fetches, which must not prompt:
+// GIT_TERMINAL_PROMPT only covers git's own prompts, ssh asks for passphrases and host keys on the
+// terminal, so it runs in batch mode unless an ssh command is configured
+func (m *Manager) backgroundFetchEnv() []string {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if os.Getenv("GIT_SSH_COMMAND") != "" {
+		return env
+	}
+	if output, err := exec.Command("git", "-C", m.repoPath, "config", "core.sshCommand").Output(); err == nil && strings.TrimSpace(string(output)) != "" {
+		return env
+	}
+	return append(env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+}
+
+// isOfflineMessage checks whether a fetch failed because the network or the remote host is down
+func isOfflineMessage(output string) bool {
+	output = strings.ToLower(output)
+	for _, message := range offlineMessages {
+		if strings.Contains(output, message) {
+			return true
+		}
+	}
+	return false
+}
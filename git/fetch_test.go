@@ -0,0 +1,71 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestIsOfflineMessage tests telling an unreachable remote from other fetch failures
+func TestIsOfflineMessage(t *testing.T) {
+	tests := map[string]bool{
+		"fatal: unable to access 'https://github.com/o/r.git/': Could not resolve host: github.com":          true,
+		"ssh: connect to host github.com port 22: Network is unreachable\nfatal: Could not read from remote": true,
+		"ssh: Could not resolve hostname github.com: Temporary failure in name resolution":                   true,
+		"git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.":      false,
+		"fatal: couldn't find remote ref main":                                                               false,
+	}
+	for output, want := range tests {
+		if got := isOfflineMessage(output); got != want {
+			t.Errorf("%q: expected %v, got %v", output, want, got)
+		}
+	}
+}
+
+// TestBackgroundFetch tests that background fetches run ssh in batch mode unless an ssh command is
+// configured, and are skipped while another fetch runs
+func TestBackgroundFetch(t *testing.T) {
+	repo := t.TempDir()
+	testGit(t, "", "init", "-q", repo)
+	t.Setenv("GIT_SSH_COMMAND", "")
+	m := NewManager(repo)
+
+	if env := m.backgroundFetchEnv(); !slices.Contains(env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes") {
+		t.Errorf("Expected ssh to run in batch mode, got %v", env)
+	}
+	testGit(t, repo, "config", "core.sshCommand", "ssh -i key")
+	if env := m.backgroundFetchEnv(); slices.Contains(env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes") {
+		t.Errorf("Expected the configured ssh command to be kept, got %v", env)
+	}
+
+	m.fetchMu.Lock()
+	if _, err := m.BackgroundFetch(); !errors.Is(err, ErrFetchInProgress) {
+		t.Errorf("Expected ErrFetchInProgress while another fetch runs, got %v", err)
+	}
+	m.fetchMu.Unlock()
+}
+
+// TestBackgroundFetchBehindCount tests that a fetch bringing new base branch commits makes worktrees behind,
+// though the local base branch didn't move
+func TestBackgroundFetchBehindCount(t *testing.T) {
+	dir := t.TempDir()
+	origin := filepath.Join(dir, "origin")
+	repo := filepath.Join(dir, "repo")
+	testGit(t, "", "init", "-q", "-b", "main", origin)
+	testGit(t, origin, "commit", "-q", "--allow-empty", "-m", "init")
+	testGit(t, "", "clone", "-q", origin, repo)
+	testGit(t, repo, "checkout", "-q", "-b", "feature")
+
+	m := NewManager(repo)
+	if _, behind, err := m.GetBranchStatus(repo, "feature", "main"); err != nil || behind != 0 {
+		t.Fatalf("Expected feature to be up to date, got %d behind (%v)", behind, err)
+	}
+	testGit(t, origin, "commit", "-q", "--allow-empty", "-m", "fix")
+	if updated, err := m.BackgroundFetch(); err != nil || !updated {
+		t.Fatalf("Expected the fetch to update refs, got %v (%v)", updated, err)
+	}
+	if _, behind, err := m.GetBranchStatus(repo, "feature", "main"); err != nil || behind != 1 {
+		t.Errorf("Expected feature to be 1 behind after the fetch, got %d (%v)", behind, err)
+	}
+}
@@ -0,0 +1,22 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// testGit runs git in dir ("" = the current directory) with a test identity for commits, failing the test
+// if it fails, and returns its trimmed output
+func testGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s", args, output)
+	}
+	return strings.TrimSpace(string(output))
+}
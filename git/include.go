@@ -0,0 +1,187 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// IncludeAction is a file or directory of the root checkout that an include rule of jean.json puts into a worktree
+type IncludeAction struct {
+	Path   string // Relative to the root checkout and the worktree
+	Source string // In the root checkout
+	Target string // In the worktree
+	Mode   string // config.IncludeCopy, config.IncludeSymlink, or config.IncludeHardlink
+	IsDir  bool
+	Exists bool // The target exists already: kept when creating, replaced when resyncing
+}
+
+// PlanIncludes returns what the include rules of jean.json put into a worktree, without changing anything
+// Files tracked by git are skipped, the worktree has its own checkout of them
+func (m *Manager) PlanIncludes(worktreePath string) ([]IncludeAction, error) {
+	root, err := m.CheckoutRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo root: %w", err)
+	}
+	if isWithin(root, worktreePath) && isWithin(worktreePath, root) {
+		return nil, fmt.Errorf("includes come from the root checkout, they can't be synced into it")
+	}
+	scriptConfig, err := config.LoadScripts(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jean.json: %w", err)
+	}
+
+	var actions []IncludeAction
+	seen := make(map[string]bool)
+	for _, rule := range scriptConfig.Include {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		matches, err := filepath.Glob(filepath.Join(root, strings.TrimSuffix(rule.Path, "/")))
+		if err != nil {
+			return nil, fmt.Errorf("include %q: %w", rule.Path, err)
+		}
+		for _, source := range matches {
+			rel, err := filepath.Rel(root, source)
+			if err != nil || !filepath.IsLocal(rel) || rel == ".git" || strings.HasPrefix(rel, ".git"+string(filepath.Separator)) || seen[rel] {
+				continue
+			}
+			seen[rel] = true
+			// Tracked files are in the worktree's checkout, and a directory holding worktrees would copy itself
+			if isTracked(root, rel) || isWithin(worktreePath, source) {
+				continue
+			}
+			info, err := os.Stat(source)
+			if err != nil {
+				continue
+			}
+			target := filepath.Join(worktreePath, rel)
+			_, statErr := os.Lstat(target)
+			actions = append(actions, IncludeAction{
+				Path:   rel,
+				Source: source,
+				Target: target,
+				Mode:   rule.IncludeMode(),
+				IsDir:  info.IsDir(),
+				Exists: statErr == nil,
+			})
+		}
+	}
+	return actions, nil
+}
+
+// ApplyIncludes copies or links planned includes into their worktree
+// Existing targets are kept unless replace is set (resyncing from the root checkout)
+func ApplyIncludes(actions []IncludeAction, replace bool) error {
+	var failed []string
+	for _, action := range actions {
+		if action.Exists && !replace {
+			continue
+		}
+		if err := applyInclude(action); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", action.Path, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to include %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// applyInclude puts a single file or directory into the worktree, replacing what's there
+func applyInclude(action IncludeAction) error {
+	// Never replace anything outside the worktree
+	if !filepath.IsLocal(action.Path) {
+		return fmt.Errorf("path is outside the worktree")
+	}
+	if err := os.RemoveAll(action.Target); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(action.Target), 0755); err != nil {
+		return err
+	}
+
+	switch action.Mode {
+	case config.IncludeSymlink:
+		return os.Symlink(action.Source, action.Target)
+	case config.IncludeHardlink:
+		return copyTree(action.Source, action.Target, os.Link)
+	default:
+		return copyTree(action.Source, action.Target, copyFile)
+	}
+}
+
+// copyTree recreates a file or directory at target, putting each regular file there with place
+// Symlinks are recreated as they are
+func copyTree(source, target string, place func(source, target string) error) error {
+	return filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(target, rel)
+
+		switch {
+		case entry.IsDir():
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(dest, info.Mode().Perm())
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, dest)
+		case entry.Type().IsRegular():
+			return place(path, dest)
+		}
+		// Sockets, devices, and pipes aren't copied
+		return nil
+	})
+}
+
+// copyFile copies a regular file, keeping its permissions
+func copyFile(source, target string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// isTracked reports whether git tracks a path (or anything under it) in the root checkout
+func isTracked(root, path string) bool {
+	output, err := exec.Command("git", "-C", root, "ls-files", "--", path).Output()
+	return err == nil && strings.TrimSpace(string(output)) != ""
+}
+
+// isWithin reports whether path is dir or inside it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// TestApplyIncludes tests copying and linking include files into a worktree, and replacing them on resync
+func TestApplyIncludes(t *testing.T) {
+	root := t.TempDir()
+	worktree := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "certs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string]string{".env": "KEY=root", "certs/dev.pem": "cert", "seed.sql": "seed"} {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".env"), []byte("KEY=worktree"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	action := func(path, mode string, isDir, exists bool) IncludeAction {
+		return IncludeAction{Path: path, Source: filepath.Join(root, path), Target: filepath.Join(worktree, path), Mode: mode, IsDir: isDir, Exists: exists}
+	}
+	actions := []IncludeAction{
+		action(".env", config.IncludeCopy, false, true),
+		action("certs", config.IncludeSymlink, true, false),
+		action("seed.sql", config.IncludeHardlink, false, false),
+	}
+
+	// Creating a worktree keeps what's there
+	if err := ApplyIncludes(actions, false); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(worktree, ".env")); string(data) != "KEY=worktree" {
+		t.Errorf("Expected the existing .env to be kept, got %q", data)
+	}
+	if link, err := os.Readlink(filepath.Join(worktree, "certs")); err != nil || link != filepath.Join(root, "certs") {
+		t.Errorf("Expected certs to link to the root checkout, got %q (%v)", link, err)
+	}
+	rootInfo, _ := os.Stat(filepath.Join(root, "seed.sql"))
+	linkInfo, err := os.Stat(filepath.Join(worktree, "seed.sql"))
+	if err != nil || !os.SameFile(rootInfo, linkInfo) {
+		t.Errorf("Expected seed.sql to be a hard link (%v)", err)
+	}
+
+	// Resyncing replaces it
+	if err := ApplyIncludes(actions, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(worktree, ".env")); string(data) != "KEY=root" {
+		t.Errorf("Expected .env to be resynced from the root checkout, got %q", data)
+	}
+
+	// Paths outside the worktree are rejected, and nothing there is replaced
+	if err := (config.IncludeRule{Path: "../shared/.env"}).Validate(); err == nil {
+		t.Error("Expected a rule with .. to be rejected")
+	}
+	if err := (config.IncludeRule{Path: filepath.Join(root, ".env")}).Validate(); err == nil {
+		t.Error("Expected an absolute rule to be rejected")
+	}
+	outside := IncludeAction{Path: filepath.Join("..", filepath.Base(root), "seed.sql"), Source: filepath.Join(root, "seed.sql"), Target: filepath.Join(root, "seed.sql"), Mode: config.IncludeCopy}
+	if err := ApplyIncludes([]IncludeAction{outside}, true); err == nil {
+		t.Error("Expected an include outside the worktree to fail")
+	}
+	if _, err := os.Stat(filepath.Join(root, "seed.sql")); err != nil {
+		t.Errorf("Expected the source to be kept: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// LockWorktree locks a worktree so it isn't pruned, moved, or removed, e.g. while it's on a removable drive
+func (m *Manager) LockWorktree(path, reason string) error {
+	args := []string{"-C", m.repoPath, "worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	args = append(args, path)
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to lock worktree: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// UnlockWorktree unlocks a locked worktree
+func (m *Manager) UnlockWorktree(path string) error {
+	if output, err := exec.Command("git", "-C", m.repoPath, "worktree", "unlock", path).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to unlock worktree: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RepairWorktree repairs the links between a worktree and the repository, e.g. after the worktree
+// directory was moved without git; path is where the worktree is now
+func (m *Manager) RepairWorktree(path string) error {
+	if output, err := exec.Command("git", "-C", m.repoPath, "worktree", "repair", path).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to repair worktree: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// PruneWorktrees removes the administrative data of prunable worktrees (whose directory is gone);
+// locked worktrees are kept
+// Returns what was pruned, one line per worktree
+func (m *Manager) PruneWorktrees() ([]string, error) {
+	output, err := exec.Command("git", "-C", m.repoPath, "worktree", "prune", "--verbose").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune worktrees: %s", strings.TrimSpace(string(output)))
+	}
+	var pruned []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			pruned = append(pruned, line)
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestWorktreeMaintenance tests that locked and prunable worktrees are listed as such, including locked
+// ones whose directory is missing, and pruning forgets only the prunable ones that aren't locked
+func TestWorktreeMaintenance(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	testGit(t, repo, "init", "-q")
+	testGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+	for _, name := range []string{"usb", "gone", "kept"} {
+		testGit(t, repo, "worktree", "add", "-q", "-b", name, filepath.Join(dir, name))
+	}
+
+	m := NewManager(repo)
+	if err := m.LockWorktree(filepath.Join(dir, "usb"), "on external drive"); err != nil {
+		t.Fatal(err)
+	}
+	// Unplugging the drive and deleting a directory both leave prunable worktrees
+	for _, name := range []string{"usb", "gone"} {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	worktrees, err := m.ListLightweight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byBranch := make(map[string]Worktree)
+	for _, wt := range worktrees {
+		byBranch[wt.Branch] = wt
+	}
+	if usb := byBranch["usb"]; !usb.Locked || usb.LockReason != "on external drive" {
+		t.Errorf("Expected usb to be locked on external drive, got %+v", usb)
+	}
+	// git doesn't report it as prunable because it's locked, its directory is missing all the same
+	if usb := byBranch["usb"]; !usb.Prunable || usb.PrunableReason == "" {
+		t.Errorf("Expected usb to be missing, got %+v", usb)
+	}
+	if gone := byBranch["gone"]; !gone.Prunable || gone.PrunableReason == "" {
+		t.Errorf("Expected gone to be prunable, got %+v", gone)
+	}
+	if kept := byBranch["kept"]; kept.Locked || kept.Prunable {
+		t.Errorf("Expected kept to be neither locked nor prunable, got %+v", kept)
+	}
+
+	pruned, err := m.PruneWorktrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pruned) != 1 {
+		t.Errorf("Expected only gone to be pruned, got %v", pruned)
+	}
+	if worktrees, _ := m.ListLightweight(); len(worktrees) != 3 {
+		t.Errorf("Expected the repo, usb, and kept to remain, got %+v", worktrees)
+	}
+
+	if err := m.UnlockWorktree(filepath.Join(dir, "usb")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.PruneWorktrees(); err != nil {
+		t.Fatal(err)
+	}
+	if worktrees, _ := m.ListLightweight(); len(worktrees) != 2 {
+		t.Errorf("Expected usb to be pruned once unlocked, got %+v", worktrees)
+	}
+}
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPathTemplate puts worktrees in the .workspaces directory of the main checkout
+const DefaultPathTemplate = "{root}/.workspaces/{branch}"
+
+// SetPathTemplate sets the template for new worktree paths, "" = DefaultPathTemplate
+func (m *Manager) SetPathTemplate(template string) {
+	m.pathTemplate = template
+}
+
+// PathTemplate returns the template for new worktree paths
+func (m *Manager) PathTemplate() string {
+	if m.pathTemplate == "" {
+		if m.IsBare() {
+			return BarePathTemplate
+		}
+		return DefaultPathTemplate
+	}
+	return m.pathTemplate
+}
+
+// RepoName returns the name of the repository at root, its directory name without .git, or the
+// project directory for the project/.bare layout
+func RepoName(root string) string {
+	repo := strings.TrimSuffix(filepath.Base(root), ".git")
+	if repo == ".bare" {
+		repo = filepath.Base(filepath.Dir(root))
+	}
+	return repo
+}
+
+// ExpandPathTemplate returns the worktree path a template gives for a branch directory name
+// Placeholders: {root} (main checkout, or bare directory), {repo} (its directory name, "app" for
+// app.git and app/.bare), {branch}
+// A leading ~/ is the home directory, and relative templates are relative to the main checkout
+// (so "../{repo}-{branch}" puts worktrees next to it)
+func ExpandPathTemplate(template, root, branch string) (string, error) {
+	if !strings.Contains(template, "{branch}") {
+		return "", fmt.Errorf("worktree path template %q has no {branch}", template)
+	}
+
+	path := strings.NewReplacer("{root}", root, "{repo}", RepoName(root), "{branch}", branch).Replace(template)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	return filepath.Clean(path), nil
+}
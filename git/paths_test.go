@@ -0,0 +1,48 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExpandPathTemplate tests building worktree paths from path templates
+func TestExpandPathTemplate(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	tests := map[string]string{
+		DefaultPathTemplate:           "/src/app/.workspaces/feature-login",
+		"../{repo}-{branch}":          "/src/app-feature-login",
+		"~/worktrees/{repo}/{branch}": filepath.Join(home, "worktrees", "app", "feature-login"),
+		"/tmp/wt/{branch}":            "/tmp/wt/feature-login",
+	}
+	for template, want := range tests {
+		got, err := ExpandPathTemplate(template, "/src/app", "feature-login")
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", template, err)
+		} else if got != want {
+			t.Errorf("%s: expected %s, got %s", template, want, got)
+		}
+	}
+
+	if _, err := ExpandPathTemplate("~/worktrees/{repo}", "/src/app", "feature-login"); err == nil {
+		t.Error("Expected an error for a template without {branch}")
+	}
+}
+
+// TestRepoName tests naming a repository by its main checkout or bare directory
+func TestRepoName(t *testing.T) {
+	tests := map[string]string{
+		"/src/app":       "app",
+		"/src/app.git":   "app",
+		"/src/app/.bare": "app",
+	}
+	for root, want := range tests {
+		if got := RepoName(root); got != want {
+			t.Errorf("%s: expected %s, got %s", root, want, got)
+		}
+	}
+}
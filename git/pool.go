@@ -0,0 +1,120 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for working on many worktrees at once
+const (
+	DefaultConcurrency   = 4                // Worktrees whose status or pull runs at the same time
+	DefaultStatusTimeout = 10 * time.Second // Per git command computing a worktree's status
+	DefaultPullTimeout   = 2 * time.Minute  // Per git command pulling a worktree
+)
+
+// ErrTimeout is returned (wrapped) when a status or pull command was killed for taking too long
+var ErrTimeout = errors.New("timed out")
+
+// Pool bounds how many worktrees are worked on at once
+type Pool struct {
+	slots chan struct{}
+}
+
+// NewPool creates a pool running at most workers functions at once, workers < 1 = DefaultConcurrency
+func NewPool(workers int) *Pool {
+	if workers < 1 {
+		workers = DefaultConcurrency
+	}
+	return &Pool{slots: make(chan struct{}, workers)}
+}
+
+// Do runs fn once a worker is free
+func (p *Pool) Do(fn func()) {
+	p.slots <- struct{}{}
+	defer func() { <-p.slots }()
+	fn()
+}
+
+// Run runs fn for each index from 0 to n-1 on the pool and waits for all of them
+func (p *Pool) Run(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p.Do(func() { fn(i) })
+		}(i)
+	}
+	wg.Wait()
+}
+
+// SetConcurrency configures the worker pool and the per-command timeouts for status and pull operations
+// Values < 1 use the defaults
+func (m *Manager) SetConcurrency(workers int, statusTimeout, pullTimeout time.Duration) {
+	m.pool = NewPool(workers)
+	m.statusTimeout = statusTimeout
+	m.pullTimeout = pullTimeout
+}
+
+// Pool returns the worker pool status and pull operations run on
+func (m *Manager) Pool() *Pool {
+	if m.pool == nil {
+		m.pool = NewPool(DefaultConcurrency)
+	}
+	return m.pool
+}
+
+// statusGit runs a git command computing a worktree's status, returning its output
+// args start with "-C <path>"
+// It doesn't refresh the index as a side effect, which would wake up the file watcher again
+func (m *Manager) statusGit(args ...string) ([]byte, error) {
+	timeout := m.statusTimeout
+	if timeout <= 0 {
+		timeout = DefaultStatusTimeout
+	}
+	return runGit(timeout, false, append([]string{"--no-optional-locks"}, args...)...)
+}
+
+// pullGit runs a git command pulling a worktree, returning its combined output
+// args start with "-C <path>"
+func (m *Manager) pullGit(args ...string) ([]byte, error) {
+	timeout := m.pullTimeout
+	if timeout <= 0 {
+		timeout = DefaultPullTimeout
+	}
+	return runGit(timeout, true, args...)
+}
+
+// runGit runs a git command that's killed when the timeout expires
+func runGit(timeout time.Duration, combined bool, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	// Children of git (ssh, hooks) may hold the output open after git is killed, don't wait on them
+	cmd.WaitDelay = time.Second
+	var output []byte
+	var err error
+	if combined {
+		output, err = cmd.CombinedOutput()
+	} else {
+		output, err = cmd.Output()
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		// Name the command without the global options
+		name := args
+		for len(name) > 0 && strings.HasPrefix(name[0], "-") {
+			if (name[0] == "-C" || name[0] == "-c") && len(name) > 1 {
+				name = name[1:]
+			}
+			name = name[1:]
+		}
+		return output, fmt.Errorf("git %s %w after %s", strings.Join(name, " "), ErrTimeout, timeout)
+	}
+	return output, err
+}
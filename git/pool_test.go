@@ -0,0 +1,44 @@
+package git
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestPoolBoundsConcurrency tests that a pool never runs more functions at once than it has workers
+func TestPoolBoundsConcurrency(t *testing.T) {
+	pool := NewPool(3)
+	var running, peak, done int32
+	pool.Run(20, func(i int) {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&done, 1)
+	})
+	if done != 20 {
+		t.Errorf("Expected all 20 functions to run, got %d", done)
+	}
+	if peak > 3 {
+		t.Errorf("Expected at most 3 functions at once, got %d", peak)
+	}
+}
+
+// TestRunGitTimeout tests that a git command taking too long is stopped with ErrTimeout
+func TestRunGitTimeout(t *testing.T) {
+	start := time.Now()
+	_, err := runGit(50*time.Millisecond, false, "-c", "alias.slow=!sleep 5", "slow")
+	if time.Since(start) > 3*time.Second {
+		t.Errorf("Expected the command to be stopped, took %s", time.Since(start))
+	}
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("Expected ErrTimeout, got %v", err)
+	}
+}
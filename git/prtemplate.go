@@ -0,0 +1,107 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// prTemplateFiles are the single-file PR template locations used by GitHub, Gitea, and GitLab, in priority order
+var prTemplateFiles = []string{
+	".github/pull_request_template.md",
+	"pull_request_template.md",
+	"docs/pull_request_template.md",
+	".gitea/pull_request_template.md",
+	".gitlab/merge_request_templates/default.md",
+}
+
+// prTemplateDirs are the directories holding multiple PR templates, in priority order
+var prTemplateDirs = []string{
+	".github/PULL_REQUEST_TEMPLATE",
+	"PULL_REQUEST_TEMPLATE",
+	"docs/PULL_REQUEST_TEMPLATE",
+	".gitea/PULL_REQUEST_TEMPLATE",
+	".gitlab/merge_request_templates",
+}
+
+// PRTemplate is a pull request template found in a repository
+type PRTemplate struct {
+	Name    string // File name, or the path relative to the worktree for single-file templates
+	Path    string // Absolute path
+	Content string
+}
+
+// FindPRTemplates returns the pull request templates in a worktree
+// Single-file templates come first, followed by the templates in template directories sorted by name
+// File and directory names are matched case-insensitively; empty templates are skipped
+// Returns nil if the repository has no PR template
+func FindPRTemplates(worktreePath string) []PRTemplate {
+	var templates []PRTemplate
+	seen := make(map[string]bool)
+
+	add := func(name, path string) {
+		if seen[path] {
+			return
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || strings.TrimSpace(string(data)) == "" {
+			return
+		}
+		seen[path] = true
+		templates = append(templates, PRTemplate{Name: name, Path: path, Content: string(data)})
+	}
+
+	for _, file := range prTemplateFiles {
+		if path, ok := findFold(worktreePath, file); ok {
+			rel, _ := filepath.Rel(worktreePath, path)
+			add(filepath.ToSlash(rel), path)
+		}
+	}
+
+	for _, dir := range prTemplateDirs {
+		dirPath, ok := findFold(worktreePath, dir)
+		if !ok {
+			continue
+		}
+		entries, err := os.ReadDir(dirPath)
+		if err != nil {
+			continue
+		}
+		var names []string
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			add(name, filepath.Join(dirPath, name))
+		}
+	}
+
+	return templates
+}
+
+// findFold resolves a slash-separated path below root, matching each element case-insensitively
+func findFold(root, rel string) (string, bool) {
+	path := root
+	for _, part := range strings.Split(rel, "/") {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return "", false
+		}
+		found := false
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name(), part) {
+				path = filepath.Join(path, entry.Name())
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+	return path, true
+}
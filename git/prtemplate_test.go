@@ -0,0 +1,47 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFindPRTemplates tests template discovery order and case-insensitive matching
+func TestFindPRTemplates(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".github/PULL_REQUEST_TEMPLATE.md":         "## Summary",
+		".github/PULL_REQUEST_TEMPLATE/feature.md": "## Feature",
+		".github/PULL_REQUEST_TEMPLATE/bugfix.md":  "## Bug",
+		".github/PULL_REQUEST_TEMPLATE/empty.md":   "  \n",
+		".github/PULL_REQUEST_TEMPLATE/notes.txt":  "not a template",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates := FindPRTemplates(root)
+
+	want := []string{".github/PULL_REQUEST_TEMPLATE.md", "bugfix.md", "feature.md"}
+	if len(templates) != len(want) {
+		t.Fatalf("Expected %d templates, got %d: %v", len(want), len(templates), templates)
+	}
+	for i, name := range want {
+		if templates[i].Name != name {
+			t.Errorf("Template %d: expected %q, got %q", i, name, templates[i].Name)
+		}
+	}
+	if templates[0].Content != "## Summary" {
+		t.Errorf("Expected template content %q, got %q", "## Summary", templates[0].Content)
+	}
+
+	if got := FindPRTemplates(t.TempDir()); got != nil {
+		t.Errorf("Expected no templates, got %v", got)
+	}
+}
@@ -0,0 +1,427 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWatchDebounce is how long a worktree has to be quiet before its changes are reported
+const DefaultWatchDebounce = 300 * time.Millisecond
+
+// watchMaxDelay bounds the debounce, so a worktree that keeps changing (a build) is still reported
+const watchMaxDelay = 2 * time.Second
+
+var (
+	// ErrWatchUnsupported is returned by NewWatcher where file watching isn't available
+	ErrWatchUnsupported = errors.New("file watching is not supported on this platform")
+	// ErrWatchLimit is returned when the inotify watch limit (fs.inotify.max_user_watches) is reached
+	ErrWatchLimit = errors.New("inotify watch limit reached")
+)
+
+// watchKind is what a watched directory is to the worktrees
+type watchKind int
+
+const (
+	watchContent watchKind = iota // A directory of a worktree's files
+	watchGitDir                   // A worktree's git dir: its HEAD and index
+	watchRefs                     // A directory under refs/heads or refs/remotes, shared by all worktrees
+)
+
+// headRefPrefix is how a git dir's HEAD file names the branch checked out
+const headRefPrefix = "ref: refs/heads/"
+
+// watch is a watched directory
+type watch struct {
+	dir      string
+	worktree string // Owning worktree, "" = shared
+	kind     watchKind
+}
+
+// pendingChange is what changed in a worktree since it was last reported
+type pendingChange struct {
+	git   bool     // HEAD, the index, or refs changed
+	paths []string // Changed files, relative to the worktree
+}
+
+// Watcher watches worktrees for changes to their files, HEAD, index, and refs,
+// so their status can be refreshed when it may have changed instead of polling
+// Gitignored files and .git internals other than HEAD, the index, and refs are left out
+type Watcher struct {
+	// Events receives the paths of worktrees whose status may have changed, debounced
+	Events chan []string
+
+	debounce time.Duration
+	done     chan struct{}
+	fd       int      // inotify instance
+	inotify  *os.File // The same, for reading events
+
+	mu           sync.Mutex
+	watches      map[int32]watch
+	worktrees    map[string][]int32 // Watched worktree -> its watches
+	gitDirOwners map[string]string  // Git dir -> the worktree it belongs to
+	commonDir    string
+	baseBranch   string // Moving it changes every worktree's ahead/behind counts
+	pending      map[string]*pendingChange
+	firstPending time.Time
+	timer        *time.Timer
+}
+
+// Sync watches exactly the given worktrees, adding new ones and dropping removed ones
+// Returns the worktrees that couldn't be watched (they should be polled), with ErrWatchLimit
+// if the watch limit was reached
+func (w *Watcher) Sync(paths []string) ([]string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wanted := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		wanted[path] = true
+	}
+	for path := range w.worktrees {
+		if !wanted[path] {
+			w.removeWorktree(path)
+		}
+	}
+
+	var unwatched []string
+	var limitErr error
+	for _, path := range paths {
+		if _, ok := w.worktrees[path]; ok {
+			continue
+		}
+		if limitErr != nil {
+			unwatched = append(unwatched, path)
+			continue
+		}
+		if err := w.addWorktree(path, wanted); err != nil {
+			w.removeWorktree(path)
+			unwatched = append(unwatched, path)
+			if errors.Is(err, ErrWatchLimit) {
+				limitErr = err
+			}
+		}
+	}
+	return unwatched, limitErr
+}
+
+// SetBaseBranch sets the branch worktrees are compared against: when it moves, all worktrees are
+// reported, when another branch moves only the worktrees it's checked out in
+func (w *Watcher) SetBaseBranch(branch string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.baseBranch = branch
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	select {
+	case <-w.done:
+		return nil
+	default:
+	}
+	close(w.done)
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	return w.inotify.Close()
+}
+
+// addWorktree watches a worktree's files (skipping gitignored directories and the other worktrees),
+// its git dir, and the refs shared by all worktrees
+func (w *Watcher) addWorktree(path string, worktrees map[string]bool) error {
+	gitDir, commonDir, err := gitDirs(path)
+	if err != nil {
+		return err
+	}
+	w.worktrees[path] = nil
+
+	if w.commonDir == "" {
+		if _, err := w.addWatch(commonDir, "", watchGitDir); err != nil {
+			return err
+		}
+		w.commonDir = commonDir
+		for _, refs := range []string{"refs/heads", "refs/remotes"} {
+			if err := w.addRefs(filepath.Join(commonDir, refs)); err != nil {
+				return err
+			}
+		}
+	}
+	if gitDir != w.commonDir {
+		if _, err := w.addWatch(gitDir, path, watchGitDir); err != nil {
+			return err
+		}
+	}
+	w.gitDirOwners[gitDir] = path
+
+	return w.addContent(path, path, ignoredDirs(path), worktrees)
+}
+
+// addContent watches a directory of a worktree and its subdirectories
+func (w *Watcher) addContent(worktree, dir string, ignored map[string]bool, worktrees map[string]bool) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil // Vanished or unreadable directories aren't watched
+		}
+		if path != dir && (entry.Name() == ".git" || ignored[path] || worktrees[path]) {
+			return filepath.SkipDir
+		}
+		if _, err := w.addWatch(path, worktree, watchContent); err != nil {
+			if errors.Is(err, ErrWatchLimit) {
+				return err
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// addRefs watches a refs directory and its subdirectories (branches like feature/x)
+func (w *Watcher) addRefs(dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+		if _, err := w.addWatch(path, "", watchRefs); err != nil && errors.Is(err, ErrWatchLimit) {
+			return err
+		}
+		return nil
+	})
+}
+
+// addWatch watches a directory and records what it is
+func (w *Watcher) addWatch(dir, worktree string, kind watchKind) (int32, error) {
+	wd, err := w.addDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	w.watches[wd] = watch{dir: dir, worktree: worktree, kind: kind}
+	if worktree != "" {
+		w.worktrees[worktree] = append(w.worktrees[worktree], wd)
+	}
+	return wd, nil
+}
+
+// removeWorktree stops watching a worktree, keeping the watches shared with the others
+func (w *Watcher) removeWorktree(path string) {
+	for _, wd := range w.worktrees[path] {
+		w.removeDir(wd)
+		delete(w.watches, wd)
+	}
+	delete(w.worktrees, path)
+	delete(w.pending, path)
+	for dir, owner := range w.gitDirOwners {
+		if owner == path {
+			delete(w.gitDirOwners, dir)
+		}
+	}
+}
+
+// handleEvent records a change to a watched directory, watching directories created in it
+// name is the changed entry, "" = the directory itself; overflow = events were lost
+func (w *Watcher) handleEvent(wd int32, name string, createdDir, removed, overflow bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if overflow {
+		for path := range w.worktrees {
+			w.changed(path, "")
+		}
+		return
+	}
+	watched, ok := w.watches[wd]
+	if !ok {
+		return
+	}
+	if removed {
+		delete(w.watches, wd)
+		return
+	}
+	path := filepath.Join(watched.dir, name)
+
+	switch watched.kind {
+	case watchContent:
+		if name == "" || (name == ".git" && watched.dir == watched.worktree) {
+			return
+		}
+		if _, isWorktree := w.worktrees[path]; createdDir && !isWorktree && !isIgnored(watched.worktree, path) {
+			_ = w.addContent(watched.worktree, path, nil, w.worktreeSet())
+		}
+		rel, err := filepath.Rel(watched.worktree, path)
+		if err != nil {
+			return
+		}
+		w.changed(watched.worktree, rel)
+	case watchGitDir:
+		switch {
+		case name == "HEAD" || name == "index":
+			if owner, ok := w.gitDirOwners[watched.dir]; ok {
+				w.changed(owner, "")
+			}
+		case name == "packed-refs" && watched.dir == w.commonDir:
+			for path := range w.worktrees {
+				w.changed(path, "")
+			}
+		}
+	case watchRefs:
+		if strings.HasSuffix(name, ".lock") {
+			return
+		}
+		if createdDir {
+			_ = w.addRefs(path)
+		}
+		// A branch only changes the worktrees on it, unless it's the base branch; remote refs (which
+		// worktrees may be compared against) can change any worktree's ahead/behind counts
+		rel, err := filepath.Rel(filepath.Join(w.commonDir, "refs", "heads"), path)
+		if err != nil || strings.HasPrefix(rel, "..") || filepath.ToSlash(rel) == w.baseBranch {
+			for path := range w.worktrees {
+				w.changed(path, "")
+			}
+			return
+		}
+		for gitDir, owner := range w.gitDirOwners {
+			if headBranch(gitDir) == filepath.ToSlash(rel) {
+				w.changed(owner, "")
+			}
+		}
+	}
+}
+
+// headBranch returns the branch checked out in a git dir, "" if its HEAD is detached or unreadable
+func headBranch(gitDir string) string {
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	branch, ok := strings.CutPrefix(strings.TrimSpace(string(head)), headRefPrefix)
+	if !ok {
+		return ""
+	}
+	return branch
+}
+
+// worktreeSet returns the watched worktrees
+func (w *Watcher) worktreeSet() map[string]bool {
+	set := make(map[string]bool, len(w.worktrees))
+	for path := range w.worktrees {
+		set[path] = true
+	}
+	return set
+}
+
+// changed records a change to a worktree and (re)starts the debounce, rel "" = git state
+func (w *Watcher) changed(worktree, rel string) {
+	change, ok := w.pending[worktree]
+	if !ok {
+		change = &pendingChange{}
+		w.pending[worktree] = change
+	}
+	if rel == "" {
+		change.git = true
+	} else {
+		change.paths = append(change.paths, rel)
+	}
+
+	if w.timer == nil {
+		w.firstPending = time.Now()
+		w.timer = time.AfterFunc(w.debounce, w.flush)
+	} else if time.Since(w.firstPending) < watchMaxDelay {
+		w.timer.Reset(w.debounce)
+	}
+}
+
+// flush reports the worktrees changed since the last flush, leaving out those where only
+// gitignored files changed
+func (w *Watcher) flush() {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = make(map[string]*pendingChange)
+	w.timer = nil
+	w.mu.Unlock()
+
+	var changed []string
+	for worktree, change := range pending {
+		if change.git || !allIgnored(worktree, change.paths) {
+			changed = append(changed, worktree)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	select {
+	case w.Events <- changed:
+	case <-w.done:
+	}
+}
+
+// gitDirs returns a worktree's own git dir (holding its HEAD and index) and the common dir
+// shared by all worktrees of the repository
+func gitDirs(worktree string) (gitDir, commonDir string, err error) {
+	output, err := exec.Command("git", "-C", worktree, "rev-parse", "--absolute-git-dir", "--git-common-dir").Output()
+	if err != nil {
+		return "", "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 {
+		return "", "", errors.New("unexpected rev-parse output")
+	}
+	gitDir, commonDir = lines[0], lines[1]
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(worktree, commonDir)
+	}
+	return filepath.Clean(gitDir), filepath.Clean(commonDir), nil
+}
+
+// ignoredDirs returns the gitignored directories of a worktree (node_modules, build output, ...)
+func ignoredDirs(worktree string) map[string]bool {
+	dirs := make(map[string]bool)
+	output, err := exec.Command("git", "-C", worktree, "ls-files", "-z", "--others", "--ignored", "--exclude-standard", "--directory").Output()
+	if err != nil {
+		return dirs
+	}
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if strings.HasSuffix(entry, "/") {
+			dirs[filepath.Join(worktree, entry)] = true
+		}
+	}
+	return dirs
+}
+
+// isIgnored checks whether a path in a worktree is gitignored
+func isIgnored(worktree, path string) bool {
+	return exec.Command("git", "-C", worktree, "check-ignore", "-q", path).Run() == nil
+}
+
+// allIgnored checks whether all of the paths (relative to the worktree) are gitignored
+func allIgnored(worktree string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+	unique := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		unique[path] = true
+	}
+	var input bytes.Buffer
+	for path := range unique {
+		input.WriteString(path)
+		input.WriteByte(0)
+	}
+	cmd := exec.Command("git", "-C", worktree, "check-ignore", "-z", "--stdin")
+	cmd.Stdin = &input
+	output, _ := cmd.Output() // Exits 1 when nothing is ignored
+	ignored := 0
+	for _, path := range strings.Split(string(output), "\x00") {
+		if unique[path] {
+			ignored++
+		}
+	}
+	return ignored == len(unique)
+}
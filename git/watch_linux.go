@@ -0,0 +1,74 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// watchMask is what's watched in each directory: files written, created, deleted, or renamed
+const watchMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_ONLYDIR
+
+// NewWatcher creates a watcher reporting changes once they've been quiet for debounce
+func NewWatcher(debounce time.Duration) (*Watcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		Events:       make(chan []string, 1),
+		debounce:     debounce,
+		done:         make(chan struct{}),
+		fd:           fd,
+		inotify:      os.NewFile(uintptr(fd), "inotify"), // Non-blocking, so Close stops a pending Read
+		watches:      make(map[int32]watch),
+		worktrees:    make(map[string][]int32),
+		gitDirOwners: make(map[string]string),
+		pending:      make(map[string]*pendingChange),
+	}
+	go w.readEvents()
+	return w, nil
+}
+
+// addDir adds an inotify watch for a directory
+func (w *Watcher) addDir(dir string) (int32, error) {
+	wd, err := syscall.InotifyAddWatch(w.fd, dir, watchMask)
+	if err != nil {
+		if errors.Is(err, syscall.ENOSPC) {
+			return 0, ErrWatchLimit
+		}
+		return 0, err
+	}
+	return int32(wd), nil
+}
+
+// removeDir removes an inotify watch
+func (w *Watcher) removeDir(wd int32) {
+	_, _ = syscall.InotifyRmWatch(w.fd, uint32(wd))
+}
+
+// readEvents reads inotify events until the watcher is closed
+func (w *Watcher) readEvents() {
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := w.inotify.Read(buf)
+		if err != nil {
+			return
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			nameEnd := nameStart + int(event.Len)
+			if nameEnd > n {
+				break
+			}
+			name := string(bytes.TrimRight(buf[nameStart:nameEnd], "\x00"))
+			createdDir := event.Mask&syscall.IN_ISDIR != 0 && event.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0
+			w.handleEvent(event.Wd, name, createdDir, event.Mask&syscall.IN_IGNORED != 0, event.Mask&syscall.IN_Q_OVERFLOW != 0)
+			offset = nameEnd
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestWatcher tests that changes to a worktree's files are reported, and changes to gitignored files aren't
+func TestWatcher(t *testing.T) {
+	repo := t.TempDir()
+	testGit(t, repo, "init", "-q")
+	testGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte("build/\n*.log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(repo, "build"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWatcher(20 * time.Millisecond)
+	if err != nil {
+		t.Skipf("no inotify: %v", err)
+	}
+	defer w.Close()
+	if unwatched, err := w.Sync([]string{repo}); err != nil || len(unwatched) > 0 {
+		t.Fatalf("Expected the repo to be watched, got %v (%v)", unwatched, err)
+	}
+
+	expectEvent := func(want bool, what string) {
+		t.Helper()
+		select {
+		case paths := <-w.Events:
+			if !want {
+				t.Errorf("Expected no change reported for %s, got %v", what, paths)
+			} else if len(paths) != 1 || paths[0] != repo {
+				t.Errorf("Expected %s to report the repo, got %v", what, paths)
+			}
+		case <-time.After(time.Second):
+			if want {
+				t.Errorf("Expected a change reported for %s", what)
+			}
+		}
+	}
+
+	// Gitignored files, in ignored directories or not, are left out
+	if err := os.WriteFile(filepath.Join(repo, "build", "out.bin"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "debug.log"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(false, "ignored files")
+
+	// Files in new directories are watched too
+	if err := os.MkdirAll(filepath.Join(repo, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(true, "a new directory")
+	if err := os.WriteFile(filepath.Join(repo, "src", "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(true, "a new file")
+
+	// So are the index and HEAD
+	testGit(t, repo, "add", "src")
+	expectEvent(true, "staging")
+}
+
+// TestWatcherRefs tests that a branch moving reports only the worktrees on it, and the base branch all of them
+func TestWatcherRefs(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	feature := filepath.Join(dir, "feature")
+	testGit(t, "", "init", "-q", "-b", "main", repo)
+	testGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+	testGit(t, repo, "worktree", "add", "-q", "-b", "feature", feature)
+	testGit(t, repo, "branch", "other")
+	testGit(t, repo, "commit", "-q", "--allow-empty", "-m", "next")
+
+	w, err := NewWatcher(20 * time.Millisecond)
+	if err != nil {
+		t.Skipf("no inotify: %v", err)
+	}
+	defer w.Close()
+	w.SetBaseBranch("main")
+	if unwatched, err := w.Sync([]string{repo, feature}); err != nil || len(unwatched) > 0 {
+		t.Fatalf("Expected the worktrees to be watched, got %v (%v)", unwatched, err)
+	}
+
+	expectEvent := func(what string, want ...string) {
+		t.Helper()
+		select {
+		case paths := <-w.Events:
+			slices.Sort(paths)
+			if !slices.Equal(paths, want) {
+				t.Errorf("Expected %s to report %v, got %v", what, want, paths)
+			}
+		case <-time.After(time.Second):
+			if len(want) > 0 {
+				t.Errorf("Expected %s to report %v", what, want)
+			}
+		}
+	}
+
+	testGit(t, repo, "update-ref", "refs/heads/feature", "main")
+	expectEvent("moving feature", feature)
+	testGit(t, repo, "update-ref", "refs/heads/other", "main")
+	expectEvent("moving a branch without a worktree")
+	testGit(t, repo, "update-ref", "refs/heads/main", "main~1")
+	expectEvent("moving the base branch", feature, repo)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package git
+
+import "time"
+
+// NewWatcher creates a watcher reporting changes once they've been quiet for debounce
+// Only Linux (inotify) is supported; elsewhere worktrees are polled
+func NewWatcher(debounce time.Duration) (*Watcher, error) {
+	return nil, ErrWatchUnsupported
+}
+
+func (w *Watcher) addDir(dir string) (int32, error) {
+	return 0, ErrWatchUnsupported
+}
+
+func (w *Watcher) removeDir(wd int32) {}
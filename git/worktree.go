@@ -2,13 +2,16 @@ package git
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/coollabsio/jean-tui/config"
@@ -27,11 +30,30 @@ type Worktree struct {
 	PRs               interface{}      // []config.PRInfo - Pull requests for this branch (loaded from config)
 	LastModified      time.Time        // Last modification time of the worktree directory
 	ClaudeSessionName string           // Sanitized tmux session name for Claude (e.g., "jean-feature-add-status")
+	Locked            bool             // Locked against pruning, moving, and removal (git worktree lock)
+	LockReason        string           // Why it's locked, "" if no reason was given
+	Prunable          bool             // Its directory is gone (deleted, moved, or on an unmounted drive)
+	PrunableReason    string           // Why git considers it prunable, or "directory is missing"
+	Detached          bool             // HEAD is detached (at a tag, commit, or PR), Branch is only a label
+	Protected         bool             // Can't be deleted: the main checkout, or in a bare repository the worktree of the default branch (jean.json is read from it) or the one jean was launched from
 }
 
 // Manager handles Git worktree operations
 type Manager struct {
-	repoPath string
+	repoPath       string
+	pushRemote     string // Remote branches are pushed to ("" = origin)
+	upstreamRemote string // Remote PRs target and base branches are compared against ("" = origin)
+	pathTemplate   string // Template for new worktree paths ("" = DefaultPathTemplate)
+	branchTemplate string         // Template for new branch names ("" = random names)
+	branchPattern  *regexp.Regexp // Branch names must match this (nil = any name)
+	branchUser     string         // git user.name for the {user} placeholder
+	dependencySeeding string      // How dependency dirs of new worktrees are seeded ("" = off)
+	pool           *Pool          // Bounds how many worktrees are worked on at once (nil = DefaultConcurrency)
+	statusTimeout  time.Duration  // Per git command computing a worktree's status (0 = DefaultStatusTimeout)
+	pullTimeout    time.Duration  // Per git command pulling a worktree (0 = DefaultPullTimeout)
+	bareOnce       sync.Once      // Detects a bare repository once (see IsBare)
+	bare           bool
+	fetchMu        sync.Mutex     // Held while fetching, background fetches are skipped while another one runs
 }
 
 // NewManager creates a new worktree manager
@@ -39,6 +61,49 @@ func NewManager(repoPath string) *Manager {
 	return &Manager{repoPath: repoPath}
 }
 
+// SetRemotes configures the remotes for a fork-based workflow, where branches are pushed to a fork
+// and compared against the upstream repository; empty names default to "origin"
+func (m *Manager) SetRemotes(pushRemote, upstreamRemote string) {
+	m.pushRemote = pushRemote
+	m.upstreamRemote = upstreamRemote
+}
+
+// PushRemote returns the remote branches are pushed to
+func (m *Manager) PushRemote() string {
+	if m.pushRemote == "" {
+		return "origin"
+	}
+	return m.pushRemote
+}
+
+// UpstreamRemote returns the remote PRs target and base branches are compared against
+func (m *Manager) UpstreamRemote() string {
+	if m.upstreamRemote == "" {
+		return "origin"
+	}
+	return m.upstreamRemote
+}
+
+// IsForkWorkflow returns true if branches are pushed to a different remote than the upstream
+func (m *Manager) IsForkWorkflow() bool {
+	return m.PushRemote() != m.UpstreamRemote()
+}
+
+// BaseRef returns the ref to compare and merge the base branch from
+// Fetches only move the upstream's remote-tracking branch (e.g. origin/main or upstream/main), and the
+// local base branch is usually stale, so the remote-tracking branch is used when it exists
+func (m *Manager) BaseRef(baseBranch string) string {
+	if baseBranch == "" {
+		return baseBranch
+	}
+	ref := m.UpstreamRemote() + "/" + baseBranch
+	cmd := exec.Command("git", "-C", m.repoPath, "rev-parse", "--verify", "--quiet", "refs/remotes/"+ref)
+	if err := cmd.Run(); err != nil {
+		return baseBranch
+	}
+	return ref
+}
+
 // List returns all worktrees in the repository with status relative to the base branch
 func (m *Manager) List(baseBranch string) ([]Worktree, error) {
 	cmd := exec.Command("git", "-C", m.repoPath, "worktree", "list", "--porcelain")
@@ -80,10 +145,15 @@ func (m *Manager) ListLightweight() ([]Worktree, error) {
 func (m *Manager) parseWorktrees(output string, baseBranch string, lightweight bool) ([]Worktree, error) {
 	var worktrees []Worktree
 	var current Worktree
+	isBare := false // The entry of a bare repository itself, which has no checkout
 
 	lines := strings.Split(strings.TrimSpace(output), "\n")
 	for _, line := range lines {
 		if line == "" {
+			if isBare {
+				current = Worktree{}
+				isBare = false
+			}
 			if current.Path != "" {
 				// Populate LastModified time before adding to list
 				if modTime, err := m.getWorktreeModTime(current.Path); err == nil {
@@ -95,13 +165,18 @@ func (m *Manager) parseWorktrees(output string, baseBranch string, lightweight b
 			continue
 		}
 
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) < 2 {
+		if line == "bare" {
+			isBare = true
 			continue
 		}
 
+		// Attributes like "locked" may come without a value
+		parts := strings.SplitN(line, " ", 2)
 		key := parts[0]
-		value := parts[1]
+		value := ""
+		if len(parts) == 2 {
+			value = parts[1]
+		}
 
 		switch key {
 		case "worktree":
@@ -112,12 +187,19 @@ func (m *Manager) parseWorktrees(output string, baseBranch string, lightweight b
 			// Remove "refs/heads/" prefix
 			current.Branch = strings.TrimPrefix(value, "refs/heads/")
 		case "detached":
-			current.Branch = fmt.Sprintf("(detached at %s)", current.Commit[:7])
+			current.Branch = DetachedBranchLabel(current.Commit)
+			current.Detached = true
+		case "locked":
+			current.Locked = true
+			current.LockReason = value
+		case "prunable":
+			current.Prunable = true
+			current.PrunableReason = value
 		}
 	}
 
 	// Add the last worktree if exists
-	if current.Path != "" {
+	if current.Path != "" && !isBare {
 		// Populate LastModified time before adding to list
 		if modTime, err := m.getWorktreeModTime(current.Path); err == nil {
 			current.LastModified = modTime
@@ -125,48 +207,82 @@ func (m *Manager) parseWorktrees(output string, baseBranch string, lightweight b
 		worktrees = append(worktrees, current)
 	}
 
-	// Mark current worktree and check for uncommitted changes
+	// git doesn't report locked worktrees as prunable, so check their directories (e.g. on an unplugged
+	// drive) ourselves, like any other git missed
+	for i := range worktrees {
+		if _, err := os.Stat(worktrees[i].Path); err != nil && !worktrees[i].Prunable {
+			worktrees[i].Prunable = true
+			worktrees[i].PrunableReason = "directory is missing"
+		}
+	}
+
+	// Mark current worktree (a bare repository has no main checkout) and check for uncommitted changes
 	currentPath, err := m.getCurrentPath()
-	if err == nil {
+	if err == nil && !m.IsBare() {
 		for i := range worktrees {
 			if worktrees[i].Path == currentPath {
 				worktrees[i].IsCurrent = true
+				worktrees[i].Protected = true
 			}
 		}
-	}
-
-	// Check for uncommitted changes in each worktree (skip if lightweight mode)
-	if !lightweight {
+	} else if m.IsBare() {
+		// Protect the worktree CheckoutRoot reads from and the one jean was launched from instead
+		defaultBranch := m.defaultBranchName()
+		checkoutRootFound := false
 		for i := range worktrees {
-			hasUncommitted, err := m.HasUncommittedChanges(worktrees[i].Path)
-			if err == nil {
-				worktrees[i].HasUncommitted = hasUncommitted
+			if !checkoutRootFound && defaultBranch != "" && worktrees[i].Branch == defaultBranch {
+				worktrees[i].Protected = true
+				checkoutRootFound = true
+			}
+			if err == nil && worktrees[i].Path == currentPath {
+				worktrees[i].Protected = true
 			}
 		}
 	}
 
-	// Calculate branch status relative to base branch (skip if lightweight mode)
-	if !lightweight && baseBranch != "" {
-		for i := range worktrees {
-			// Skip detached HEAD and main repo worktrees
-			if strings.HasPrefix(worktrees[i].Branch, "(detached") {
-				continue
+	// Check for uncommitted changes and calculate branch status relative to base branch,
+	// several worktrees at a time (skip if lightweight mode)
+	if !lightweight {
+		m.Pool().Run(len(worktrees), func(i int) {
+			if worktrees[i].Prunable {
+				return // Its directory is gone
 			}
+			status := m.GetWorktreeStatus(worktrees[i], baseBranch)
+			worktrees[i].HasUncommitted = status.HasUncommitted
+			worktrees[i].AheadCount = status.AheadCount
+			worktrees[i].BehindCount = status.BehindCount
+			worktrees[i].IsOutdated = status.BehindCount > 0
+		})
+	}
 
-			// Skip if we can't get branch status (base branch might not exist locally)
-			aheadCount, behindCount, err := m.GetBranchStatus(worktrees[i].Path, worktrees[i].Branch, baseBranch)
-			if err != nil {
-				// Silent skip - base branch might not exist locally or there might be other issues
-				continue
-			}
+	return worktrees, nil
+}
 
-			worktrees[i].AheadCount = aheadCount
-			worktrees[i].BehindCount = behindCount
-			worktrees[i].IsOutdated = behindCount > 0
+// WorktreeStatus is the status of a worktree: uncommitted changes and ahead/behind counts
+type WorktreeStatus struct {
+	HasUncommitted bool
+	AheadCount     int
+	BehindCount    int
+	Duration       time.Duration // How long computing the status took
+}
+
+// GetWorktreeStatus computes the status of a worktree relative to the base branch
+// Counts are left at 0 for detached HEADs and when the base branch doesn't exist locally
+func (m *Manager) GetWorktreeStatus(wt Worktree, baseBranch string) WorktreeStatus {
+	start := time.Now()
+	var status WorktreeStatus
+	if hasUncommitted, err := m.HasUncommittedChanges(wt.Path); err == nil {
+		status.HasUncommitted = hasUncommitted
+	}
+	if baseBranch != "" && !wt.Detached {
+		// Silent skip - base branch might not exist locally or there might be other issues
+		if ahead, behind, err := m.GetBranchStatus(wt.Path, wt.Branch, baseBranch); err == nil {
+			status.AheadCount = ahead
+			status.BehindCount = behind
 		}
 	}
-
-	return worktrees, nil
+	status.Duration = time.Since(start)
+	return status
 }
 
 // getWorktreeModTime returns the modification time of a worktree directory
@@ -276,8 +392,15 @@ func (m *Manager) Create(path, branch string, newBranch bool, baseBranch string)
 	args := []string{"-C", m.repoPath, "worktree", "add"}
 	workspacePath := path // May be adjusted below
 
+	// When creating new branch, start from the base branch (its remote-tracking branch when there is one)
+	startPoint := m.BaseRef(baseBranch)
+
 	if newBranch {
 		args = append(args, "-b", branch)
+		if startPoint != baseBranch {
+			// Don't track the remote base branch; the new branch is pushed to a branch of its own
+			args = append(args, "--no-track")
+		}
 	} else if isRemoteBranch(branch) {
 		// For remote branches, check if local branch already exists
 		localBranch := getLocalBranchName(branch)
@@ -299,7 +422,7 @@ func (m *Manager) Create(path, branch string, newBranch bool, baseBranch string)
 		args = append(args, branch)
 	} else if baseBranch != "" {
 		// When creating new branch, specify the base branch to start from
-		args = append(args, baseBranch)
+		args = append(args, startPoint)
 	}
 
 	cmd := exec.Command("git", args...)
@@ -307,11 +430,51 @@ func (m *Manager) Create(path, branch string, newBranch bool, baseBranch string)
 		return fmt.Errorf("failed to create worktree: %s", string(output))
 	}
 
-	// Execute setup script if configured (non-blocking - errors are returned but don't prevent worktree usage)
-	if err := m.executeSetupScript(workspacePath); err != nil {
-		return fmt.Errorf("setup script failed: %w", err)
+	return m.prepareWorktree(workspacePath)
+}
+
+// PrepareError is returned when a worktree was created but getting it ready partly failed
+// The worktree is usable, so callers should show this as a warning rather than a failure
+type PrepareError struct {
+	IncludeErr error // Copying or linking the include files of jean.json failed
+	SetupErr   error // The setup script failed
+}
+
+func (e *PrepareError) Error() string {
+	var problems []string
+	if e.IncludeErr != nil {
+		problems = append(problems, "include failed: "+e.IncludeErr.Error())
 	}
+	if e.SetupErr != nil {
+		problems = append(problems, "setup script failed: "+e.SetupErr.Error())
+	}
+	return strings.Join(problems, "\n")
+}
 
+// prepareWorktree gets a new worktree ready to work in: include files, dependency directories, and the setup script
+// Every step runs even if an earlier one failed; failures are returned as a *PrepareError
+func (m *Manager) prepareWorktree(workspacePath string) error {
+	var prepareErr PrepareError
+
+	// Copy or link the include files of jean.json, so the setup script can use them
+	// (unless this is the checkout they come from, the default branch's worktree of a bare repository)
+	if root, err := m.CheckoutRoot(); err != nil || root != workspacePath {
+		actions, err := m.PlanIncludes(workspacePath)
+		if err == nil {
+			err = ApplyIncludes(actions, false)
+		}
+		prepareErr.IncludeErr = err
+	}
+
+	// Seed dependency directories from a checkout with the same lockfile (best effort, the setup script installs the rest)
+	_, _ = m.SeedDependencies(workspacePath)
+
+	// Execute setup script if configured (non-blocking - errors are returned but don't prevent worktree usage)
+	prepareErr.SetupErr = m.executeSetupScript(workspacePath)
+
+	if prepareErr.IncludeErr != nil || prepareErr.SetupErr != nil {
+		return &prepareErr
+	}
 	return nil
 }
 
@@ -319,7 +482,7 @@ func (m *Manager) Create(path, branch string, newBranch bool, baseBranch string)
 // Returns error if script execution fails, nil if no script configured or script succeeds
 func (m *Manager) executeSetupScript(workspacePath string) error {
 	// Load script config from repository root
-	repoRoot, err := m.GetRepoRoot()
+	repoRoot, err := m.CheckoutRoot()
 	if err != nil {
 		return fmt.Errorf("failed to get repo root: %w", err)
 	}
@@ -411,8 +574,12 @@ func isProtectedBranch(branchName string) bool {
 }
 
 // MoveWorktree moves a worktree to a new location using git worktree move
-// This is used to rename the worktree directory when a branch is renamed
+// This is used to rename the worktree directory when a branch is renamed, and to migrate worktrees
+// to a new path template
 func (m *Manager) MoveWorktree(oldPath, newPath string) error {
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(newPath), err)
+	}
 	cmd := exec.Command("git", "-C", m.repoPath, "worktree", "move", oldPath, newPath)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -530,7 +697,11 @@ func SanitizeBranchName(name string) string {
 }
 
 // CheckoutBranch checks out a branch in the main repository
+// A bare repository has none, its branches are checked out by creating worktrees
 func (m *Manager) CheckoutBranch(branch string) error {
+	if m.IsBare() {
+		return ErrNoMainCheckout
+	}
 	cmd := exec.Command("git", "-C", m.repoPath, "checkout", branch)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -562,7 +733,11 @@ func (m *Manager) ListBranches() ([]string, error) {
 }
 
 // GetRepoRoot returns the root path of the repository
+// For a bare repository this is the bare directory itself, even from one of its worktrees
 func (m *Manager) GetRepoRoot() (string, error) {
+	if m.IsBare() {
+		return m.bareDir()
+	}
 	cmd := exec.Command("git", "-C", m.repoPath, "rev-parse", "--show-toplevel")
 	output, err := cmd.Output()
 	if err != nil {
@@ -571,19 +746,47 @@ func (m *Manager) GetRepoRoot() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// GetDefaultPath returns a default path for a new worktree in .workspaces directory
+// GetDefaultPath returns the path for a new worktree of a branch, from the path template
 func (m *Manager) GetDefaultPath(branch string) (string, error) {
 	root, err := m.GetRepoRoot()
 	if err != nil {
 		return "", err
 	}
 
-	// Use .workspaces directory inside repo root
-	workspacesDir := filepath.Join(root, ".workspaces")
-
 	// Sanitize branch name to create safe directory name
 	sanitized := sanitizeBranchForPath(branch)
-	return filepath.Join(workspacesDir, sanitized), nil
+	return ExpandPathTemplate(m.PathTemplate(), root, sanitized)
+}
+
+// IsWorkspacePath reports whether a worktree path is a jean workspace: in the .workspaces directory,
+// or where the path template puts worktrees
+func (m *Manager) IsWorkspacePath(path string) bool {
+	root, err := m.GetRepoRoot()
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(path, filepath.Join(root, ".workspaces")+string(filepath.Separator)) {
+		return true
+	}
+	pattern, err := ExpandPathTemplate(m.PathTemplate(), root, "*")
+	if err != nil || path == root {
+		return false
+	}
+	matched, _ := filepath.Match(pattern, path)
+	return matched
+}
+
+// RenamedWorktreePath returns where a workspace worktree goes when its branch is renamed
+// Returns false for worktrees that are not workspaces (e.g. the main checkout), which stay where they are
+func (m *Manager) RenamedWorktreePath(path, newBranch string) (string, bool) {
+	if !m.IsWorkspacePath(path) {
+		return "", false
+	}
+	newPath, err := m.GetDefaultPath(SanitizeBranchName(newBranch))
+	if err != nil || newPath == path {
+		return "", false
+	}
+	return newPath, true
 }
 
 // GetWorkspacesDir returns the .workspaces directory path
@@ -596,7 +799,12 @@ func (m *Manager) GetWorkspacesDir() (string, error) {
 }
 
 // EnsureWorkspacesDir creates the .workspaces directory if it doesn't exist
+// With a custom path template (or a bare repository) there is nothing to do, git creates the directories of new worktrees
 func (m *Manager) EnsureWorkspacesDir() error {
+	if m.PathTemplate() != DefaultPathTemplate {
+		return nil
+	}
+
 	dir, err := m.GetWorkspacesDir()
 	if err != nil {
 		return err
@@ -625,8 +833,14 @@ var (
 )
 
 // GenerateRandomName creates a random name like "happy-panda-42"
+// With a branch name template the random name is its slug, e.g. "alice/happy-panda-42"
 func (m *Manager) GenerateRandomName() (string, error) {
-	return generateRandomName(), nil
+	if m.branchTemplate == "" {
+		return generateRandomName(), nil
+	}
+	values := m.templateValues()
+	values.Slug = generateRandomName()
+	return ExpandBranchTemplate(m.branchTemplate, values), nil
 }
 
 // generateRandomName creates a random name like "happy-panda-42"
@@ -649,7 +863,12 @@ func randomInt(max int) int {
 }
 
 // IsRandomBranchName checks if a branch name matches the random naming pattern (adjective-noun-number)
+// or was created from the branch name template with a random slug
 func (m *Manager) IsRandomBranchName(branchName string) bool {
+	if m.branchTemplate != "" && templateRegexp(m.branchTemplate).MatchString(branchName) {
+		return true
+	}
+
 	parts := strings.Split(branchName, "-")
 	if len(parts) != 3 {
 		return false
@@ -712,13 +931,14 @@ func (m *Manager) Push(worktreePath, branch string) error {
 	}
 
 	// First check if remote exists
-	cmd := exec.Command("git", "-C", worktreePath, "remote", "get-url", "origin")
+	remote := m.PushRemote()
+	cmd := exec.Command("git", "-C", worktreePath, "remote", "get-url", remote)
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("no remote 'origin' configured")
+		return fmt.Errorf("no remote '%s' configured", remote)
 	}
 
 	// Push with --set-upstream to create remote branch if it doesn't exist
-	cmd = exec.Command("git", "-C", worktreePath, "push", "-u", "origin", branch)
+	cmd = exec.Command("git", "-C", worktreePath, "push", "-u", remote, branch)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to push: %s", string(output))
@@ -729,7 +949,7 @@ func (m *Manager) Push(worktreePath, branch string) error {
 
 // RemoteBranchExists checks if a branch exists on the remote
 func (m *Manager) RemoteBranchExists(worktreePath, branch string) (bool, error) {
-	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--verify", fmt.Sprintf("origin/%s", branch))
+	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--verify", fmt.Sprintf("%s/%s", m.PushRemote(), branch))
 	err := cmd.Run()
 	if err != nil {
 		// Check if it's an actual error or just branch not found
@@ -743,7 +963,7 @@ func (m *Manager) RemoteBranchExists(worktreePath, branch string) (bool, error)
 
 // DeleteRemoteBranch deletes a branch from the remote repository
 func (m *Manager) DeleteRemoteBranch(worktreePath, branch string) error {
-	cmd := exec.Command("git", "-C", worktreePath, "push", "origin", "--delete", branch)
+	cmd := exec.Command("git", "-C", worktreePath, "push", m.PushRemote(), "--delete", branch)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to delete remote branch: %s", string(output))
@@ -776,7 +996,7 @@ func (m *Manager) HasUnpushedCommits(worktreePath, branch string) (bool, error)
 	}
 
 	// Remote branch exists, check if we're ahead
-	cmd := exec.Command("git", "-C", worktreePath, "rev-list", "--count", fmt.Sprintf("origin/%s..HEAD", branch))
+	cmd := exec.Command("git", "-C", worktreePath, "rev-list", "--count", fmt.Sprintf("%s/%s..HEAD", m.PushRemote(), branch))
 	output, err := cmd.Output()
 	if err != nil {
 		return false, fmt.Errorf("failed to check unpushed commits: %w", err)
@@ -786,9 +1006,14 @@ func (m *Manager) HasUnpushedCommits(worktreePath, branch string) (bool, error)
 	return commitCount != "0", nil
 }
 
-// GetRemoteURL returns the URL of the origin remote
+// GetRemoteURL returns the URL of the upstream remote (origin unless a fork workflow is configured)
 func (m *Manager) GetRemoteURL() (string, error) {
-	cmd := exec.Command("git", "-C", m.repoPath, "remote", "get-url", "origin")
+	return m.GetRemoteURLFor(m.UpstreamRemote())
+}
+
+// GetRemoteURLFor returns the URL of a remote
+func (m *Manager) GetRemoteURLFor(remote string) (string, error) {
+	cmd := exec.Command("git", "-C", m.repoPath, "remote", "get-url", remote)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get remote URL: %w", err)
@@ -796,22 +1021,45 @@ func (m *Manager) GetRemoteURL() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// IsGitHubRepo checks if the repository is hosted on GitHub
-func (m *Manager) IsGitHubRepo() (bool, error) {
-	url, err := m.GetRemoteURL()
+// ListRemotes returns the names of the configured remotes
+func (m *Manager) ListRemotes() ([]string, error) {
+	cmd := exec.Command("git", "-C", m.repoPath, "remote")
+	output, err := cmd.Output()
 	if err != nil {
-		return false, err
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
 	}
+	return strings.Fields(string(output)), nil
+}
 
-	// Check if URL contains github.com
-	return strings.Contains(url, "github.com"), nil
+// AddRemote adds a remote
+func (m *Manager) AddRemote(name, url string) error {
+	cmd := exec.Command("git", "-C", m.repoPath, "remote", "add", name, url)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add remote: %s", string(output))
+	}
+	return nil
+}
+
+// fetchRemotes returns the remotes to fetch: the upstream, plus the push remote in a fork workflow
+// Remotes that don't exist are skipped
+func (m *Manager) fetchRemotes() []string {
+	var remotes []string
+	for _, remote := range []string{m.UpstreamRemote(), m.PushRemote()} {
+		if len(remotes) > 0 && remotes[0] == remote {
+			continue
+		}
+		checkCmd := exec.Command("git", "-C", m.repoPath, "remote", "get-url", remote)
+		if checkOutput, err := checkCmd.CombinedOutput(); err == nil && strings.TrimSpace(string(checkOutput)) != "" {
+			remotes = append(remotes, remote)
+		}
+	}
+	return remotes
 }
 
 // HasUncommittedChanges checks if there are uncommitted changes in a worktree
 func (m *Manager) HasUncommittedChanges(worktreePath string) (bool, error) {
 	// Check for staged and unstaged changes
-	cmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
-	output, err := cmd.Output()
+	output, err := m.statusGit("-C", worktreePath, "status", "--porcelain")
 	if err != nil {
 		return false, fmt.Errorf("failed to check git status: %w", err)
 	}
@@ -824,23 +1072,76 @@ func (m *Manager) HasUncommittedChanges(worktreePath string) (bool, error) {
 // Returns nil if remote doesn't exist (graceful skip) or if fetch succeeds
 // Returns error only if remote exists but fetch fails
 func (m *Manager) FetchRemote() error {
-	// Check if 'origin' remote exists
-	checkCmd := exec.Command("git", "-C", m.repoPath, "remote", "get-url", "origin")
-	checkOutput, err := checkCmd.CombinedOutput()
-	checkOutputStr := strings.TrimSpace(string(checkOutput))
+	m.fetchMu.Lock()
+	defer m.fetchMu.Unlock()
+	// Fetch the upstream and (in a fork workflow) the fork; remotes that don't exist are skipped gracefully
+	for _, remote := range m.fetchRemotes() {
+		cmd := exec.Command("git", "-C", m.repoPath, "fetch", remote)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to fetch from remote: %s", string(output))
+		}
+	}
+	return nil
+}
 
-	// If remote doesn't exist or check fails, skip fetch gracefully
-	if err != nil || checkOutputStr == "" {
-		return nil // No remote configured, skip fetch
+// FetchRemotePrune fetches from the remote and prunes remote-tracking branches that no longer exist
+// Like FetchRemote, returns nil if no remote is configured
+func (m *Manager) FetchRemotePrune() error {
+	m.fetchMu.Lock()
+	defer m.fetchMu.Unlock()
+	for _, remote := range m.fetchRemotes() {
+		cmd := exec.Command("git", "-C", m.repoPath, "fetch", "--prune", remote)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to fetch from remote: %s", string(output))
+		}
 	}
+	return nil
+}
 
-	// Remote exists, attempt fetch
-	cmd := exec.Command("git", "-C", m.repoPath, "fetch", "origin")
-	output, err := cmd.CombinedOutput()
+// IsUpstreamGone checks if a branch tracks a remote branch that has since been deleted
+// Only reliable after a pruning fetch (see FetchRemotePrune)
+func (m *Manager) IsUpstreamGone(branch string) (bool, error) {
+	cmd := exec.Command("git", "-C", m.repoPath, "for-each-ref", "--format=%(upstream:track)", "refs/heads/"+branch)
+	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to fetch from remote: %s", string(output))
+		return false, fmt.Errorf("failed to check upstream: %w", err)
 	}
-	return nil
+	return strings.TrimSpace(string(output)) == "[gone]", nil
+}
+
+// IsBranchMerged checks if a branch was merged into the base branch (locally or on the upstream remote)
+// The branch has to be contained in the base branch without its tip being on the base branch's
+// first-parent history, so branches that were created from the base branch and never moved are not
+// reported. Fast-forwarded branches look the same and are left to the PR and remote checks
+func (m *Manager) IsBranchMerged(branch, baseBranch string) (bool, error) {
+	if baseBranch == "" || branch == baseBranch {
+		return false, nil
+	}
+
+	output, err := exec.Command("git", "-C", m.repoPath, "rev-parse", "--verify", "refs/heads/"+branch).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve branch: %w", err)
+	}
+	tip := strings.TrimSpace(string(output))
+
+	for _, base := range []string{baseBranch, m.UpstreamRemote() + "/" + baseBranch} {
+		if exec.Command("git", "-C", m.repoPath, "merge-base", "--is-ancestor", tip, base).Run() != nil {
+			continue
+		}
+		// Walking the base branch's first-parent history down to the branch ends right above the tip
+		// only if the tip is on it, which means the branch was never merged with a merge commit
+		output, err := exec.Command("git", "-C", m.repoPath, "log", "--first-parent", "--format=%P", tip+".."+base).Output()
+		if err != nil {
+			return false, fmt.Errorf("failed to read base branch history: %w", err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if parents := strings.Fields(lines[len(lines)-1]); len(parents) > 0 && parents[0] != tip {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // GetBranchStatus returns the ahead and behind counts for a branch compared to the base branch
@@ -849,16 +1150,15 @@ func (m *Manager) GetBranchStatus(worktreePath, branch, baseBranch string) (int,
 	if baseBranch == "" {
 		return 0, 0, fmt.Errorf("base branch not specified")
 	}
+	baseBranch = m.BaseRef(baseBranch)
 
 	// Check if base branch exists
-	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--verify", baseBranch)
-	if err := cmd.Run(); err != nil {
+	if _, err := m.statusGit("-C", worktreePath, "rev-parse", "--verify", baseBranch); err != nil {
 		return 0, 0, fmt.Errorf("base branch '%s' does not exist", baseBranch)
 	}
 
 	// Get ahead count (commits in current branch not in base)
-	cmd = exec.Command("git", "-C", worktreePath, "rev-list", "--count", fmt.Sprintf("%s..%s", baseBranch, branch))
-	output, err := cmd.Output()
+	output, err := m.statusGit("-C", worktreePath, "rev-list", "--count", fmt.Sprintf("%s..%s", baseBranch, branch))
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get ahead count: %w", err)
 	}
@@ -866,8 +1166,7 @@ func (m *Manager) GetBranchStatus(worktreePath, branch, baseBranch string) (int,
 	fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &aheadCount)
 
 	// Get behind count (commits in base not in current branch)
-	cmd = exec.Command("git", "-C", worktreePath, "rev-list", "--count", fmt.Sprintf("%s..%s", branch, baseBranch))
-	output, err = cmd.Output()
+	output, err = m.statusGit("-C", worktreePath, "rev-list", "--count", fmt.Sprintf("%s..%s", branch, baseBranch))
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get behind count: %w", err)
 	}
@@ -882,6 +1181,7 @@ func (m *Manager) MergeBranch(worktreePath, baseBranch string) error {
 	if baseBranch == "" {
 		return fmt.Errorf("base branch not specified")
 	}
+	baseBranch = m.BaseRef(baseBranch)
 
 	// Check if base branch exists
 	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--verify", baseBranch)
@@ -914,11 +1214,12 @@ func (m *Manager) AbortMerge(worktreePath string) error {
 	return nil
 }
 
-// PullCurrentBranch pulls the current branch from origin
+// PullCurrentBranch pulls the current branch of the main repository from the upstream remote
 // For repositories without a remote, falls back to no-op
 func (m *Manager) PullCurrentBranch(worktreePath, branch string) error {
-	// Check if 'origin' remote exists
-	checkCmd := exec.Command("git", "-C", worktreePath, "remote", "get-url", "origin")
+	// Check if the upstream remote exists
+	remote := m.UpstreamRemote()
+	checkCmd := exec.Command("git", "-C", worktreePath, "remote", "get-url", remote)
 	checkOutput, err := checkCmd.CombinedOutput()
 	checkOutputStr := strings.TrimSpace(string(checkOutput))
 	hasRemote := err == nil && checkOutputStr != ""
@@ -928,7 +1229,7 @@ func (m *Manager) PullCurrentBranch(worktreePath, branch string) error {
 		return nil
 	}
 
-	cmd := exec.Command("git", "-C", worktreePath, "pull", "origin", branch)
+	cmd := exec.Command("git", "-C", worktreePath, "pull", remote, branch)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		outputStr := string(output)
@@ -941,11 +1242,12 @@ func (m *Manager) PullCurrentBranch(worktreePath, branch string) error {
 	return nil
 }
 
-// PullBranchInPath pulls a specific branch from origin in the given directory
+// PullBranchInPath pulls a specific branch from the push remote in the given directory
 // For repositories without a remote, falls back to local merge
 func (m *Manager) PullBranchInPath(path, branch string) error {
-	// Check if 'origin' remote exists
-	checkCmd := exec.Command("git", "-C", path, "remote", "get-url", "origin")
+	// Check if the push remote exists
+	remote := m.PushRemote()
+	checkCmd := exec.Command("git", "-C", path, "remote", "get-url", remote)
 	checkOutput, err := checkCmd.CombinedOutput()
 	checkOutputStr := strings.TrimSpace(string(checkOutput))
 	hasRemote := err == nil && checkOutputStr != ""
@@ -953,7 +1255,7 @@ func (m *Manager) PullBranchInPath(path, branch string) error {
 	var cmd *exec.Cmd
 	if hasRemote {
 		// Remote exists, use git pull
-		cmd = exec.Command("git", "-C", path, "pull", "origin", branch)
+		cmd = exec.Command("git", "-C", path, "pull", remote, branch)
 	} else {
 		// No remote, use local merge instead
 		cmd = exec.Command("git", "-C", path, "merge", branch, "--no-edit")
@@ -971,26 +1273,28 @@ func (m *Manager) PullBranchInPath(path, branch string) error {
 	return nil
 }
 
-// PullCurrentBranchWithOutput pulls current branch and returns the git output and error
+// PullCurrentBranchWithOutput pulls current branch from the upstream remote and returns the git output and error
 // For repositories without a remote, falls back to local merge
 func (m *Manager) PullCurrentBranchWithOutput(worktreePath, branch string) (string, error) {
-	// Check if 'origin' remote exists
-	checkCmd := exec.Command("git", "-C", worktreePath, "remote", "get-url", "origin")
-	checkOutput, err := checkCmd.CombinedOutput()
+	// Check if the upstream remote exists
+	remote := m.UpstreamRemote()
+	checkOutput, err := m.pullGit("-C", worktreePath, "remote", "get-url", remote)
 	checkOutputStr := strings.TrimSpace(string(checkOutput))
 	hasRemote := err == nil && checkOutputStr != ""
 
-	var cmd *exec.Cmd
+	var output []byte
 	if hasRemote {
 		// Remote exists, use git pull
-		cmd = exec.Command("git", "-C", worktreePath, "pull", "origin", branch)
+		output, err = m.pullGit("-C", worktreePath, "pull", remote, branch)
 	} else {
 		// No remote, use local merge instead
-		cmd = exec.Command("git", "-C", worktreePath, "merge", branch, "--no-edit")
+		output, err = m.pullGit("-C", worktreePath, "merge", branch, "--no-edit")
 	}
 
-	output, err := cmd.CombinedOutput()
 	outputStr := string(output)
+	if errors.Is(err, ErrTimeout) {
+		return outputStr, err
+	}
 	if err != nil {
 		// Check if it's a merge conflict
 		if strings.Contains(outputStr, "CONFLICT") || strings.Contains(outputStr, "Automatic merge failed") {
@@ -1001,26 +1305,28 @@ func (m *Manager) PullCurrentBranchWithOutput(worktreePath, branch string) (stri
 	return outputStr, nil
 }
 
-// PullBranchInPathWithOutput pulls a specific branch and returns the git output and error
+// PullBranchInPathWithOutput pulls a specific branch from the push remote and returns the git output and error
 // For repositories without a remote, falls back to local merge
 func (m *Manager) PullBranchInPathWithOutput(path, branch string) (string, error) {
-	// Check if 'origin' remote exists
-	checkCmd := exec.Command("git", "-C", path, "remote", "get-url", "origin")
-	checkOutput, err := checkCmd.CombinedOutput()
+	// Check if the push remote exists
+	remote := m.PushRemote()
+	checkOutput, err := m.pullGit("-C", path, "remote", "get-url", remote)
 	checkOutputStr := strings.TrimSpace(string(checkOutput))
 	hasRemote := err == nil && checkOutputStr != ""
 
-	var cmd *exec.Cmd
+	var output []byte
 	if hasRemote {
 		// Remote exists, use git pull
-		cmd = exec.Command("git", "-C", path, "pull", "origin", branch)
+		output, err = m.pullGit("-C", path, "pull", remote, branch)
 	} else {
 		// No remote, use local merge instead
-		cmd = exec.Command("git", "-C", path, "merge", branch, "--no-edit")
+		output, err = m.pullGit("-C", path, "merge", branch, "--no-edit")
 	}
 
-	output, err := cmd.CombinedOutput()
 	outputStr := string(output)
+	if errors.Is(err, ErrTimeout) {
+		return outputStr, err
+	}
 	if err != nil {
 		// Check if it's a merge conflict
 		if strings.Contains(outputStr, "CONFLICT") || strings.Contains(outputStr, "Automatic merge failed") {
@@ -1207,11 +1513,11 @@ func (m *Manager) GetDiffFromBase(worktreePath, baseBranch string) (string, erro
 	}
 
 	// First, ensure the base branch is fetched from remote
-	fetchCmd := exec.Command("git", "-C", worktreePath, "fetch", "origin", baseBranch)
+	fetchCmd := exec.Command("git", "-C", worktreePath, "fetch", m.UpstreamRemote(), baseBranch)
 	_ = fetchCmd.Run() // Ignore errors, base branch might be local-only
 
 	// Get diff between current branch and base branch
-	cmd := exec.Command("git", "-C", worktreePath, "diff", baseBranch)
+	cmd := exec.Command("git", "-C", worktreePath, "diff", m.BaseRef(baseBranch))
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get diff from base: %w", err)
@@ -1219,36 +1525,25 @@ func (m *Manager) GetDiffFromBase(worktreePath, baseBranch string) (string, erro
 	return string(output), nil
 }
 
-// GetBranchRemoteURL constructs a GitHub URL for a given branch
-// Returns the branch URL if the branch exists on remote, otherwise returns the repo URL
-func (m *Manager) GetBranchRemoteURL(branchName string) (string, error) {
-	isGitHub, err := m.IsGitHubRepo()
-	if err != nil {
-		return "", err
-	}
-
-	if !isGitHub {
-		return "", fmt.Errorf("repository is not hosted on GitHub")
+// GetChangedFiles returns the files changed on the worktree's branch since it diverged from the base branch
+func (m *Manager) GetChangedFiles(worktreePath, baseBranch string) ([]string, error) {
+	if baseBranch == "" {
+		return nil, fmt.Errorf("base branch not specified")
 	}
 
-	url, err := m.GetRemoteURL()
+	cmd := exec.Command("git", "-C", worktreePath, "diff", "--name-only", m.BaseRef(baseBranch)+"...HEAD")
+	output, err := cmd.Output()
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
 	}
 
-	// Convert SSH URL to HTTPS if needed
-	url = convertSSHToHTTPS(url)
-
-	// Check if branch exists on remote
-	checkCmd := exec.Command("git", "-C", m.repoPath, "ls-remote", "--heads", "origin", branchName)
-	output, err := checkCmd.Output()
-	if err == nil && len(strings.TrimSpace(string(output))) > 0 {
-		// Branch exists on remote, return branch URL
-		return fmt.Sprintf("%s/tree/%s", url, branchName), nil
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
 	}
-
-	// Branch doesn't exist on remote, return repo URL
-	return url, nil
+	return files, nil
 }
 
 // GetCurrentUser returns the current git user name
@@ -1295,18 +1590,31 @@ func (m *Manager) GetRecentCommits(worktreePath string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// convertSSHToHTTPS converts SSH git URL to HTTPS format
-// Example: git@github.com:user/repo.git -> https://github.com/user/repo
-func convertSSHToHTTPS(url string) string {
-	if strings.HasPrefix(url, "git@") {
-		// Convert git@github.com:user/repo.git to https://github.com/user/repo
-		url = strings.Replace(url, ":", "/", 1)           // git@github.com/user/repo.git
-		url = strings.Replace(url, "git@", "https://", 1) // https://github.com/user/repo.git
+// GetHeadCommit returns the commit hash HEAD points to in a worktree
+func (m *Manager) GetHeadCommit(worktreePath string) (string, error) {
+	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD commit: %w", err)
 	}
+	return strings.TrimSpace(string(output)), nil
+}
 
-	// Remove .git suffix if present
-	url = strings.TrimSuffix(url, ".git")
-	return url
+// GetCommitSubjects returns the subjects of the commits in fromRef..HEAD, oldest first
+func (m *Manager) GetCommitSubjects(worktreePath, fromRef string) ([]string, error) {
+	cmd := exec.Command("git", "-C", worktreePath, "log", "--reverse", "--format=%s", fromRef+"..HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	var subjects []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
 }
 
 // OpenInBrowser opens a URL in the default web browser
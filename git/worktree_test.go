@@ -0,0 +1,49 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestIsBranchMerged tests that branches merged locally or on the upstream remote are reported, and
+// unmerged or freshly created ones aren't, without relying on the reflog
+func TestIsBranchMerged(t *testing.T) {
+	dir := t.TempDir()
+	origin := filepath.Join(dir, "origin")
+	repo := filepath.Join(dir, "repo")
+	testGit(t, "", "init", "-q", "-b", "main", origin)
+	testGit(t, origin, "commit", "-q", "--allow-empty", "-m", "init")
+	testGit(t, "", "clone", "-q", origin, repo)
+	testGit(t, repo, "config", "core.logAllRefUpdates", "false")
+
+	testGit(t, repo, "branch", "fresh")
+	testGit(t, repo, "branch", "remote", "origin/main")
+	testGit(t, repo, "checkout", "-q", "-b", "unmerged")
+	testGit(t, repo, "commit", "-q", "--allow-empty", "-m", "unmerged")
+	testGit(t, repo, "checkout", "-q", "-b", "merged", "main")
+	testGit(t, repo, "commit", "-q", "--allow-empty", "-m", "merged")
+	testGit(t, repo, "checkout", "-q", "main")
+	testGit(t, repo, "merge", "-q", "--no-ff", "-m", "merge", "merged")
+
+	// Merged on the remote only
+	testGit(t, origin, "checkout", "-q", "-b", "pushed")
+	testGit(t, origin, "commit", "-q", "--allow-empty", "-m", "pushed")
+	testGit(t, origin, "checkout", "-q", "main")
+	testGit(t, origin, "merge", "-q", "--no-ff", "-m", "merge", "pushed")
+	testGit(t, repo, "fetch", "-q")
+	testGit(t, repo, "branch", "pushed", "origin/pushed")
+
+	m := NewManager(repo)
+	for branch, want := range map[string]bool{
+		"fresh":    false,
+		"remote":   false,
+		"unmerged": false,
+		"merged":   true,
+		"pushed":   true,
+		"main":     false,
+	} {
+		if merged, err := m.IsBranchMerged(branch, "main"); err != nil || merged != want {
+			t.Errorf("IsBranchMerged(%s) = %v (%v), expected %v", branch, merged, err, want)
+		}
+	}
+}
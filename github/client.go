@@ -0,0 +1,656 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRateLimitRetries is how many times a rate-limited request is retried
+	maxRateLimitRetries = 3
+
+	// maxRateLimitWait caps how long a single backoff may sleep
+	maxRateLimitWait = 60 * time.Second
+
+	// branchesPerQuery limits aliases per GraphQL query to stay under GitHub's node limits
+	branchesPerQuery = 50
+)
+
+// ghTokens caches tokens obtained from `gh auth token`, keyed by host
+var ghTokens = struct {
+	sync.Mutex
+	byHost map[string]string
+}{byHost: make(map[string]string)}
+
+// Client talks to the GitHub REST and GraphQL APIs directly using a token
+// It avoids spawning gh for every call and supports batched PR lookups
+type Client struct {
+	owner      string
+	repo       string
+	token      string
+	apiURL     string
+	graphqlURL string
+	httpClient *http.Client
+	sleep      func(time.Duration) // Overridable for tests
+}
+
+// restPR is the JSON shape of a pull request returned by the REST API
+type restPR struct {
+	Number   int     `json:"number"`
+	NodeID   string  `json:"node_id"`
+	Title    string  `json:"title"`
+	Body     string  `json:"body"`
+	HTMLURL  string  `json:"html_url"`
+	State    string  `json:"state"` // "open" or "closed"
+	MergedAt *string `json:"merged_at"`
+	Head     struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// NewClient creates an API client for a repository on host (github.com or a GitHub Enterprise host)
+func NewClient(host, owner, repo, token string) *Client {
+	apiURL := "https://api.github.com"
+	graphqlURL := "https://api.github.com/graphql"
+	if host != "" && host != "github.com" {
+		apiURL = fmt.Sprintf("https://%s/api/v3", host)
+		graphqlURL = fmt.Sprintf("https://%s/api/graphql", host)
+	}
+
+	return &Client{
+		owner:      owner,
+		repo:       repo,
+		token:      token,
+		apiURL:     apiURL,
+		graphqlURL: graphqlURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		sleep:      time.Sleep,
+	}
+}
+
+// ResolveToken returns a GitHub token from (in order) the configured token,
+// the GH_TOKEN or GITHUB_TOKEN environment variables, or `gh auth token`
+// Returns "" if no token is available
+func ResolveToken(host, configToken string) string {
+	if configToken != "" {
+		return configToken
+	}
+	if token := os.Getenv("GH_TOKEN"); token != "" {
+		return token
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+
+	ghTokens.Lock()
+	defer ghTokens.Unlock()
+
+	if token, ok := ghTokens.byHost[host]; ok {
+		return token
+	}
+
+	token := ""
+	cmd := exec.Command("gh", "auth", "token", "--hostname", host)
+	if output, err := cmd.Output(); err == nil {
+		token = strings.TrimSpace(string(output))
+	}
+	ghTokens.byHost[host] = token
+	return token
+}
+
+// CreatePR creates a pull request (draft or ready for review)
+func (c *Client) CreatePR(branch, baseBranch, title, description string, isDraft bool) (string, error) {
+	body := map[string]interface{}{
+		"head":  branch,
+		"base":  baseBranch,
+		"title": title,
+		"body":  description,
+		"draft": isDraft,
+	}
+
+	var pr restPR
+	if err := c.rest("POST", c.repoPath("/pulls"), body, &pr); err != nil {
+		return "", fmt.Errorf("failed to create PR: %w", err)
+	}
+
+	return pr.HTMLURL, nil
+}
+
+// CreateFork forks the repository into the authenticated user's account
+// Returns the fork's clone URL (SSH if useSSH is set); an existing fork is returned as is
+func (c *Client) CreateFork(useSSH bool) (string, error) {
+	var fork struct {
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+	}
+	if err := c.rest("POST", c.repoPath("/forks"), map[string]interface{}{}, &fork); err != nil {
+		return "", fmt.Errorf("failed to create fork: %w", err)
+	}
+
+	if useSSH {
+		return fork.SSHURL, nil
+	}
+	return fork.CloneURL, nil
+}
+
+// UpdatePR updates the title and/or description of an existing PR
+// prIdentifier may be a PR URL, a PR number, or the head branch name
+func (c *Client) UpdatePR(prIdentifier, title, description string) error {
+	number, err := c.resolvePRNumber(prIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to update PR: %w", err)
+	}
+
+	body := map[string]string{}
+	if title != "" {
+		body["title"] = title
+	}
+	if description != "" {
+		body["body"] = description
+	}
+
+	if err := c.rest("PATCH", c.repoPath(fmt.Sprintf("/pulls/%d", number)), body, nil); err != nil {
+		return fmt.Errorf("failed to update PR: %w", err)
+	}
+
+	return nil
+}
+
+// GetPRStatus gets the current status of a pull request ("open", "merged", or "closed")
+func (c *Client) GetPRStatus(prURL string) (string, error) {
+	pr, err := c.getPR(parsePRNumber(prURL))
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR status: %w", err)
+	}
+
+	return strings.ToLower(pr.status()), nil
+}
+
+// GetPRBody gets the current description of a pull request
+func (c *Client) GetPRBody(prURL string) (string, error) {
+	pr, err := c.getPR(parsePRNumber(prURL))
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR description: %w", err)
+	}
+	return pr.Body, nil
+}
+
+// GetPRForBranch gets the PR details for a given branch (if it exists)
+// branch may be "owner:branch" for a branch in a fork, otherwise it's looked up in the repository itself
+func (c *Client) GetPRForBranch(branch string) (*PRInfo, error) {
+	head := branch
+	if !strings.Contains(head, ":") {
+		head = c.owner + ":" + branch
+	}
+	var prs []restPR
+	path := c.repoPath(fmt.Sprintf("/pulls?state=all&per_page=1&head=%s", url.QueryEscape(head)))
+	if err := c.rest("GET", path, nil, &prs); err != nil {
+		return nil, fmt.Errorf("failed to search for PR: %w", err)
+	}
+
+	if len(prs) == 0 {
+		return nil, nil // No PR found
+	}
+
+	prInfo := prs[0].toPRInfo()
+	return &prInfo, nil
+}
+
+// ListPRs lists open pull requests for the repository
+func (c *Client) ListPRs() ([]PRInfo, error) {
+	// Only 5 latest to avoid cluttering the screen
+	var prs []restPR
+	if err := c.rest("GET", c.repoPath("/pulls?state=open&per_page=5"), nil, &prs); err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	result := make([]PRInfo, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, pr.toPRInfo())
+	}
+	return result, nil
+}
+
+// MarkPRReady converts a draft PR to ready for review
+// The REST API can't do this, so it uses the GraphQL mutation
+func (c *Client) MarkPRReady(prURL string) error {
+	query := `mutation($id: ID!) { markPullRequestReadyForReview(input: {pullRequestId: $id}) { pullRequest { isDraft } } }`
+	if err := c.prMutation(prURL, query, nil); err != nil {
+		return fmt.Errorf("failed to mark PR as ready: %w", err)
+	}
+
+	return nil
+}
+
+// MergePR merges a pull request using the specified merge method
+// mergeMethod should be one of: "squash", "merge", "rebase"
+func (c *Client) MergePR(prURL, mergeMethod string) error {
+	body := map[string]string{"merge_method": mergeMethod}
+	path := c.repoPath(fmt.Sprintf("/pulls/%d/merge", parsePRNumber(prURL)))
+	if err := c.rest("PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to merge PR: %w", err)
+	}
+
+	return nil
+}
+
+// SetPRMetadata adds reviewers, labels, and assignees to a PR and sets its milestone
+// Reviewers in "org/team" form are requested as team reviewers
+func (c *Client) SetPRMetadata(prURL string, meta PRMetadata) error {
+	number := parsePRNumber(prURL)
+	if number <= 0 {
+		return fmt.Errorf("failed to set PR metadata: invalid PR URL: %s", prURL)
+	}
+
+	if len(meta.Reviewers) > 0 {
+		users, teams := []string{}, []string{}
+		for _, reviewer := range meta.Reviewers {
+			if _, team, ok := strings.Cut(reviewer, "/"); ok {
+				teams = append(teams, team)
+			} else {
+				users = append(users, reviewer)
+			}
+		}
+		body := map[string][]string{"reviewers": users, "team_reviewers": teams}
+		if err := c.rest("POST", c.repoPath(fmt.Sprintf("/pulls/%d/requested_reviewers", number)), body, nil); err != nil {
+			return fmt.Errorf("failed to request reviewers: %w", err)
+		}
+	}
+
+	// Labels, assignees, and milestones live on the PR's issue
+	if len(meta.Labels) > 0 {
+		body := map[string][]string{"labels": meta.Labels}
+		if err := c.rest("POST", c.repoPath(fmt.Sprintf("/issues/%d/labels", number)), body, nil); err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+
+	if len(meta.Assignees) > 0 {
+		body := map[string][]string{"assignees": meta.Assignees}
+		if err := c.rest("POST", c.repoPath(fmt.Sprintf("/issues/%d/assignees", number)), body, nil); err != nil {
+			return fmt.Errorf("failed to add assignees: %w", err)
+		}
+	}
+
+	if meta.Milestone != "" {
+		milestone, err := c.findMilestone(meta.Milestone)
+		if err != nil {
+			return fmt.Errorf("failed to set milestone: %w", err)
+		}
+		body := map[string]int{"milestone": milestone}
+		if err := c.rest("PATCH", c.repoPath(fmt.Sprintf("/issues/%d", number)), body, nil); err != nil {
+			return fmt.Errorf("failed to set milestone: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findMilestone returns the number of the open milestone with the given title
+func (c *Client) findMilestone(title string) (int, error) {
+	var milestones []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	if err := c.rest("GET", c.repoPath("/milestones?state=open&per_page=100"), nil, &milestones); err != nil {
+		return 0, err
+	}
+
+	for _, milestone := range milestones {
+		if strings.EqualFold(milestone.Title, title) {
+			return milestone.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("milestone %q not found", title)
+}
+
+// EnableAutoMerge enables auto-merge so the PR merges with mergeMethod once required checks pass
+func (c *Client) EnableAutoMerge(prURL, mergeMethod string) error {
+	query := `mutation($id: ID!, $method: PullRequestMergeMethod!) { enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId } }`
+	if err := c.prMutation(prURL, query, map[string]interface{}{"method": strings.ToUpper(mergeMethod)}); err != nil {
+		return fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+	return nil
+}
+
+// DisableAutoMerge cancels a pending auto-merge
+func (c *Client) DisableAutoMerge(prURL string) error {
+	query := `mutation($id: ID!) { disablePullRequestAutoMerge(input: {pullRequestId: $id}) { clientMutationId } }`
+	if err := c.prMutation(prURL, query, nil); err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %w", err)
+	}
+	return nil
+}
+
+// AddToMergeQueue adds the PR to the merge queue of its base branch
+func (c *Client) AddToMergeQueue(prURL string) error {
+	query := `mutation($id: ID!) { enqueuePullRequest(input: {pullRequestId: $id}) { clientMutationId } }`
+	if err := c.prMutation(prURL, query, nil); err != nil {
+		return fmt.Errorf("failed to add PR to merge queue: %w", err)
+	}
+	return nil
+}
+
+// prMutation runs a GraphQL mutation that takes the PR node ID as $id
+func (c *Client) prMutation(prURL, query string, variables map[string]interface{}) error {
+	pr, err := c.getPR(parsePRNumber(prURL))
+	if err != nil {
+		return err
+	}
+
+	if variables == nil {
+		variables = make(map[string]interface{})
+	}
+	variables["id"] = pr.NodeID
+	return c.graphql(query, variables, nil)
+}
+
+// GetPRsForBranches looks up the latest PR for each branch with batched GraphQL queries
+// Branches may be "owner:branch" for branches in a fork; the result is keyed the way they were given
+// Branches without a PR are omitted from the result
+func (c *Client) GetPRsForBranches(branches []string) (map[string]*PRInfo, error) {
+	result := make(map[string]*PRInfo)
+
+	for start := 0; start < len(branches); start += branchesPerQuery {
+		end := start + branchesPerQuery
+		if end > len(branches) {
+			end = len(branches)
+		}
+		if err := c.getPRsForBranchChunk(branches[start:end], result); err != nil {
+			return nil, fmt.Errorf("failed to look up PRs: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// getPRsForBranchChunk runs a single GraphQL query with one aliased pullRequests field per branch
+func (c *Client) getPRsForBranchChunk(branches []string, result map[string]*PRInfo) error {
+	var params, fields strings.Builder
+	variables := map[string]interface{}{
+		"owner": c.owner,
+		"name":  c.repo,
+	}
+
+	for i, branch := range branches {
+		alias := fmt.Sprintf("b%d", i)
+		// headRefName can't tell forks apart, the head owner ("owner:branch", or the repository owner) is matched below
+		_, refName, _ := strings.Cut(branch, ":")
+		if refName == "" {
+			refName = branch
+		}
+		variables[alias] = refName
+		fmt.Fprintf(&params, ", $%s: String!", alias)
+		fmt.Fprintf(&fields, " %s: pullRequests(headRefName: $%s, first: 5, orderBy: {field: CREATED_AT, direction: DESC}) { nodes { number title url state headRefName author { login } headRepositoryOwner { login } } }", alias, alias)
+	}
+
+	query := fmt.Sprintf("query($owner: String!, $name: String!%s) { repository(owner: $owner, name: $name) {%s } }", params.String(), fields.String())
+
+	var data struct {
+		Repository map[string]struct {
+			Nodes []struct {
+				PRInfo
+				HeadRepositoryOwner struct {
+					Login string `json:"login"`
+				} `json:"headRepositoryOwner"`
+			} `json:"nodes"`
+		} `json:"repository"`
+	}
+	if err := c.graphql(query, variables, &data); err != nil {
+		return err
+	}
+
+	for i, branch := range branches {
+		// Like GetPRForBranch, a plain branch is one of the repository itself, not a same-named fork branch
+		owner, _, isFork := strings.Cut(branch, ":")
+		if !isFork {
+			owner = c.owner
+		}
+		for _, node := range data.Repository[fmt.Sprintf("b%d", i)].Nodes {
+			if !strings.EqualFold(node.HeadRepositoryOwner.Login, owner) {
+				continue
+			}
+			prInfo := node.PRInfo
+			result[branch] = &prInfo
+			break
+		}
+	}
+
+	return nil
+}
+
+// getPR fetches a single pull request by number
+func (c *Client) getPR(number int) (*restPR, error) {
+	if number <= 0 {
+		return nil, fmt.Errorf("invalid PR number")
+	}
+
+	var pr restPR
+	if err := c.rest("GET", c.repoPath(fmt.Sprintf("/pulls/%d", number)), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// resolvePRNumber accepts a PR URL, number, or head branch name
+func (c *Client) resolvePRNumber(prIdentifier string) (int, error) {
+	if number := parsePRNumber(prIdentifier); number > 0 {
+		return number, nil
+	}
+	if number, err := strconv.Atoi(prIdentifier); err == nil {
+		return number, nil
+	}
+
+	pr, err := c.GetPRForBranch(prIdentifier)
+	if err != nil {
+		return 0, err
+	}
+	if pr == nil {
+		return 0, fmt.Errorf("no PR found for branch %s", prIdentifier)
+	}
+	return pr.Number, nil
+}
+
+// repoPath builds a REST path under /repos/{owner}/{repo}
+func (c *Client) repoPath(suffix string) string {
+	return fmt.Sprintf("/repos/%s/%s%s", c.owner, c.repo, suffix)
+}
+
+// rest sends a REST API request and decodes the response into out (if not nil)
+func (c *Client) rest(method, path string, body interface{}, out interface{}) error {
+	respBody, err := c.send(method, c.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil
+}
+
+// graphql sends a GraphQL query and decodes the "data" field into out (if not nil)
+func (c *Client) graphql(query string, variables map[string]interface{}, out interface{}) error {
+	body := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+
+	respBody, err := c.send("POST", c.graphqlURL, body)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("%s", resp.Errors[0].Message)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil
+}
+
+// send performs an HTTP request, retrying with backoff when rate limited
+func (c *Client) send(method, endpoint string, body interface{}) ([]byte, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if data != nil {
+			reqBody = bytes.NewReader(data)
+		}
+
+		req, err := http.NewRequest(method, endpoint, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		if data != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if isRateLimited(resp) && attempt < maxRateLimitRetries {
+			c.sleep(rateLimitDelay(resp, attempt))
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			var apiErr struct {
+				Message string `json:"message"`
+				Errors  []struct {
+					Message string `json:"message"`
+					Field   string `json:"field"`
+					Code    string `json:"code"`
+				} `json:"errors"`
+			}
+			if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Message != "" {
+				// The reason of a 422 "Validation Failed" is in its errors, e.g. "A pull request already exists for ..."
+				var details []string
+				for _, e := range apiErr.Errors {
+					if e.Message != "" {
+						details = append(details, e.Message)
+					} else if e.Code != "" {
+						details = append(details, strings.TrimSpace(e.Code+" "+e.Field))
+					}
+				}
+				if len(details) > 0 {
+					return nil, fmt.Errorf("%s: %s (HTTP %d)", apiErr.Message, strings.Join(details, "; "), resp.StatusCode)
+				}
+				return nil, fmt.Errorf("%s (HTTP %d)", apiErr.Message, resp.StatusCode)
+			}
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+		}
+
+		return respBody, nil
+	}
+}
+
+// isRateLimited reports whether a response is a primary or secondary rate limit rejection
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+	}
+	return false
+}
+
+// rateLimitDelay returns how long to wait before retrying a rate-limited request
+// Uses Retry-After or X-RateLimit-Reset when present, otherwise exponential backoff
+func rateLimitDelay(resp *http.Response, attempt int) time.Duration {
+	delay := time.Duration(1<<attempt) * time.Second
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		delay = time.Until(time.Unix(reset, 0))
+	}
+
+	if delay < time.Second {
+		delay = time.Second
+	}
+	if delay > maxRateLimitWait {
+		delay = maxRateLimitWait
+	}
+	return delay
+}
+
+// status returns the PR state in the GraphQL form ("OPEN", "MERGED", or "CLOSED")
+func (pr restPR) status() string {
+	if pr.MergedAt != nil {
+		return "MERGED"
+	}
+	return strings.ToUpper(pr.State)
+}
+
+// toPRInfo converts a REST PR to PRInfo
+func (pr restPR) toPRInfo() PRInfo {
+	prInfo := PRInfo{
+		Number:      pr.Number,
+		Title:       pr.Title,
+		HeadRefName: pr.Head.Ref,
+		URL:         pr.HTMLURL,
+		Status:      pr.status(),
+	}
+	prInfo.Author.Login = pr.User.Login
+	return prInfo
+}
+
+// parsePRNumber extracts the PR number from a URL like https://github.com/owner/repo/pull/123
+// Returns 0 if the URL doesn't contain a PR number
+func parsePRNumber(prURL string) int {
+	i := strings.LastIndex(prURL, "/pull/")
+	if i < 0 {
+		return 0
+	}
+	number := 0
+	fmt.Sscanf(prURL[i+len("/pull/"):], "%d", &number)
+	return number
+}
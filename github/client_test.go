@@ -0,0 +1,254 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient starts a local HTTP stand-in for the GitHub API and returns a client pointing at it
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	var sleeps []time.Duration
+	c := NewClient("github.com", "owner", "repo", "secret")
+	c.apiURL = server.URL
+	c.graphqlURL = server.URL + "/graphql"
+	c.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return c, &sleeps
+}
+
+// TestClient_GetPRsForBranches tests that all branches are looked up in one GraphQL query
+func TestClient_GetPRsForBranches(t *testing.T) {
+	requests := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/graphql" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Expected bearer auth header, got %q", r.Header.Get("Authorization"))
+		}
+
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Variables["b0"] != "feature-a" || body.Variables["b1"] != "feature-b" {
+			t.Errorf("Unexpected variables: %v", body.Variables)
+		}
+		if !strings.Contains(body.Query, "b1: pullRequests(headRefName: $b1") {
+			t.Errorf("Expected aliased field per branch, got query: %s", body.Query)
+		}
+
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"b0": {"nodes": [{"number": 1, "title": "A", "url": "https://github.com/owner/repo/pull/1", "state": "MERGED", "headRefName": "feature-a", "author": {"login": "jean"}, "headRepositoryOwner": {"login": "owner"}}]},
+			"b1": {"nodes": [{"number": 2, "title": "B", "url": "https://github.com/owner/repo/pull/2", "state": "OPEN", "headRefName": "feature-b", "author": {"login": "someone"}, "headRepositoryOwner": {"login": "someone"}}]}
+		}}}`))
+	})
+
+	prs, err := c.GetPRsForBranches([]string{"feature-a", "feature-b"})
+	if err != nil {
+		t.Fatalf("GetPRsForBranches returned error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+	// feature-b only has a PR from a same-named branch of someone else's fork
+	if len(prs) != 1 {
+		t.Fatalf("Expected 1 PR, got %d", len(prs))
+	}
+	if pr := prs["feature-a"]; pr == nil || pr.Number != 1 || pr.Status != "MERGED" || pr.Author.Login != "jean" {
+		t.Errorf("Unexpected PR for feature-a: %+v", pr)
+	}
+}
+
+// TestClient_GraphQLErrors tests that GraphQL errors are surfaced
+func TestClient_GraphQLErrors(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errors": [{"message": "Could not resolve to a Repository"}]}`))
+	})
+
+	_, err := c.GetPRsForBranches([]string{"feature"})
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("Expected GraphQL error, got %v", err)
+	}
+}
+
+// TestClient_ValidationErrors tests that the reasons of a 422 are surfaced, not only "Validation Failed"
+func TestClient_ValidationErrors(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "PullRequest", "code": "custom", "message": "A pull request already exists for owner:feature."}]}`))
+	})
+
+	_, err := c.CreatePR("feature", "main", "Title", "", false)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected the validation reason in the error, got %v", err)
+	}
+}
+
+// TestClient_RateLimitBackoff tests that rate-limited requests are retried after Retry-After
+func TestClient_RateLimitBackoff(t *testing.T) {
+	attempts := 0
+	c, sleeps := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"number": 7, "state": "closed", "merged_at": "2025-01-01T00:00:00Z"}`))
+	})
+
+	status, err := c.GetPRStatus("https://github.com/owner/repo/pull/7")
+	if err != nil {
+		t.Fatalf("GetPRStatus returned error: %v", err)
+	}
+	if status != "merged" {
+		t.Errorf("Expected status merged, got %s", status)
+	}
+	if len(*sleeps) != 2 || (*sleeps)[0] != 2*time.Second {
+		t.Errorf("Expected two 2s backoffs, got %v", *sleeps)
+	}
+}
+
+// TestClient_RateLimitGivesUp tests that retries stop after maxRateLimitRetries
+func TestClient_RateLimitGivesUp(t *testing.T) {
+	c, sleeps := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	})
+
+	_, err := c.ListPRs()
+	if err == nil || !strings.Contains(err.Error(), "rate limit") {
+		t.Errorf("Expected rate limit error, got %v", err)
+	}
+	if len(*sleeps) != maxRateLimitRetries {
+		t.Errorf("Expected %d backoffs, got %d", maxRateLimitRetries, len(*sleeps))
+	}
+}
+
+// TestClient_UpdatePRByBranch tests resolving a branch name to a PR number before updating
+func TestClient_UpdatePRByBranch(t *testing.T) {
+	patched := false
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if r.URL.Query().Get("head") != "owner:feature/x" {
+				t.Errorf("Unexpected head filter: %s", r.URL.Query().Get("head"))
+			}
+			_, _ = w.Write([]byte(`[{"number": 12, "state": "open", "head": {"ref": "feature/x"}}]`))
+		case "PATCH":
+			if r.URL.Path != "/repos/owner/repo/pulls/12" {
+				t.Errorf("Unexpected path: %s", r.URL.Path)
+			}
+			patched = true
+			_, _ = w.Write([]byte(`{"number": 12}`))
+		}
+	})
+
+	if err := c.UpdatePR("feature/x", "New title", ""); err != nil {
+		t.Fatalf("UpdatePR returned error: %v", err)
+	}
+	if !patched {
+		t.Errorf("Expected PR to be patched")
+	}
+}
+
+// TestClient_ForkHeads tests looking up PRs opened from a fork ("owner:branch"), singly and batched
+func TestClient_ForkHeads(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			// The fork's owner is kept, not replaced with the upstream owner
+			if head := r.URL.Query().Get("head"); head != "contributor:feature" {
+				t.Errorf("Unexpected head filter: %s", head)
+			}
+			_, _ = w.Write([]byte(`[{"number": 7, "state": "open", "head": {"ref": "feature"}}]`))
+			return
+		}
+
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Variables["b0"] != "feature" {
+			t.Errorf("Expected the branch name without the owner, got %v", body.Variables["b0"])
+		}
+		// An upstream branch with the same name had a PR too
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"b0": {"nodes": [
+				{"number": 8, "state": "OPEN", "headRefName": "feature", "headRepositoryOwner": {"login": "owner"}},
+				{"number": 7, "state": "OPEN", "headRefName": "feature", "headRepositoryOwner": {"login": "Contributor"}}
+			]}
+		}}}`))
+	})
+
+	pr, err := c.GetPRForBranch("contributor:feature")
+	if err != nil || pr == nil || pr.Number != 7 {
+		t.Errorf("Expected PR 7, got %+v (err: %v)", pr, err)
+	}
+
+	prs, err := c.GetPRsForBranches([]string{"contributor:feature"})
+	if err != nil {
+		t.Fatalf("GetPRsForBranches returned error: %v", err)
+	}
+	if pr := prs["contributor:feature"]; pr == nil || pr.Number != 7 {
+		t.Errorf("Expected the fork's PR 7, got %+v", pr)
+	}
+}
+
+// TestClient_SetPRMetadata tests that team reviewers, labels, and milestone titles are mapped to the REST API
+func TestClient_SetPRMetadata(t *testing.T) {
+	calls := map[string]map[string]interface{}{}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/repos/owner/repo/milestones" {
+			_, _ = w.Write([]byte(`[{"number": 3, "title": "v1.0"}, {"number": 4, "title": "v2.0"}]`))
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		calls[r.Method+" "+r.URL.Path] = body
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	meta := PRMetadata{Reviewers: []string{"alice", "org/core"}, Labels: []string{"bug"}, Milestone: "V2.0"}
+	if err := c.SetPRMetadata("https://github.com/owner/repo/pull/5", meta); err != nil {
+		t.Fatalf("SetPRMetadata returned error: %v", err)
+	}
+
+	reviewers := calls["POST /repos/owner/repo/pulls/5/requested_reviewers"]
+	if reviewers == nil || reviewers["reviewers"].([]interface{})[0] != "alice" || reviewers["team_reviewers"].([]interface{})[0] != "core" {
+		t.Errorf("Unexpected reviewer request: %v", reviewers)
+	}
+	if labels := calls["POST /repos/owner/repo/issues/5/labels"]; labels == nil {
+		t.Errorf("Expected labels to be added")
+	}
+	if issue := calls["PATCH /repos/owner/repo/issues/5"]; issue == nil || issue["milestone"] != float64(4) {
+		t.Errorf("Expected milestone 4 to be set, got %v", issue)
+	}
+	if _, ok := calls["POST /repos/owner/repo/issues/5/assignees"]; ok {
+		t.Errorf("Expected no assignee request without assignees")
+	}
+}
+
+// TestResolveToken tests token precedence between config and environment
+func TestResolveToken(t *testing.T) {
+	t.Setenv("GH_TOKEN", "from-gh-env")
+	t.Setenv("GITHUB_TOKEN", "from-github-env")
+
+	if token := ResolveToken("github.com", "from-config"); token != "from-config" {
+		t.Errorf("Expected config token to win, got %q", token)
+	}
+	if token := ResolveToken("github.com", ""); token != "from-gh-env" {
+		t.Errorf("Expected GH_TOKEN, got %q", token)
+	}
+}
@@ -5,10 +5,17 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync/atomic"
 )
 
+// ghReady records that gh was found installed and authenticated,
+// so later calls can skip re-running `gh --version` and `gh auth status`
+var ghReady atomic.Bool
+
 // Manager handles GitHub operations using gh CLI
-type Manager struct{}
+type Manager struct {
+	repo string // [HOST/]OWNER/REPO PRs from forks are opened against, "" = resolved by gh
+}
 
 // PRInfo holds information about a pull request
 type PRInfo struct {
@@ -22,11 +29,29 @@ type PRInfo struct {
 	} `json:"author"`
 }
 
+// PRMetadata holds the reviewers, labels, assignees, and milestone applied to a pull request
+type PRMetadata struct {
+	Reviewers []string `json:"reviewers,omitempty"` // Usernames, or "org/team" for team reviewers
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone string   `json:"milestone,omitempty"` // Milestone title
+}
+
+// IsEmpty reports whether there is no metadata to apply
+func (meta PRMetadata) IsEmpty() bool {
+	return len(meta.Reviewers) == 0 && len(meta.Labels) == 0 && len(meta.Assignees) == 0 && meta.Milestone == ""
+}
+
 // NewManager creates a new GitHub manager
 func NewManager() *Manager {
 	return &Manager{}
 }
 
+// NewManagerForRepo creates a GitHub manager that opens PRs from forks against repo ([HOST/]OWNER/REPO)
+func NewManagerForRepo(repo string) *Manager {
+	return &Manager{repo: repo}
+}
+
 // IsGhInstalled checks if gh CLI is installed
 func (m *Manager) IsGhInstalled() bool {
 	cmd := exec.Command("gh", "--version")
@@ -47,20 +72,35 @@ func (m *Manager) IsAuthenticated() (bool, error) {
 	return true, nil
 }
 
-// CreatePR creates a pull request (draft or ready for review)
-func (m *Manager) CreatePR(worktreePath, branch, baseBranch, title, description string, isDraft bool) (string, error) {
+// ensureReady checks that gh is installed and authenticated
+// A successful check is cached for the lifetime of the process
+func (m *Manager) ensureReady() error {
+	if ghReady.Load() {
+		return nil
+	}
+
 	// Check if gh is installed
 	if !m.IsGhInstalled() {
-		return "", fmt.Errorf("gh CLI is not installed. Install it from https://cli.github.com")
+		return fmt.Errorf("gh CLI is not installed. Install it from https://cli.github.com")
 	}
 
 	// Check if authenticated
 	authenticated, err := m.IsAuthenticated()
 	if err != nil {
-		return "", err
+		return err
 	}
 	if !authenticated {
-		return "", fmt.Errorf("not authenticated with GitHub. Run 'gh auth login' to authenticate")
+		return fmt.Errorf("not authenticated with GitHub. Run 'gh auth login' to authenticate")
+	}
+
+	ghReady.Store(true)
+	return nil
+}
+
+// CreatePR creates a pull request (draft or ready for review)
+func (m *Manager) CreatePR(worktreePath, branch, baseBranch, title, description string, isDraft bool) (string, error) {
+	if err := m.ensureReady(); err != nil {
+		return "", err
 	}
 
 	// Create PR with title and description
@@ -77,6 +117,12 @@ func (m *Manager) CreatePR(worktreePath, branch, baseBranch, title, description
 		args = append(args, "--draft")
 	}
 
+	// A head in a fork ("owner:branch") is opened against the upstream repository,
+	// which gh can't tell apart from the fork when both are remotes
+	if m.repo != "" && strings.Contains(branch, ":") {
+		args = append(args, "--repo", m.repo)
+	}
+
 	cmd := exec.Command("gh", args...)
 	cmd.Dir = worktreePath
 	output, err := cmd.CombinedOutput()
@@ -89,6 +135,22 @@ func (m *Manager) CreatePR(worktreePath, branch, baseBranch, title, description
 	return prURL, nil
 }
 
+// CreateFork forks owner/repo on host into the authenticated user's account
+// Returns the fork's clone URL (SSH if useSSH is set); an existing fork is returned as is
+func (m *Manager) CreateFork(host, owner, repo string, useSSH bool) (string, error) {
+	field := ".clone_url"
+	if useSSH {
+		field = ".ssh_url"
+	}
+
+	cmd := exec.Command("gh", "api", "--hostname", host, "-X", "POST", fmt.Sprintf("repos/%s/%s/forks", owner, repo), "--jq", field)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to create fork: %s", string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // GetRepoName gets the repository name from gh CLI
 func (m *Manager) GetRepoName(worktreePath string) (string, error) {
 	cmd := exec.Command("gh", "repo", "view", "--json", "nameWithOwner", "--jq", ".nameWithOwner")
@@ -113,14 +175,39 @@ func (m *Manager) GetPRStatus(prURL string) (string, error) {
 	return status, nil
 }
 
+// GetPRBody gets the current description of a pull request
+func (m *Manager) GetPRBody(worktreePath, prURL string) (string, error) {
+	if err := m.ensureReady(); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("gh", "pr", "view", prURL, "--json", "body", "-q", ".body")
+	cmd.Dir = worktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR description: %w", err)
+	}
+
+	// gh adds a trailing newline to the printed value
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
 // GetPRForBranch gets the PR details for a given branch (if it exists)
+// branch may be "owner:branch" for a branch in a fork of the repository
 func (m *Manager) GetPRForBranch(worktreePath, branch string) (*PRInfo, error) {
 	// Search for PR on this branch with full details (including closed/merged)
-	cmd := exec.Command("gh", "pr", "list",
-		"--head", branch,
-		"--state", "all",
-		"--json", "number,title,headRefName,url,state,author",
-		"--jq", ".[0]")
+	// --head only takes a branch name, so a fork's PRs are picked out by the owner of their head
+	jq := ".[0]"
+	args := []string{"pr", "list", "--state", "all", "--json", "number,title,headRefName,url,state,author,headRepositoryOwner"}
+	if owner, forkBranch, ok := strings.Cut(branch, ":"); ok {
+		branch = forkBranch
+		jq = fmt.Sprintf("[.[] | select(.headRepositoryOwner.login | ascii_downcase == %q)][0]", strings.ToLower(owner))
+		if m.repo != "" {
+			args = append(args, "--repo", m.repo)
+		}
+	}
+	args = append(args, "--head", branch, "--jq", jq)
+	cmd := exec.Command("gh", args...)
 	cmd.Dir = worktreePath
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -142,7 +229,19 @@ func (m *Manager) GetPRForBranch(worktreePath, branch string) (*PRInfo, error) {
 }
 
 // UpdatePR updates the title and/or description of an existing PR
+// prIdentifier may be a PR URL, a PR number, or the head branch ("owner:branch" for a fork)
 func (m *Manager) UpdatePR(worktreePath, prIdentifier, title, description string) error {
+	if strings.Contains(prIdentifier, ":") && !strings.Contains(prIdentifier, "://") {
+		// gh pr edit looks branches up in the current repository only, so resolve a fork's head to its PR
+		pr, err := m.GetPRForBranch(worktreePath, prIdentifier)
+		if err != nil {
+			return fmt.Errorf("failed to update PR: %w", err)
+		}
+		if pr == nil {
+			return fmt.Errorf("failed to update PR: no PR found for branch %s", prIdentifier)
+		}
+		prIdentifier = pr.URL
+	}
 	args := []string{"pr", "edit", prIdentifier}
 
 	if title != "" {
@@ -163,20 +262,40 @@ func (m *Manager) UpdatePR(worktreePath, prIdentifier, title, description string
 	return nil
 }
 
-// MarkPRReady converts a draft PR to ready for review
-func (m *Manager) MarkPRReady(worktreePath, prURL string) error {
-	// Check if gh is installed
-	if !m.IsGhInstalled() {
-		return fmt.Errorf("gh CLI is not installed. Install it from https://cli.github.com")
+// SetPRMetadata adds reviewers, labels, and assignees to a PR and sets its milestone
+func (m *Manager) SetPRMetadata(worktreePath, prURL string, meta PRMetadata) error {
+	if meta.IsEmpty() {
+		return nil
 	}
 
-	// Check if authenticated
-	authenticated, err := m.IsAuthenticated()
+	args := []string{"pr", "edit", prURL}
+	if len(meta.Reviewers) > 0 {
+		args = append(args, "--add-reviewer", strings.Join(meta.Reviewers, ","))
+	}
+	if len(meta.Labels) > 0 {
+		args = append(args, "--add-label", strings.Join(meta.Labels, ","))
+	}
+	if len(meta.Assignees) > 0 {
+		args = append(args, "--add-assignee", strings.Join(meta.Assignees, ","))
+	}
+	if meta.Milestone != "" {
+		args = append(args, "--milestone", meta.Milestone)
+	}
+
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = worktreePath
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to set PR metadata: %s", string(output))
 	}
-	if !authenticated {
-		return fmt.Errorf("not authenticated with GitHub. Run 'gh auth login' to authenticate")
+
+	return nil
+}
+
+// MarkPRReady converts a draft PR to ready for review
+func (m *Manager) MarkPRReady(worktreePath, prURL string) error {
+	if err := m.ensureReady(); err != nil {
+		return err
 	}
 
 	// Mark PR as ready (remove draft status)
@@ -193,19 +312,9 @@ func (m *Manager) MarkPRReady(worktreePath, prURL string) error {
 // MergePR merges a pull request using the specified merge method
 // mergeMethod should be one of: "squash", "merge", "rebase"
 func (m *Manager) MergePR(worktreePath, prURL, mergeMethod string) error {
-	// Check if gh is installed
-	if !m.IsGhInstalled() {
-		return fmt.Errorf("gh CLI is not installed. Install it from https://cli.github.com")
-	}
-
-	// Check if authenticated
-	authenticated, err := m.IsAuthenticated()
-	if err != nil {
+	if err := m.ensureReady(); err != nil {
 		return err
 	}
-	if !authenticated {
-		return fmt.Errorf("not authenticated with GitHub. Run 'gh auth login' to authenticate")
-	}
 
 	// Validate merge method
 	validMethods := map[string]bool{
@@ -229,21 +338,58 @@ func (m *Manager) MergePR(worktreePath, prURL, mergeMethod string) error {
 	return nil
 }
 
-// ListPRs lists all open pull requests for the repository
-func (m *Manager) ListPRs(worktreePath string) ([]PRInfo, error) {
-	// Check if gh is installed
-	if !m.IsGhInstalled() {
-		err := fmt.Errorf("gh CLI is not installed. Install it from https://cli.github.com")
-		return nil, err
+// EnableAutoMerge enables auto-merge so the PR merges with mergeMethod once required checks pass
+func (m *Manager) EnableAutoMerge(worktreePath, prURL, mergeMethod string) error {
+	if err := m.ensureReady(); err != nil {
+		return err
 	}
 
-	// Check if authenticated
-	authenticated, err := m.IsAuthenticated()
+	cmd := exec.Command("gh", "pr", "merge", prURL, "--auto", "--"+mergeMethod)
+	cmd.Dir = worktreePath
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to enable auto-merge: %s", string(output))
 	}
-	if !authenticated {
-		err := fmt.Errorf("not authenticated with GitHub. Run 'gh auth login' to authenticate")
+
+	return nil
+}
+
+// DisableAutoMerge cancels a pending auto-merge (also removes the PR from a merge queue)
+func (m *Manager) DisableAutoMerge(worktreePath, prURL string) error {
+	if err := m.ensureReady(); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("gh", "pr", "merge", prURL, "--disable-auto")
+	cmd.Dir = worktreePath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %s", string(output))
+	}
+
+	return nil
+}
+
+// AddToMergeQueue adds the PR to the merge queue of its base branch
+// gh enqueues the PR when the base branch requires a merge queue, so no strategy flag is passed
+func (m *Manager) AddToMergeQueue(worktreePath, prURL string) error {
+	if err := m.ensureReady(); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("gh", "pr", "merge", prURL)
+	cmd.Dir = worktreePath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to add PR to merge queue: %s", string(output))
+	}
+
+	return nil
+}
+
+// ListPRs lists all open pull requests for the repository
+func (m *Manager) ListPRs(worktreePath string) ([]PRInfo, error) {
+	if err := m.ensureReady(); err != nil {
 		return nil, err
 	}
 
@@ -255,15 +401,13 @@ func (m *Manager) ListPRs(worktreePath string) ([]PRInfo, error) {
 	cmd.Dir = worktreePath
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		errMsg := fmt.Sprintf("failed to list PRs: %s", string(output))
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("failed to list PRs: %s", string(output))
 	}
 
 	// Parse JSON response
 	var prs []PRInfo
 	if err := json.Unmarshal(output, &prs); err != nil {
-		errMsg := fmt.Sprintf("failed to parse PR list: %v", err)
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("failed to parse PR list: %v", err)
 	}
 
 	return prs, nil
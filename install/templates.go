@@ -41,7 +41,7 @@ jean() {
         if [ "$debug_enabled" = "true" ]; then
         echo "DEBUG wrapper: switch file exists and has content" >> "$debug_log"
         fi
-        # Read the switch info: path|branch|auto-claude|target-window|script-command|claude-session-name|is-claude-initialized
+        # Read the switch info: path|branch|auto-claude|target-window|script-command|claude-session-name|is-agent-initialized|multiplexer|agent-command
         local switch_info=$(cat "$temp_file")
         if [ "$debug_enabled" = "true" ]; then
         echo "DEBUG wrapper: switch_info=$switch_info" >> "$debug_log"
@@ -52,10 +52,24 @@ jean() {
         fi
 
         # Parse the info (using worktree_path instead of path to avoid PATH conflict)
-        IFS='|' read -r worktree_path branch auto_claude target_window script_command claude_session_name is_claude_initialized <<< "$switch_info"
+        # agent_command is last so it keeps any "|" it contains (e.g. "claude --continue || claude")
+        IFS='|' read -r worktree_path branch auto_claude target_window script_command claude_session_name is_agent_initialized multiplexer agent_command <<< "$switch_info"
 
         # Check if we got valid data (has at least two pipes)
         if [[ "$switch_info" == *"|"*"|"* ]]; then
+            # Zellij: jean has already created the session, so just select the target tab and attach
+            if [ "$multiplexer" = "zellij" ]; then
+                if ! command -v zellij >/dev/null 2>&1 || [ -n "$ZELLIJ" ]; then
+                    # No zellij, or already inside zellij (which can't switch sessions from the command line), just cd
+                    cd "$worktree_path" || return
+                    echo "Switched to worktree: $branch (zellij session: $claude_session_name)"
+                    return
+                fi
+                zellij --session "$claude_session_name" action go-to-tab-name "$target_window" >/dev/null 2>&1
+                (cd "$worktree_path" && zellij attach --create "$claude_session_name")
+                continue
+            fi
+
             # Check if tmux is available
             if ! command -v tmux >/dev/null 2>&1; then
                 # No tmux, just cd
@@ -89,38 +103,35 @@ jean() {
                 # Different session - fall through to switch to it
             fi
 
-            # Set window index based on target window
-            # Note: with base-index 1, windows are 1, 2, 3... instead of 0, 1, 2...
-            local window_index="1"
-            if [ "$target_window" = "claude" ]; then
-                window_index="2"
-            fi
-
-            # Check if session exists
+            # Check if session exists (jean creates it beforehand when jean.json declares a layout)
             if tmux has-session -t "=$session_name" 2>/dev/null; then
-                # Session exists - check if target window exists
-                if ! tmux list-windows -t "$session_name" -F "#{window_index}:#{window_name}" | grep -q "^${window_index}:"; then
+                # Session exists - check if target window exists (windows are matched by name)
+                if ! tmux list-windows -t "$session_name" -F "#{window_name}" | grep -qxF "$target_window"; then
                     # Target window doesn't exist, create it
                     if [ "$target_window" = "claude" ]; then
-                        # Create claude window with claude command
-                        if command -v claude >/dev/null 2>&1; then
-                            if [ "$is_claude_initialized" = "true" ]; then
-                                # Try with --continue first, fallback to fresh start if it fails
-                                tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "claude --add-dir \"$worktree_path\" --continue --permission-mode plan || claude --add-dir \"$worktree_path\" --permission-mode plan"
-                            else
-                                tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "claude --add-dir \"$worktree_path\" --permission-mode plan"
-                            fi
+                        # Create claude window with the agent command built by jean (it resumes initialized agents)
+                        if [ -n "$agent_command" ]; then
+                            tmux new-window -t "$session_name:" -c "$worktree_path" -n "claude" "$agent_command"
+                            # Keep the window when the agent exits so jean shows it as exited
+                            tmux set-option -w -t "$session_name:=claude" remain-on-exit on
                         else
-                            # Fallback to shell if claude not available
-                            tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude"
+                            # Fallback to shell for the shell profile or if the agent is not installed
+                            tmux new-window -t "$session_name:" -c "$worktree_path" -n "claude"
                         fi
                     else
-                        # Create terminal window
-                        tmux new-window -t "$session_name:1" -c "$worktree_path" -n "terminal"
+                        # Create terminal (or other layout) window
+                        tmux new-window -t "$session_name:" -c "$worktree_path" -n "$target_window"
+                    fi
+                elif [ "$target_window" = "claude" ] && [ "$(tmux display-message -p -t "$session_name:=claude" '#{pane_dead}')" = "1" ]; then
+                    # The agent exited - start it again in the kept window
+                    if [ -n "$agent_command" ]; then
+                        tmux respawn-pane -t "$session_name:=claude" "$agent_command"
+                    else
+                        tmux respawn-pane -t "$session_name:=claude"
                     fi
                 fi
                 # Attach to target window
-                tmux attach-session -t "$session_name:${window_index}"
+                tmux attach-session -t "$session_name:=$target_window"
                 continue
             else
                 # Create new session with both windows
@@ -132,13 +143,9 @@ jean() {
 
                 # Window 2: claude (if auto-claude is true)
                 if [ "$auto_claude" = "true" ]; then
-                    if command -v claude >/dev/null 2>&1; then
-                        if [ "$is_claude_initialized" = "true" ]; then
-                            # Try with --continue first, fallback to fresh start if it fails
-                            tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "claude --add-dir \"$worktree_path\" --continue --permission-mode plan || claude --add-dir \"$worktree_path\" --permission-mode plan"
-                        else
-                            tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "claude --add-dir \"$worktree_path\" --permission-mode plan"
-                        fi
+                    if [ -n "$agent_command" ]; then
+                        tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "$agent_command"
+                        tmux set-option -w -t "$session_name:=claude" remain-on-exit on
                     else
                         # Fallback: create window with shell
                         tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude"
@@ -146,7 +153,7 @@ jean() {
                 fi
 
                 # Attach to target window
-                tmux attach-session -t "$session_name:${window_index}"
+                tmux attach-session -t "$session_name:=$target_window"
                 continue
             fi
         else
@@ -192,7 +199,7 @@ function jean
 
         # Check if switch info was written
         if test -f "$temp_file" -a -s "$temp_file"
-            # Read the switch info: path|branch|auto-claude|target-window|script-command|claude-session-name|is-claude-initialized
+            # Read the switch info: path|branch|auto-claude|target-window|script-command|claude-session-name|is-agent-initialized|multiplexer|agent-command
             set switch_info (cat $temp_file)
             rm $temp_file
 
@@ -212,9 +219,27 @@ function jean
                 if test (count $parts) -ge 6
                     set claude_session_name $parts[6]
                 end
-                set is_claude_initialized "false"
-                if test (count $parts) -ge 7
-                    set is_claude_initialized $parts[7]
+                set multiplexer "tmux"
+                if test (count $parts) -ge 8
+                    set multiplexer $parts[8]
+                end
+                # The agent command is last and may itself contain "|" (e.g. "claude --continue || claude")
+                set agent_command ""
+                if test (count $parts) -ge 9
+                    set agent_command (string join '|' $parts[9..-1])
+                end
+
+                # Zellij: jean has already created the session, so just select the target tab and attach
+                if test "$multiplexer" = "zellij"
+                    if not command -v zellij &> /dev/null; or test -n "$ZELLIJ"
+                        # No zellij, or already inside zellij (which can't switch sessions from the command line), just cd
+                        cd $worktree_path
+                        echo "Switched to worktree: $branch (zellij session: $claude_session_name)"
+                        return
+                    end
+                    zellij --session "$claude_session_name" action go-to-tab-name "$target_window" &> /dev/null
+                    fish -c 'cd $argv[1]; and zellij attach --create $argv[2]' "$worktree_path" "$claude_session_name"
+                    continue
                 end
 
                 # Check if tmux is available
@@ -245,39 +270,35 @@ function jean
                     return
                 end
 
-                # Set window index based on target window
-                # Note: with base-index 1, windows are 1, 2, 3... instead of 0, 1, 2...
-                set window_index "1"
-                if test "$target_window" = "claude"
-                    set window_index "2"
-                end
-
-                # Check if session exists
+                # Check if session exists (jean creates it beforehand when jean.json declares a layout)
                 if tmux has-session -t "=$session_name" 2>/dev/null
-                    # Session exists - check if target window exists
-                    set window_exists (tmux list-windows -t "$session_name" -F "#{window_index}:#{window_name}" | grep "^${window_index}:" | wc -l)
-                    if test $window_exists -eq 0
+                    # Session exists - check if target window exists (windows are matched by name)
+                    if not tmux list-windows -t "$session_name" -F "#{window_name}" | grep -qxF "$target_window"
                         # Target window doesn't exist, create it
                         if test "$target_window" = "claude"
-                            # Create claude window
-                            if command -v claude &> /dev/null
-                                set claude_args "--add-dir \"$worktree_path\" --permission-mode plan"
-                                if test "$is_claude_initialized" = "true"
-                                    # Try with --continue first, fallback to fresh start if it fails
-                                    set claude_args "--add-dir \"$worktree_path\" --continue --permission-mode plan; or claude --add-dir \"$worktree_path\" --permission-mode plan"
-                                end
-                                tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "claude $claude_args"
+                            # Create claude window with the agent command built by jean (it resumes initialized agents)
+                            if test -n "$agent_command"
+                                tmux new-window -t "$session_name:" -c "$worktree_path" -n "claude" "$agent_command"
+                                # Keep the window when the agent exits so jean shows it as exited
+                                tmux set-option -w -t "$session_name:=claude" remain-on-exit on
                             else
-                                # Fallback to shell
-                                tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude"
+                                # Fallback to shell for the shell profile or if the agent is not installed
+                                tmux new-window -t "$session_name:" -c "$worktree_path" -n "claude"
                             end
                         else
-                            # Create terminal window
-                            tmux new-window -t "$session_name:1" -c "$worktree_path" -n "terminal"
+                            # Create terminal (or other layout) window
+                            tmux new-window -t "$session_name:" -c "$worktree_path" -n "$target_window"
+                        end
+                    else if test "$target_window" = "claude"; and string match -q 1 -- (tmux display-message -p -t "$session_name:=claude" '#{pane_dead}')
+                        # The agent exited - start it again in the kept window
+                        if test -n "$agent_command"
+                            tmux respawn-pane -t "$session_name:=claude" "$agent_command"
+                        else
+                            tmux respawn-pane -t "$session_name:=claude"
                         end
                     end
                     # Attach to target window
-                    tmux attach-session -t "$session_name:${window_index}"
+                    tmux attach-session -t "$session_name:=$target_window"
                     continue
                 else
                     # Create new session with both windows
@@ -286,13 +307,9 @@ function jean
 
                     # Window 2: claude (if auto-claude is true)
                     if test "$auto_claude" = "true"
-                        if command -v claude &> /dev/null
-                            set claude_args "--add-dir \"$worktree_path\" --permission-mode plan"
-                            if test "$is_claude_initialized" = "true"
-                                # Try with --continue first, fallback to fresh start if it fails
-                                set claude_args "--add-dir \"$worktree_path\" --continue --permission-mode plan; or claude --add-dir \"$worktree_path\" --permission-mode plan"
-                            end
-                            tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "claude $claude_args"
+                        if test -n "$agent_command"
+                            tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude" "$agent_command"
+                            tmux set-option -w -t "$session_name:=claude" remain-on-exit on
                         else
                             # Fallback: create window with shell
                             tmux new-window -t "$session_name:2" -c "$worktree_path" -n "claude"
@@ -300,7 +317,7 @@ function jean
                     end
 
                     # Attach to target window
-                    tmux attach-session -t "$session_name:${window_index}"
+                    tmux attach-session -t "$session_name:=$target_window"
                     continue
                 end
             end
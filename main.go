@@ -130,7 +130,8 @@ func main() {
 	if m, ok := finalModel.(tui.Model); ok {
 		switchInfo := m.GetSwitchInfo()
 		if switchInfo.Path != "" {
-			// Format: path|branch|auto-claude|target-window|script-command|session-name|is-claude-initialized
+			// Format: path|branch|auto-claude|target-window|script-command|session-name|is-agent-initialized|multiplexer|agent-command
+			// agent-command is last because it may itself contain "|" (e.g. "claude --continue || claude")
 			autoCl := "false"
 			if switchInfo.AutoClaude {
 				autoCl = "true"
@@ -140,10 +141,10 @@ func main() {
 				targetWindow = "terminal" // Default to terminal window if not set
 			}
 			isInitialized := "false"
-			if switchInfo.IsClaudeInitialized {
+			if switchInfo.IsAgentInitialized {
 				isInitialized = "true"
 			}
-			switchData := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s", switchInfo.Path, switchInfo.Branch, autoCl, targetWindow, switchInfo.ScriptCommand, switchInfo.SessionName, isInitialized)
+			switchData := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s", switchInfo.Path, switchInfo.Branch, autoCl, targetWindow, switchInfo.ScriptCommand, switchInfo.SessionName, isInitialized, switchInfo.Multiplexer, switchInfo.AgentCommand)
 
 			// Debug: log what we're writing
 			debugLog(fmt.Sprintf("DEBUG main: switchInfo={Path:%q Branch:%q AutoClaude:%v TargetWindow:%q SessionName:%q}", switchInfo.Path, switchInfo.Branch, switchInfo.AutoClaude, switchInfo.TargetWindow, switchInfo.SessionName))
@@ -152,6 +152,14 @@ func (c *Client) GenerateBranchName(diff, customPrompt string) (string, error) {
 // GeneratePRContent generates a PR title and description from a git diff
 // If customPrompt is empty, uses the default prompt
 func (c *Client) GeneratePRContent(diff, customPrompt string) (title, description string, err error) {
+	return c.GeneratePRContentWithTemplate(diff, customPrompt, "")
+}
+
+// GeneratePRContentWithTemplate generates a PR title and description that fill in the repository's PR template
+// A custom prompt without a {template} placeholder gets PRTemplateSection appended, without a custom prompt
+// DefaultPRTemplatePrompt is used
+// Without a template, this behaves like GeneratePRContent
+func (c *Client) GeneratePRContentWithTemplate(diff, customPrompt, template string) (title, description string, err error) {
 	if c.apiKey == "" {
 		return "", "", fmt.Errorf("OpenRouter API key not configured")
 	}
@@ -161,15 +169,7 @@ func (c *Client) GeneratePRContent(diff, customPrompt string) (title, descriptio
 		diff = diff[:5000]
 	}
 
-	// Use custom prompt if provided, otherwise use default
-	prompt := customPrompt
-	if prompt == "" {
-		prompt = DefaultPRPrompt
-	}
-	// Replace {diff} placeholder with actual diff
-	prompt = strings.ReplaceAll(prompt, "{diff}", diff)
-
-	response, err := c.callAPI(prompt)
+	response, err := c.callAPI(BuildPRPrompt(diff, customPrompt, template))
 	if err != nil {
 		return "", "", err
 	}
@@ -192,6 +192,52 @@ func (c *Client) GeneratePRContent(diff, customPrompt string) (title, descriptio
 	return content.Title, content.Description, nil
 }
 
+// BuildPRPrompt returns the prompt generating PR content for a diff, see GeneratePRContentWithTemplate
+// A custom prompt equal to DefaultPRPrompt counts as no custom prompt
+func BuildPRPrompt(diff, customPrompt, template string) string {
+	prompt := customPrompt
+	if prompt == DefaultPRPrompt {
+		prompt = ""
+	}
+	if template != "" {
+		if prompt == "" {
+			prompt = DefaultPRTemplatePrompt
+		} else if !strings.Contains(prompt, "{template}") {
+			prompt += PRTemplateSection
+		}
+		prompt = strings.ReplaceAll(prompt, "{template}", strings.TrimSpace(template))
+	} else if prompt == "" {
+		prompt = DefaultPRPrompt
+	}
+	// Replace {diff} placeholder with actual diff
+	return strings.ReplaceAll(prompt, "{diff}", diff)
+}
+
+// SummarizeTranscript summarizes an agent session transcript as a markdown bullet list
+// Only the end of long transcripts is sent
+func (c *Client) SummarizeTranscript(transcript string) (string, error) {
+	if c.apiKey == "" {
+		return "", fmt.Errorf("OpenRouter API key not configured")
+	}
+
+	// Limit transcript to reasonable size, keeping the most recent part
+	if len(transcript) > 8000 {
+		transcript = transcript[len(transcript)-8000:]
+	}
+
+	prompt := strings.ReplaceAll(DefaultTranscriptSummaryPrompt, "{transcript}", transcript)
+	summary, err := c.callAPI(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return "", fmt.Errorf("AI generated an empty summary")
+	}
+	return summary, nil
+}
+
 // callAPI makes a request to the OpenRouter API
 func (c *Client) callAPI(prompt string) (string, error) {
 	req := ChatRequest{
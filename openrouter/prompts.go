@@ -80,6 +80,54 @@ Example JSON Response:
 
 Git diff:
 {diff}`
+
+	// DefaultPRTemplatePrompt generates a PR title and a description that fills in the repository's PR template
+	// The {template} and {diff} placeholders will be replaced with the template and the actual git diff
+	DefaultPRTemplatePrompt = `Generate a pull request title and a description for these changes by filling in the repository's pull request template.
+
+Return ONLY valid JSON in this format (no markdown, no extra text):
+{"title": "...", "description": "..."}
+
+Requirements:
+- title: CRITICAL - MUST be 72 characters or less (hard limit). Present tense, user-friendly summary.
+- description: Required. The template below in markdown, with every section filled in based on the changes.
+
+Template Guidelines:
+- Keep the template's headings and their order
+- Replace placeholder text and HTML comments with content describing the changes
+- Tick checklist items ("- [x]") only when the changes clearly satisfy them, leave the others unticked
+- Write "N/A" for sections that don't apply instead of removing them
+- Keep each item short and user-friendly
+
+Pull request template:
+{template}
+
+Git diff:
+{diff}`
+
+	// PRTemplateSection is appended to custom PR prompts without a {template} placeholder when the
+	// repository has a PR template, so the description still fills it in
+	// The {template} placeholder will be replaced with the template
+	PRTemplateSection = `
+
+The description must fill in the repository's pull request template below: keep its headings and their order, replace placeholder text and HTML comments with content describing the changes, and write "N/A" for sections that don't apply.
+
+Pull request template:
+{template}`
+
+	// DefaultTranscriptSummaryPrompt summarizes an agent session transcript for a PR description
+	// The {transcript} placeholder will be replaced with the captured terminal output of the session
+	DefaultTranscriptSummaryPrompt = `Summarize this coding agent session for the reviewers of a pull request.
+
+Return ONLY a short markdown bullet list (3-6 items, no heading, no extra text) covering:
+- What the agent was asked to do
+- Key decisions and trade-offs made along the way
+- Anything left unfinished or worth a closer look
+
+The transcript is raw terminal output and may contain UI noise, ignore it.
+
+Transcript:
+{transcript}`
 )
 
 // GetDefaultCommitPrompt returns the default commit message prompt
@@ -96,3 +144,8 @@ func GetDefaultBranchNamePrompt() string {
 func GetDefaultPRPrompt() string {
 	return DefaultPRPrompt
 }
+
+// GetDefaultPRTemplatePrompt returns the default prompt for filling in a PR template
+func GetDefaultPRTemplatePrompt() string {
+	return DefaultPRTemplatePrompt
+}
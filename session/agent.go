@@ -0,0 +1,62 @@
+package session
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// AgentCommand builds the shell command that starts an agent profile in a worktree
+// If resume is set and the profile has resume arguments, the command falls back to a fresh
+// start when resuming fails (e.g. there is no previous conversation)
+// Returns "" if the profile is a plain shell or its command is not installed
+func AgentCommand(profile config.AgentProfile, path string, resume bool) string {
+	if profile.Command == "" {
+		return ""
+	}
+	if _, err := exec.LookPath(profile.Command); err != nil {
+		return ""
+	}
+
+	fresh := agentInvocation(profile, path, nil)
+	if !resume || len(profile.ResumeArgs) == 0 {
+		return fresh
+	}
+	return agentInvocation(profile, path, profile.ResumeArgs) + " || " + fresh
+}
+
+// agentInvocation renders one invocation of the agent, with its environment and arguments quoted for sh
+func agentInvocation(profile config.AgentProfile, path string, resumeArgs []string) string {
+	var parts []string
+
+	if len(profile.Env) > 0 {
+		keys := make([]string, 0, len(profile.Env))
+		for key := range profile.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts = append(parts, "env")
+		for _, key := range keys {
+			parts = append(parts, shellQuote(key+"="+profile.Env[key]))
+		}
+	}
+
+	parts = append(parts, shellQuote(profile.Command))
+	for _, arg := range resumeArgs {
+		parts = append(parts, shellQuote(arg))
+	}
+	for _, arg := range profile.Args {
+		parts = append(parts, shellQuote(strings.ReplaceAll(arg, "{path}", path)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote quotes s for sh and fish, leaving simple words as they are
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_=./:,@+") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// TestAgentCommand tests building the agent command line from a profile
+func TestAgentCommand(t *testing.T) {
+	profile := config.AgentProfile{
+		Name:       "custom",
+		Command:    "sh",
+		Args:       []string{"--dir", "{path}", "--note", "it's"},
+		ResumeArgs: []string{"--continue"},
+		Env:        map[string]string{"MODE": "plan", "A": "1"},
+	}
+
+	fresh := "env A=1 MODE=plan sh --dir '/tmp/my repo' --note 'it'\\''s'"
+	if got := AgentCommand(profile, "/tmp/my repo", false); got != fresh {
+		t.Errorf("Fresh start:\n got  %s\n want %s", got, fresh)
+	}
+
+	resume := "env A=1 MODE=plan sh --continue --dir '/tmp/my repo' --note 'it'\\''s' || " + fresh
+	if got := AgentCommand(profile, "/tmp/my repo", true); got != resume {
+		t.Errorf("Resume:\n got  %s\n want %s", got, resume)
+	}
+
+	if got := AgentCommand(config.AgentProfile{Name: "shell"}, "/tmp", false); got != "" {
+		t.Errorf("Expected no command for a plain shell, got %q", got)
+	}
+	if got := AgentCommand(config.AgentProfile{Command: "jean-no-such-agent"}, "/tmp", false); got != "" {
+		t.Errorf("Expected no command for a missing agent, got %q", got)
+	}
+}
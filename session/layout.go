@@ -0,0 +1,94 @@
+package session
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// CreateWithLayout creates a detached session with the windows and panes of a layout
+// Agent panes run agentCommand, or a shell if it is empty. Other pane commands are typed into
+// a shell, so the pane stays open (with the command in its history) when the command exits
+func (m *Manager) CreateWithLayout(sessionName, path string, layout config.Layout, agentCommand string) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	for i, window := range layout.Windows {
+		panes := window.Panes
+		if len(panes) == 0 {
+			panes = []config.LayoutPane{{}}
+		}
+
+		var args []string
+		if i == 0 {
+			args = []string{"new-session", "-d", "-s", sessionName}
+		} else {
+			args = []string{"new-window", "-d", "-t", sessionName + ":"}
+		}
+		args = append(args, "-c", path, "-n", window.Name, "-P", "-F", "#{pane_id}")
+		paneID, err := m.startPane(args, panes[0], agentCommand)
+		if err != nil {
+			if i > 0 {
+				_ = m.Kill(sessionName)
+			}
+			return fmt.Errorf("failed to create window %q: %w", window.Name, err)
+		}
+
+		for _, pane := range panes[1:] {
+			args = []string{"split-window", "-d", "-t", paneID, "-c", path, "-P", "-F", "#{pane_id}"}
+			if pane.Split == "right" {
+				args = append(args, "-h")
+			}
+			if pane.Size != "" {
+				args = append(args, "-l", pane.Size)
+			}
+			if paneID, err = m.startPane(args, pane, agentCommand); err != nil {
+				_ = m.Kill(sessionName)
+				return fmt.Errorf("failed to split window %q: %w", window.Name, err)
+			}
+		}
+	}
+
+	return exec.Command("tmux", "select-window", "-t", sessionName+":="+layout.FocusWindow()).Run()
+}
+
+// ResetLayout recreates a session from a layout, stopping everything running in it
+func (m *Manager) ResetLayout(sessionName, path string, layout config.Layout, agentCommand string) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	if m.SessionExists(sessionName) {
+		if err := m.Kill(sessionName); err != nil {
+			return fmt.Errorf("failed to stop session: %w", err)
+		}
+	}
+	return m.CreateWithLayout(sessionName, path, layout, agentCommand)
+}
+
+// startPane runs a tmux command that creates a pane (printing its ID) and starts the pane's command
+func (m *Manager) startPane(args []string, pane config.LayoutPane, agentCommand string) (string, error) {
+	if pane.Agent && agentCommand != "" {
+		args = append(args, agentCommand)
+	}
+	output, err := exec.Command("tmux", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	paneID := strings.TrimSpace(string(output))
+	if pane.Agent && agentCommand != "" {
+		keepAgentPane(paneID)
+	}
+
+	if !pane.Agent && pane.Command != "" {
+		if err := exec.Command("tmux", "send-keys", "-t", paneID, "-l", pane.Command).Run(); err != nil {
+			return paneID, err
+		}
+		if err := exec.Command("tmux", "send-keys", "-t", paneID, "Enter").Run(); err != nil {
+			return paneID, err
+		}
+	}
+	return paneID, nil
+}
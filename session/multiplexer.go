@@ -0,0 +1,43 @@
+package session
+
+import "github.com/coollabsio/jean-tui/config"
+
+const (
+	MultiplexerTmux   = "tmux"
+	MultiplexerZellij = "zellij"
+)
+
+// Multiplexers are the supported terminal multiplexers, the first one is the default
+var Multiplexers = []string{MultiplexerTmux, MultiplexerZellij}
+
+// Multiplexer is a terminal multiplexer hosting the worktree sessions
+type Multiplexer interface {
+	Name() string
+	IsAvailable() bool
+	SanitizeBranchName(branch string) string
+	SanitizeName(repoName, branch string) string
+
+	SessionExists(sessionName string) bool
+	List(repoPath string) ([]Session, error)
+	ListWithAgentState(repoPath string) ([]Session, error)
+	Attach(sessionName string) error
+	Kill(sessionName string) error
+	RenameSession(oldName, newName string) error
+	WindowNames(sessionName string) ([]string, error)
+	CaptureWindow(sessionName, window string) (string, error)
+
+	// CreateWithLayout creates a detached session; ResetLayout recreates an existing one
+	CreateWithLayout(sessionName, path string, layout config.Layout, agentCommand string) error
+	ResetLayout(sessionName, path string, layout config.Layout, agentCommand string) error
+
+	AgentState(sessionName string) AgentState
+	SendPrompt(sessionName, prompt string) error
+}
+
+// NewMultiplexer returns the multiplexer with the given name, tmux for "" or unknown names
+func NewMultiplexer(name string) Multiplexer {
+	if name == MultiplexerZellij {
+		return NewZellijManager()
+	}
+	return NewManager()
+}
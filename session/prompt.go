@@ -0,0 +1,44 @@
+package session
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// pasteSettleDelay gives the agent time to finish handling a bracketed paste before Enter submits it
+const pasteSettleDelay = 150 * time.Millisecond
+
+// SendPrompt types a prompt into the claude window of a session and submits it
+// The prompt is delivered through a tmux paste buffer with bracketed paste, so newlines
+// in multi-line prompts are inserted as text instead of submitting each line
+func (m *Manager) SendPrompt(sessionName, prompt string) error {
+	prompt = strings.TrimRight(prompt, "\n")
+	if strings.TrimSpace(prompt) == "" {
+		return fmt.Errorf("prompt is empty")
+	}
+
+	target := sessionName + ":=claude"
+	if err := exec.Command("tmux", "has-session", "-t", target).Run(); err != nil {
+		return fmt.Errorf("no Claude window in session %s", sessionName)
+	}
+
+	buffer := "jean-prompt-" + sessionName
+	load := exec.Command("tmux", "load-buffer", "-b", buffer, "-")
+	load.Stdin = strings.NewReader(prompt)
+	if output, err := load.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to load prompt: %s", strings.TrimSpace(string(output)))
+	}
+
+	// -p wraps the text in bracketed paste markers, -d deletes the buffer afterwards
+	if output, err := exec.Command("tmux", "paste-buffer", "-p", "-d", "-b", buffer, "-t", target).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to paste prompt: %s", strings.TrimSpace(string(output)))
+	}
+
+	time.Sleep(pasteSettleDelay)
+	if output, err := exec.Command("tmux", "send-keys", "-t", target, "Enter").CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to submit prompt: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// scrollbackLines is how many lines of history are captured per window
+const scrollbackLines = 2000
+
+// WindowNames returns the window names of a tmux session, in order
+func (m *Manager) WindowNames(sessionName string) ([]string, error) {
+	output, err := exec.Command("tmux", "list-windows", "-t", sessionName, "-F", "#{window_name}").Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSpace(string(output)), "\n"), nil
+}
+
+// CaptureWindow returns the whole history of the active pane of a window
+func (m *Manager) CaptureWindow(sessionName, window string) (string, error) {
+	output, err := exec.Command("tmux", "capture-pane", "-p", "-J", "-S", "-", "-t", sessionName+":="+window).Output()
+	if err != nil {
+		return "", fmt.Errorf("no %s window in session %s", window, sessionName)
+	}
+	return string(output), nil
+}
+
+// CaptureScrollback saves the history of the active pane of each window in dir, one <window>.txt file per window
+func (m *Manager) CaptureScrollback(sessionName, dir string) error {
+	windows, err := m.WindowNames(sessionName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for _, window := range windows {
+		output, err := exec.Command("tmux", "capture-pane", "-p", "-J", "-S", "-"+strconv.Itoa(scrollbackLines), "-t", sessionName+":="+window).Output()
+		if err != nil {
+			continue
+		}
+		if err := os.WriteFile(scrollbackFile(dir, window), []byte(strings.TrimRight(string(output), "\n")+"\n"), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreScrollback prints the captured history of each plain shell window before its shell starts
+// Windows running a command or the agent are skipped, they redraw their own output
+func (m *Manager) RestoreScrollback(sessionName, path, dir string, layout config.Layout) error {
+	for _, window := range layout.Windows {
+		if len(window.Panes) > 0 && (window.Panes[0].Agent || window.Panes[0].Command != "") {
+			continue
+		}
+		file := scrollbackFile(dir, window.Name)
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		// The first pane of a new window is its active pane
+		cmd := exec.Command("tmux", "respawn-pane", "-k", "-c", path, "-t", sessionName+":="+window.Name,
+			"sh", "-c", `cat "$0"; exec "${SHELL:-sh}"`, file)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to restore scrollback of window %q: %s", window.Name, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// scrollbackFile returns the file holding the captured history of a window
+func scrollbackFile(dir, window string) string {
+	return filepath.Join(dir, strings.ReplaceAll(window, string(filepath.Separator), "_")+".txt")
+}
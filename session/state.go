@@ -0,0 +1,95 @@
+package session
+
+import (
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AgentState is the state of the agent running in a session's claude window
+type AgentState string
+
+const (
+	AgentStateNone    AgentState = ""        // No claude window
+	AgentStateBusy    AgentState = "busy"    // Working on a request
+	AgentStateWaiting AgentState = "waiting" // Asking for permission or an answer
+	AgentStateIdle    AgentState = "idle"    // Finished, sitting at an empty prompt
+	AgentStateExited  AgentState = "exited"  // Crashed or quit back to a shell
+)
+
+// capturedLines is how many lines at the bottom of the claude pane are classified
+const capturedLines = 40
+
+// shells are the pane commands that mean the agent is no longer running in the window
+var shells = map[string]bool{"bash": true, "zsh": true, "sh": true, "fish": true, "dash": true, "ksh": true}
+
+var (
+	// busyPattern matches the interrupt hint Claude shows while working (e.g. "✻ Thinking… (12s · esc to interrupt)")
+	busyPattern = regexp.MustCompile(`(?i)esc to interrupt`)
+
+	// waitingPattern matches permission prompts and questions that need an answer
+	waitingPattern = regexp.MustCompile(`(?i)do you want to|would you like to|❯\s*1\.|\(y/n\)|\[y/n\]|waiting for your (input|response)`)
+
+	// promptPattern matches Claude's input box
+	promptPattern = regexp.MustCompile(`^\s*[│|]?\s*>(\s|$)`)
+)
+
+// ClassifyAgentOutput classifies the agent state from the visible text of its pane
+// Permission prompts replace Claude's input box, so only text below the last input box
+// is checked for them; older prompts in the scrollback are ignored
+func ClassifyAgentOutput(content string) AgentState {
+	// A blank pane has nothing to go on (e.g. the agent is still starting)
+	if strings.TrimSpace(content) == "" {
+		return AgentStateIdle
+	}
+	lines := strings.Split(strings.TrimRight(content, "\n "), "\n")
+	if len(lines) > capturedLines {
+		lines = lines[len(lines)-capturedLines:]
+	}
+
+	if busyPattern.MatchString(strings.Join(lines, "\n")) {
+		return AgentStateBusy
+	}
+
+	lastPrompt := -1
+	for i, line := range lines {
+		if promptPattern.MatchString(line) {
+			lastPrompt = i
+		}
+	}
+	if waitingPattern.MatchString(strings.Join(lines[lastPrompt+1:], "\n")) {
+		return AgentStateWaiting
+	}
+	if lastPrompt >= 0 {
+		return AgentStateIdle
+	}
+	// Output without a prompt or interrupt hint means the agent is still producing it
+	return AgentStateBusy
+}
+
+// AgentState returns the state of the agent in the claude window of a session
+// Returns AgentStateNone if the session has no claude window
+func (m *Manager) AgentState(sessionName string) AgentState {
+	target := sessionName + ":=claude"
+
+	cmd := exec.Command("tmux", "display-message", "-p", "-t", target, "#{pane_dead} #{pane_current_command}")
+	output, err := cmd.Output()
+	if err != nil {
+		return AgentStateNone
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 || fields[0] == "1" {
+		return AgentStateExited
+	}
+	if len(fields) > 1 && shells[fields[1]] {
+		return AgentStateExited
+	}
+
+	cmd = exec.Command("tmux", "capture-pane", "-p", "-J", "-t", target, "-S", "-"+strconv.Itoa(capturedLines))
+	content, err := cmd.Output()
+	if err != nil {
+		return AgentStateNone
+	}
+	return ClassifyAgentOutput(string(content))
+}
@@ -0,0 +1,51 @@
+package session
+
+import "testing"
+
+// TestClassifyAgentOutput tests agent state detection from captured pane content
+func TestClassifyAgentOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    AgentState
+	}{
+		{
+			name:    "working",
+			content: "● Reading files\n\n✻ Thinking… (12s · esc to interrupt)\n\n│ >  │\n",
+			want:    AgentStateBusy,
+		},
+		{
+			name:    "permission prompt",
+			content: "Bash command\n  npm test\n\nDo you want to proceed?\n❯ 1. Yes\n  2. No\n",
+			want:    AgentStateWaiting,
+		},
+		{
+			name:    "idle at prompt",
+			content: "● Done, all tests pass.\n\n│ > │\n  ? for shortcuts\n",
+			want:    AgentStateIdle,
+		},
+		{
+			name:    "old permission prompt above input box",
+			content: "Do you want to proceed?\n❯ 1. Yes\n● Ran tests\n\n│ > │\n",
+			want:    AgentStateIdle,
+		},
+		{
+			name:    "streaming output",
+			content: "● Writing the parser\n",
+			want:    AgentStateBusy,
+		},
+		{
+			name:    "blank pane",
+			content: "\n  \n",
+			want:    AgentStateIdle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyAgentOutput(tt.content); got != tt.want {
+				t.Errorf("ClassifyAgentOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
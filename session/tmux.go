@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/coollabsio/jean-tui/config"
 )
 
 const sessionPrefix = "jean-"
@@ -21,9 +23,10 @@ type Session struct {
 	Active       bool
 	Windows      int
 	LastActivity time.Time
+	AgentState   AgentState // State of the agent in the claude window, filled in by ListWithAgentState
 }
 
-// Manager handles tmux session operations
+// Manager handles tmux session operations, it is the default Multiplexer
 type Manager struct{}
 
 // NewManager creates a new session manager
@@ -31,9 +34,30 @@ func NewManager() *Manager {
 	return &Manager{}
 }
 
+// Name returns the multiplexer name
+func (m *Manager) Name() string {
+	return MultiplexerTmux
+}
+
+// IsAvailable checks if tmux is installed
+func (m *Manager) IsAvailable() bool {
+	return m.IsTmuxAvailable()
+}
+
 // SanitizeBranchName sanitizes a branch name for use as a git branch (without prefix)
 // This is useful when accepting user input for branch names
 func (m *Manager) SanitizeBranchName(branch string) string {
+	return sanitizeBranchName(branch)
+}
+
+// SanitizeName sanitizes a repo name and branch name for use as a tmux session name
+// Format: jean-<repo>-<branch>
+func (m *Manager) SanitizeName(repoName, branch string) string {
+	return sanitizeSessionName(repoName, branch)
+}
+
+// sanitizeBranchName replaces characters that are not allowed in session names with hyphens
+func sanitizeBranchName(branch string) string {
 	// Replace invalid characters with hyphens
 	reg := regexp.MustCompile(`[^a-zA-Z0-9\-_]`)
 	sanitized := reg.ReplaceAllString(branch, "-")
@@ -48,12 +72,11 @@ func (m *Manager) SanitizeBranchName(branch string) string {
 	return sanitized
 }
 
-// SanitizeName sanitizes a repo name and branch name for use as a tmux session name
-// Format: jean-<repo>-<branch>
-func (m *Manager) SanitizeName(repoName, branch string) string {
+// sanitizeSessionName builds the session name of a worktree: jean-<repo>-<branch>
+func sanitizeSessionName(repoName, branch string) string {
 	// Sanitize both repo name and branch name
-	sanitizedRepo := m.SanitizeBranchName(repoName)
-	sanitizedBranch := m.SanitizeBranchName(branch)
+	sanitizedRepo := sanitizeBranchName(repoName)
+	sanitizedBranch := sanitizeBranchName(branch)
 
 	// Combine with repo name for uniqueness across repositories
 	if sanitizedRepo != "" {
@@ -70,23 +93,6 @@ func (m *Manager) SessionExists(sessionName string) bool {
 	return err == nil
 }
 
-// isClaudeAvailable checks if the claude command is available in PATH
-func (m *Manager) isClaudeAvailable() bool {
-	cmd := exec.Command("sh", "-c", "command -v claude")
-	err := cmd.Run()
-	return err == nil
-}
-
-// buildClaudeCommand constructs the proper claude command with flags
-// path is the worktree path to add with --add-dir
-// isInitialized determines whether to use --continue flag
-func (m *Manager) buildClaudeCommand(path string, isInitialized bool) string {
-	if isInitialized {
-		return fmt.Sprintf("claude --add-dir %s --continue --permission-mode plan", path)
-	}
-	return fmt.Sprintf("claude --add-dir %s --permission-mode plan", path)
-}
-
 // createOrAttach creates a new session or attaches to existing one
 // targetWindow specifies which window to attach to: "terminal" (window 0) or "claude" (window 1)
 // Always creates both windows when creating a new session
@@ -115,20 +121,20 @@ func (m *Manager) Create(sessionName, path string, autoStartClaude bool, targetW
 
 	// Create window 2 (claude) if autoStartClaude is true
 	if autoStartClaude {
-		if m.isClaudeAvailable() {
-			// Claude is available - create window with proper flags
-			// Use --permission-mode plan (shell wrapper handles --continue for initialized sessions)
-			claudeCmd := m.buildClaudeCommand(path, false)
-			cmd = exec.Command("tmux", "new-window", "-t", sessionName+":2", "-c", path, "-n", "claude", claudeCmd)
+		if claudeCmd := AgentCommand(config.AgentPresets[0], path, false); claudeCmd != "" {
+			// Claude is available - create window with the default agent profile
+			// (shell wrapper handles the configured agent and resuming for initialized sessions)
+			cmd = exec.Command("tmux", "new-window", "-P", "-F", "#{pane_id}", "-t", sessionName+":2", "-c", path, "-n", "claude", claudeCmd)
 		} else {
 			// Claude not available - create shell window as fallback
 			cmd = exec.Command("tmux", "new-window", "-t", sessionName+":2", "-c", path, "-n", "claude")
 		}
 
-		if err := cmd.Run(); err != nil {
-			// Window creation failed, but session exists, so we continue
-			// The user can manually create the window later
+		if output, err := cmd.Output(); err == nil {
+			keepAgentPane(strings.TrimSpace(string(output)))
 		}
+		// If window creation failed, the session exists, so we continue
+		// The user can manually create the window later
 	}
 
 	// Attach to the target window
@@ -145,12 +151,8 @@ func (m *Manager) AttachToWindow(sessionName, path string, autoStartClaude bool,
 	if targetWindow == "claude" {
 		windowIndex = "2"
 		windowName = "claude"
-		// Use proper claude command with flags, or fallback to shell
-		if m.isClaudeAvailable() {
-			windowCommand = m.buildClaudeCommand(path, false)
-		} else {
-			windowCommand = "" // Fallback to shell
-		}
+		// Use the default agent profile, or fallback to shell ("") if claude is not installed
+		windowCommand = AgentCommand(config.AgentPresets[0], path, false)
 	} else {
 		windowIndex = "1"
 		windowName = "terminal"
@@ -175,13 +177,23 @@ func (m *Manager) AttachToWindow(sessionName, path string, autoStartClaude bool,
 		if !windowExists {
 			if windowCommand != "" {
 				// Create window with specific command
-				cmd := exec.Command("tmux", "new-window", "-t", sessionName+":"+windowIndex, "-c", path, "-n", windowName, windowCommand)
-				cmd.Run() // Ignore errors, window might be created concurrently
+				cmd := exec.Command("tmux", "new-window", "-P", "-F", "#{pane_id}", "-t", sessionName+":"+windowIndex, "-c", path, "-n", windowName, windowCommand)
+				// Ignore errors, window might be created concurrently
+				if output, err := cmd.Output(); err == nil {
+					keepAgentPane(strings.TrimSpace(string(output)))
+				}
 			} else {
 				// Create shell window
 				cmd := exec.Command("tmux", "new-window", "-t", sessionName+":"+windowIndex, "-c", path, "-n", windowName)
 				cmd.Run() // Ignore errors
 			}
+		} else if targetWindow == "claude" && agentPaneDead(sessionName) {
+			// The agent exited - start it again in the kept window
+			args := []string{"respawn-pane", "-t", sessionName + ":=claude"}
+			if windowCommand != "" {
+				args = append(args, windowCommand)
+			}
+			exec.Command("tmux", args...).Run()
 		}
 	}
 
@@ -194,6 +206,18 @@ func (m *Manager) AttachToWindow(sessionName, path string, autoStartClaude bool,
 	return cmd.Run()
 }
 
+// keepAgentPane keeps the window of an agent pane open after the agent exits, so AgentState reports
+// it as exited instead of the window disappearing
+func keepAgentPane(paneID string) {
+	exec.Command("tmux", "set-option", "-w", "-t", paneID, "remain-on-exit", "on").Run()
+}
+
+// agentPaneDead reports whether the agent in the claude window of a session has exited
+func agentPaneDead(sessionName string) bool {
+	output, err := exec.Command("tmux", "display-message", "-p", "-t", sessionName+":=claude", "#{pane_dead}").Output()
+	return err == nil && strings.TrimSpace(string(output)) == "1"
+}
+
 const jeanTmuxConfigMarker = "# === JEAN_TMUX_CONFIG_START_DO_NOT_MODIFY_THIS_LINE ==="
 const jeanTmuxConfigEnd = "# === JEAN_TMUX_CONFIG_END_DO_NOT_MODIFY_THIS_LINE ==="
 
@@ -437,6 +461,18 @@ func (m *Manager) List(repoPath string) ([]Session, error) {
 	return sessions, nil
 }
 
+// ListWithAgentState lists sessions like List and classifies the agent running in each one
+func (m *Manager) ListWithAgentState(repoPath string) ([]Session, error) {
+	sessions, err := m.List(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].AgentState = m.AgentState(sessions[i].Name)
+	}
+	return sessions, nil
+}
+
 // Kill terminates a tmux session and all its windows
 func (m *Manager) Kill(sessionName string) error {
 	// tmux kill-session handles killing all windows in the session efficiently
@@ -0,0 +1,314 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coollabsio/jean-tui/config"
+	"github.com/coollabsio/jean-tui/git"
+)
+
+// ZellijManager handles zellij session operations
+// Zellij cannot read the screen of a background tab, so agent state is not detected
+type ZellijManager struct{}
+
+// NewZellijManager creates a new zellij session manager
+func NewZellijManager() *ZellijManager {
+	return &ZellijManager{}
+}
+
+// Name returns the multiplexer name
+func (z *ZellijManager) Name() string {
+	return MultiplexerZellij
+}
+
+// IsAvailable checks if zellij is installed
+func (z *ZellijManager) IsAvailable() bool {
+	_, err := exec.LookPath("zellij")
+	return err == nil
+}
+
+// SanitizeBranchName sanitizes a branch name for use in a session name
+func (z *ZellijManager) SanitizeBranchName(branch string) string {
+	return sanitizeBranchName(branch)
+}
+
+// SanitizeName sanitizes a repo name and branch name for use as a session name
+// Format: jean-<repo>-<branch>
+func (z *ZellijManager) SanitizeName(repoName, branch string) string {
+	return sanitizeSessionName(repoName, branch)
+}
+
+// zellijSession is a line of `zellij list-sessions`
+type zellijSession struct {
+	name    string
+	current bool
+}
+
+// runningSessions returns the running zellij sessions (exited, resurrectable sessions are skipped)
+func (z *ZellijManager) runningSessions() []zellijSession {
+	// zellij exits with an error when there are no sessions
+	output, err := exec.Command("zellij", "list-sessions", "--no-formatting").Output()
+	if err != nil {
+		return nil
+	}
+
+	var sessions []zellijSession
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.Contains(line, "EXITED") {
+			continue
+		}
+		sessions = append(sessions, zellijSession{name: fields[0], current: strings.Contains(line, "(current)")})
+	}
+	return sessions
+}
+
+// SessionExists checks if a running zellij session with the given name exists
+func (z *ZellijManager) SessionExists(sessionName string) bool {
+	for _, sess := range z.runningSessions() {
+		if sess.name == sessionName {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the jean sessions of a repository
+// Zellij does not report a session's directory, so sessions are matched by their jean-<repo>- name prefix
+// and Path is left empty; Windows and LastActivity are not available either
+func (z *ZellijManager) List(repoPath string) ([]Session, error) {
+	prefix := sessionPrefix
+	if repoPath != "" {
+		prefix = repoSessionPrefix(git.RepoName(repoPath))
+	}
+
+	var sessions []Session
+	for _, sess := range z.runningSessions() {
+		if !strings.HasPrefix(sess.name, prefix) {
+			continue
+		}
+		sessions = append(sessions, Session{
+			Name:   sess.name,
+			Branch: strings.TrimPrefix(sess.name, prefix),
+			Active: sess.current,
+		})
+	}
+	return sessions, nil
+}
+
+// ListWithAgentState lists sessions like List; agent state is not available for zellij
+func (z *ZellijManager) ListWithAgentState(repoPath string) ([]Session, error) {
+	return z.List(repoPath)
+}
+
+// repoSessionPrefix returns the jean-<repo>- prefix the names of a repository's sessions start with
+func repoSessionPrefix(repoName string) string {
+	return sessionPrefix + sanitizeBranchName(repoName) + "-"
+}
+
+// WindowNames returns the tab names of a zellij session, in order
+func (z *ZellijManager) WindowNames(sessionName string) ([]string, error) {
+	output, err := exec.Command("zellij", "--session", sessionName, "action", "query-tab-names").Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSpace(string(output)), "\n"), nil
+}
+
+// CaptureWindow returns the whole history of the focused pane of a tab
+// Note that this switches the session to that tab
+func (z *ZellijManager) CaptureWindow(sessionName, window string) (string, error) {
+	if err := exec.Command("zellij", "--session", sessionName, "action", "go-to-tab-name", window).Run(); err != nil {
+		return "", fmt.Errorf("no %s tab in session %s", window, sessionName)
+	}
+	file, err := os.CreateTemp("", "jean-capture-*.txt")
+	if err != nil {
+		return "", err
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	if output, err := exec.Command("zellij", "--session", sessionName, "action", "dump-screen", "--full", file.Name()).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	data, err := os.ReadFile(file.Name())
+	return string(data), err
+}
+
+// AgentState is always AgentStateNone for zellij
+func (z *ZellijManager) AgentState(sessionName string) AgentState {
+	return AgentStateNone
+}
+
+// Attach attaches to an existing zellij session
+func (z *ZellijManager) Attach(sessionName string) error {
+	cmd := exec.Command("zellij", "attach", sessionName)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// Kill stops a zellij session and deletes it, so it is not offered for resurrection
+func (z *ZellijManager) Kill(sessionName string) error {
+	return exec.Command("zellij", "delete-session", "--force", sessionName).Run()
+}
+
+// RenameSession renames an existing zellij session
+// Returns nil if session doesn't exist (no error)
+func (z *ZellijManager) RenameSession(oldName, newName string) error {
+	if !z.SessionExists(oldName) {
+		return nil
+	}
+	return exec.Command("zellij", "--session", oldName, "action", "rename-session", newName).Run()
+}
+
+// CreateWithLayout creates a background zellij session with the tabs and panes of a layout
+// Pane commands run in a shell that stays open when the command exits
+func (z *ZellijManager) CreateWithLayout(sessionName, path string, layout config.Layout, agentCommand string) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	layoutDir := filepath.Join(os.TempDir(), "jean-layouts")
+	if err := os.MkdirAll(layoutDir, 0700); err != nil {
+		return err
+	}
+	layoutPath := filepath.Join(layoutDir, sessionName+".kdl")
+	if err := os.WriteFile(layoutPath, []byte(zellijLayout(layout, path, agentCommand)), 0600); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("zellij", "attach", "--create-background", sessionName, "options", "--default-layout", layoutPath, "--default-cwd", path)
+	cmd.Dir = path
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ResetLayout recreates a session from a layout, stopping everything running in it
+func (z *ZellijManager) ResetLayout(sessionName, path string, layout config.Layout, agentCommand string) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	if z.SessionExists(sessionName) {
+		if err := z.Kill(sessionName); err != nil {
+			return fmt.Errorf("failed to stop session: %w", err)
+		}
+	}
+	return z.CreateWithLayout(sessionName, path, layout, agentCommand)
+}
+
+// SendPrompt types a prompt into the claude tab of a session and submits it
+// The prompt is wrapped in bracketed paste markers so newlines don't submit each line
+// Note that this switches the session to its claude tab
+func (z *ZellijManager) SendPrompt(sessionName, prompt string) error {
+	prompt = strings.TrimRight(prompt, "\n")
+	if strings.TrimSpace(prompt) == "" {
+		return fmt.Errorf("prompt is empty")
+	}
+	if !z.SessionExists(sessionName) {
+		return fmt.Errorf("no session %s", sessionName)
+	}
+
+	action := func(args ...string) error {
+		output, err := exec.Command("zellij", append([]string{"--session", sessionName, "action"}, args...)...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s: %s", args[0], strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	if err := action("go-to-tab-name", "claude"); err != nil {
+		return fmt.Errorf("no Claude tab in session %s", sessionName)
+	}
+	// ESC [200~ and ESC [201~ start and end a bracketed paste
+	steps := [][]string{
+		{"write", "27", "91", "50", "48", "48", "126"},
+		{"write-chars", prompt},
+		{"write", "27", "91", "50", "48", "49", "126"},
+	}
+	for _, step := range steps {
+		if err := action(step...); err != nil {
+			return fmt.Errorf("failed to send prompt: %w", err)
+		}
+	}
+	time.Sleep(pasteSettleDelay)
+	return action("write", "13")
+}
+
+// zellijLayout renders a layout as a zellij KDL layout
+func zellijLayout(layout config.Layout, path, agentCommand string) string {
+	var b strings.Builder
+	b.WriteString("layout {\n")
+	fmt.Fprintf(&b, "    cwd %s\n", strconv.Quote(path))
+	focus := layout.FocusWindow()
+	for _, window := range layout.Windows {
+		fmt.Fprintf(&b, "    tab name=%s", strconv.Quote(window.Name))
+		if window.Name == focus {
+			b.WriteString(" focus=true")
+		}
+		b.WriteString(" {\n")
+		panes := window.Panes
+		if len(panes) == 0 {
+			panes = []config.LayoutPane{{}}
+		}
+		writeZellijPanes(&b, panes, agentCommand, "        ", "")
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writeZellijPanes writes a pane chain: the first pane, split with a container holding the rest
+func writeZellijPanes(b *strings.Builder, panes []config.LayoutPane, agentCommand, indent, size string) {
+	if len(panes) == 1 {
+		writeZellijPane(b, panes[0], agentCommand, indent, size)
+		return
+	}
+
+	// zellij's "vertical" split puts panes side by side
+	direction := "horizontal"
+	if panes[1].Split == "right" {
+		direction = "vertical"
+	}
+	fmt.Fprintf(b, "%spane split_direction=%q%s {\n", indent, direction, zellijSize(size))
+	writeZellijPane(b, panes[0], agentCommand, indent+"    ", "")
+	writeZellijPanes(b, panes[1:], agentCommand, indent+"    ", panes[1].Size)
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// writeZellijPane writes a single pane running a shell, a command, or the agent
+func writeZellijPane(b *strings.Builder, pane config.LayoutPane, agentCommand, indent, size string) {
+	command := pane.Command
+	if pane.Agent {
+		command = agentCommand
+	} else if command != "" {
+		command += `; exec "${SHELL:-sh}"`
+	}
+	if command == "" {
+		fmt.Fprintf(b, "%spane%s\n", indent, zellijSize(size))
+		return
+	}
+	fmt.Fprintf(b, "%spane%s command=\"sh\" {\n", indent, zellijSize(size))
+	fmt.Fprintf(b, "%s    args \"-c\" %s\n", indent, strconv.Quote(command))
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// zellijSize renders a layout pane size as a zellij size attribute
+func zellijSize(size string) string {
+	if size == "" {
+		return ""
+	}
+	if strings.HasSuffix(size, "%") {
+		return fmt.Sprintf(" size=%q", size)
+	}
+	return " size=" + size
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coollabsio/jean-tui/config"
+)
+
+// TestZellijLayout tests rendering a jean.json layout as a zellij KDL layout
+func TestZellijLayout(t *testing.T) {
+	layout := config.Layout{
+		Focus: "claude",
+		Windows: []config.LayoutWindow{
+			{Name: "terminal"},
+			{Name: "claude", Panes: []config.LayoutPane{
+				{Agent: true},
+				{Command: "npm run dev", Split: "right", Size: "30%"},
+				{Split: "below", Size: "10"},
+			}},
+		},
+	}
+
+	want := `layout {
+    cwd "/tmp/wt"
+    tab name="terminal" {
+        pane
+    }
+    tab name="claude" focus=true {
+        pane split_direction="vertical" {
+            pane command="sh" {
+                args "-c" "claude --continue"
+            }
+            pane split_direction="horizontal" size="30%" {
+                pane command="sh" {
+                    args "-c" "npm run dev; exec \"${SHELL:-sh}\""
+                }
+                pane size=10
+            }
+        }
+    }
+}
+`
+	if got := zellijLayout(layout, "/tmp/wt", "claude --continue"); got != want {
+		t.Errorf("Unexpected layout:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestRepoSessionPrefix tests that listing matches the names sessions are created with
+func TestRepoSessionPrefix(t *testing.T) {
+	z := &ZellijManager{}
+	name := z.SanitizeName("my.repo", "feature/login")
+	prefix := repoSessionPrefix("my.repo")
+	if prefix != "jean-my-repo-" || !strings.HasPrefix(name, prefix) {
+		t.Errorf("Expected %s to start with %s", name, prefix)
+	}
+	if other := z.SanitizeName("my.repository", "main"); strings.HasPrefix(other, prefix) {
+		t.Errorf("Expected %s not to match %s", other, prefix)
+	}
+}
+
+// TestZellijList tests that a repository's sessions are listed with their branch, without the jean-<repo>- prefix
+func TestZellijList(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\nprintf 'jean-app-feature-login [Created 1m ago] (current)\\njean-app-old [Created 2h ago] (EXITED - attach to resurrect)\\njean-other-main [Created 5m ago]\\n'\n"
+	if err := os.WriteFile(filepath.Join(bin, "zellij"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	sessions, err := NewZellijManager().List("/src/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].Name != "jean-app-feature-login" || sessions[0].Branch != "feature-login" || !sessions[0].Active {
+		t.Errorf("Unexpected sessions %+v", sessions)
+	}
+}
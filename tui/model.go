@@ -1,11 +1,14 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -13,7 +16,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/coollabsio/jean-tui/config"
 	"github.com/coollabsio/jean-tui/git"
-	"github.com/coollabsio/jean-tui/github"
+	"github.com/coollabsio/jean-tui/forge"
 	"github.com/coollabsio/jean-tui/internal/version"
 	"github.com/coollabsio/jean-tui/openrouter"
 	"github.com/coollabsio/jean-tui/session"
@@ -27,7 +30,9 @@ type SwitchInfo struct {
 	TargetWindow         string // Which window to attach to: "terminal" or "claude"
 	ScriptCommand        string // If set, run this script command instead of shell/Claude
 	SessionName          string // Custom name for Claude session (for --session flag)
-	IsClaudeInitialized  bool   // Whether this Claude session has been initialized before
+	IsAgentInitialized   bool   // Whether the worktree's agent has been started before
+	AgentCommand         string // Shell command starting the agent in the claude window, "" = plain shell
+	Multiplexer          string // "tmux" or "zellij", tells the shell wrapper how to attach
 }
 
 type modalType int
@@ -58,6 +63,19 @@ const (
 	prStateSettingsModal
 	onboardingModal
 	gitInitModal
+	cleanupModal
+	prDescriptionSyncModal
+	remotesModal
+	promptComposerModal
+	agentSelectModal
+	restoreSessionsModal
+	transcriptsModal
+	worktreeLocationModal
+	branchNamingModal
+	includeModal
+	diskUsageModal
+	worktreeMaintenanceModal
+	detachedWorktreeModal
 )
 
 // NotificationType defines the type of notification
@@ -82,9 +100,9 @@ type Notification struct {
 // Model represents the TUI state
 type Model struct {
 	gitManager     *git.Manager
-	sessionManager *session.Manager
+	sessionManager session.Multiplexer
+	tmuxManager    *session.Manager // tmux config installation, whichever multiplexer hosts the sessions
 	configManager  *config.Manager
-	githubManager  *github.Manager
 	worktrees      []git.Worktree
 	branches       []string
 	sessions       []session.Session
@@ -115,6 +133,18 @@ type Model struct {
 	// Activity tracking
 	lastActivityCheck     time.Time
 	activityCheckInterval time.Duration
+	lastScrollbackCapture time.Time // Last time session scrollback was captured (when enabled)
+
+	// File watching: statuses refresh as worktrees change, worktrees that can't be watched are polled
+	watcher            *git.Watcher    // nil where file watching isn't supported
+	unwatchedPaths     map[string]bool // Worktrees polled because the watch limit was reached
+	watchLimitNotified bool            // Whether the watch limit warning was shown
+
+	// Background auto-fetch
+	autoFetchGen      int  // Bumped when the interval changes, so the previous loop's tick is dropped
+	autoFetchFailures int  // Consecutive failed fetches, each doubling the wait before the next one
+	offline           bool // The remote couldn't be reached on the last fetch
+	refreshing        bool // A refresh with pull ('r') is running, auto-fetch waits for it
 
 	// Modal state
 	modal                  modalType
@@ -126,9 +156,18 @@ type Model struct {
 	commitSubjectInput     textinput.Model // Subject line for commit message
 	prTitleInput           textinput.Model // PR title input
 	prDescriptionInput     textinput.Model // PR description input
-	prModalFocused         int             // Which field in PR modal is focused (0=title, 1=description, 2=create, 3=cancel)
+	prGeneratedDescription string          // Description AI generated into the input, to tell it from one typed by hand
+	prModalFocused         int             // Which field in PR modal is focused (see prField* constants)
 	prModalWorktreePath    string          // Worktree path for PR being created
 	prModalBranch          string          // Branch for PR being created
+	prReviewersInput       textinput.Model // Comma-separated reviewers ("org/team" for teams)
+	prLabelsInput          textinput.Model // Comma-separated labels
+	prAssigneesInput       textinput.Model // Comma-separated assignees
+	prMilestoneInput       textinput.Model // Milestone title
+	prSuggestedReviewers   []string        // Reviewers suggested from CODEOWNERS for the changed files
+	prTemplates            []git.PRTemplate // PR templates found in the worktree
+	prTemplateIndex        int              // Selected PR template for AI generation
+	prMetadata             *forge.PRMetadata // Metadata confirmed in the PR content modal (nil = use repo defaults)
 	branchIndex            int
 	filteredBranches       []string // Filtered list of branches for search
 	createNewBranch        bool
@@ -200,13 +239,13 @@ type Model struct {
 	prListCreationMode bool // Whether PR list modal is in worktree creation mode (user pressed N)
 	prListViewMode bool // Whether PR list modal is in view mode (user pressed v)
 	prFetchingForCreation bool // Whether we're fetching before PR creation
-	pendingPRInfo *github.PRInfo // Temporarily store PR info when creating worktree from PR
+	pendingPRInfo *forge.PRInfo // Temporarily store PR info when creating worktree from PR
 
 	// Merge strategy modal state
-	mergeStrategyCursor int // Selected merge strategy (0=squash, 1=merge, 2=rebase)
+	mergeStrategyCursor int // Selected entry in mergeOptions (immediate merge, auto-merge, or merge queue)
 	selectedPRForMerge string // PR URL to merge
-	prs            []github.PRInfo      // All PRs from GitHub
-	filteredPRs    []github.PRInfo      // Filtered PRs based on search
+	prs            []forge.PRInfo      // All PRs from the forge
+	filteredPRs    []forge.PRInfo      // Filtered PRs based on search
 	prSearchInput  textinput.Model      // Search input for PR filtering
 	prLoadingError string               // Error message when loading PRs
 
@@ -219,6 +258,77 @@ type Model struct {
 	localMergeFocused    int    // Which button is focused (0=confirm, 1=cancel)
 	postMergeDeleteIndex int    // Selected option in post-merge cleanup (0=delete, 1=keep)
 
+	// Remotes modal state (fork-based workflow)
+	pushRemoteInput      textinput.Model // Remote branches are pushed to
+	upstreamRemoteInput  textinput.Model // Remote PRs are opened against
+	remotesFocused       int             // 0 = push remote, 1 = upstream remote
+	remotesConfirmFork   bool            // Whether we're asking to create the missing fork remote
+
+	// Worktree location modal state
+	worktreePathInput     textinput.Model     // Path template for new worktrees of the repository
+	worktreeMigrations    []worktreeMigration // Workspaces to move to the new location, when confirming
+
+	// Branch naming modal state
+	branchTemplateInput   textinput.Model     // Template for new branch names of the repository
+	branchPatternInput    textinput.Model     // Regex branch names must match
+	branchNamingFocused   int                 // 0 = template, 1 = pattern
+
+	// Include modal state (previewing and resyncing jean.json includes of a worktree)
+	includeBranch  string              // Branch of the worktree being resynced
+	includeActions []git.IncludeAction // Files and directories the include rules put into the worktree
+
+	// Disk usage modal state
+	diskUsage      []worktreeDiskUsage // Disk usage of each worktree, largest first
+	diskUsageIndex int                 // Selected row
+
+	// Worktree maintenance modal state (lock, unlock, repair, prune)
+	maintenanceWorktree git.Worktree    // Worktree the actions apply to
+	maintenanceIndex    int             // Selected action
+	maintenanceAction   string          // Action waiting for input ("lock" reason, "repair" path, "promote" branch), "" = choosing
+	maintenanceInput    textinput.Model // Lock reason, repaired path, or branch to promote to
+
+	detachedRefInput textinput.Model // Tag, commit, or PR to create a detached worktree at
+
+	// PR description sync modal state (confirming an updated description after a push)
+	prSyncWorktreePath string // Worktree path of the PR's branch
+	prSyncBranch       string // Branch of the PR being synced
+	prSyncURL          string // URL of the PR being synced
+	prSyncOldBody      string // Current PR description
+	prSyncNewBody      string // Proposed PR description
+	prSyncCommit       string // HEAD commit the proposed description covers
+	prSyncScroll       int    // First diff line shown
+
+	// Prompt composer modal state (sending prompts to Claude sessions without attaching)
+	promptInput        textarea.Model  // Prompt being composed
+	promptBranch       string          // Branch the composer was opened for (history source)
+	promptBroadcast    bool            // Whether the prompt goes to every selected target instead of promptBranch only
+	promptTargets      []promptTarget  // Worktrees with a running Claude session
+	promptTargetCursor int             // Selected target in broadcast mode
+	promptFocus        int             // 0 = prompt, 1 = target list
+	promptHistory      []string        // Prompts previously sent to promptBranch, most recent first
+	promptHistoryIndex int             // Entry shown from promptHistory, -1 = the draft
+	promptDraft        string          // Unsent text kept while browsing history
+
+	// Agent select modal state
+	agentSelectBranch string   // Worktree branch the agent is chosen for, "" = repository default
+	agentOptions      []string // Agent profile names ("" = use the repository default, worktree scope only)
+	agentIndex        int      // Selected option
+
+	// Transcripts modal state
+	transcriptSearch  textinput.Model   // Filters transcripts and shows their matching lines
+	transcripts       []transcriptEntry // Captured transcripts of the repository, most recent first
+	transcriptIndex   int               // Selected transcript among the filtered ones
+
+	// Restore sessions modal state
+	restoreChecked  bool                 // Whether missing sessions were looked for at startup
+	restoreSessions []restorableSession  // Recorded sessions that are no longer running
+
+	// Cleanup modal state
+	cleanupCandidates []cleanupCandidate // Worktrees suggested for removal
+	cleanupCursor     int                // Selected candidate
+	cleanupConfirming bool               // Whether the deletion summary is shown
+	cleanupSuggested  map[string]bool    // Branches already suggested for cleanup (notify only once per branch)
+
 	// PR state settings modal state
 	prStateSettingsCursor int // Selected PR state (0=draft, 1=ready for review)
 	prIsDraft    bool // Whether to create PR as draft (based on config setting)
@@ -283,6 +393,51 @@ func NewModel(repoPath string, autoClaude bool) Model {
 	prDescriptionInput.CharLimit = 500
 	prDescriptionInput.Width = 70
 
+	pushRemoteInput := textinput.New()
+	pushRemoteInput.Placeholder = "origin (e.g., fork)"
+	pushRemoteInput.CharLimit = 100
+	pushRemoteInput.Width = 50
+
+	upstreamRemoteInput := textinput.New()
+	upstreamRemoteInput.Placeholder = "origin (e.g., upstream)"
+	upstreamRemoteInput.CharLimit = 100
+	upstreamRemoteInput.Width = 50
+
+	worktreePathInput := textinput.New()
+	worktreePathInput.Placeholder = git.DefaultPathTemplate + " (e.g., ~/worktrees/{repo}/{branch})"
+	worktreePathInput.CharLimit = 256
+	worktreePathInput.Width = 60
+
+	branchTemplateInput := textinput.New()
+	branchTemplateInput.Placeholder = "e.g., {user}/{type}/{ticket}-{slug}"
+	branchTemplateInput.CharLimit = 256
+	branchTemplateInput.Width = 60
+
+	branchPatternInput := textinput.New()
+	branchPatternInput.Placeholder = "e.g., ^[a-z0-9-]+/(feat|fix|chore)/[A-Z]+-[0-9]+-.+$"
+	branchPatternInput.CharLimit = 256
+	branchPatternInput.Width = 60
+
+	prReviewersInput := textinput.New()
+	prReviewersInput.Placeholder = "user, org/team"
+	prReviewersInput.CharLimit = 256
+	prReviewersInput.Width = 70
+
+	prLabelsInput := textinput.New()
+	prLabelsInput.Placeholder = "bug, enhancement"
+	prLabelsInput.CharLimit = 256
+	prLabelsInput.Width = 70
+
+	prAssigneesInput := textinput.New()
+	prAssigneesInput.Placeholder = "user"
+	prAssigneesInput.CharLimit = 256
+	prAssigneesInput.Width = 70
+
+	prMilestoneInput := textinput.New()
+	prMilestoneInput.Placeholder = "Milestone title"
+	prMilestoneInput.CharLimit = 100
+	prMilestoneInput.Width = 70
+
 	aiAPIKeyInput := textinput.New()
 	aiAPIKeyInput.Placeholder = "sk-or-..."
 	aiAPIKeyInput.CharLimit = 256
@@ -308,11 +463,32 @@ func NewModel(repoPath string, autoClaude bool) Model {
 	aiPromptBranchInput.SetHeight(5)
 
 	aiPromptPRInput := textarea.New()
-	aiPromptPRInput.Placeholder = "PR content prompt (must contain {diff})"
+	aiPromptPRInput.Placeholder = "PR content prompt (must contain {diff}, optional {template})"
 	aiPromptPRInput.CharLimit = 2000
 	aiPromptPRInput.SetWidth(100)
 	aiPromptPRInput.SetHeight(5)
 
+	promptInput := textarea.New()
+	promptInput.Placeholder = "Instructions for Claude..."
+	promptInput.CharLimit = 10000
+	promptInput.SetWidth(100)
+	promptInput.SetHeight(8)
+	promptInput.ShowLineNumbers = false
+
+	maintenanceInput := textinput.New()
+	maintenanceInput.CharLimit = 500
+	maintenanceInput.Width = 60
+
+	detachedRefInput := textinput.New()
+	detachedRefInput.Placeholder = "v1.2.0, a1b2c3d, or #123"
+	detachedRefInput.CharLimit = 200
+	detachedRefInput.Width = 50
+
+	transcriptSearch := textinput.New()
+	transcriptSearch.Placeholder = "Search transcripts..."
+	transcriptSearch.CharLimit = 100
+	transcriptSearch.Width = 50
+
 	// Initialize config manager (ignore errors, will use defaults)
 	configManager, _ := config.NewManager()
 
@@ -322,6 +498,16 @@ func NewModel(repoPath string, autoClaude bool) Model {
 	if root, err := gitManager.GetRepoRoot(); err == nil {
 		absoluteRepoPath = root
 	}
+	if configManager != nil {
+		// Push to a fork and compare against the upstream, and place new worktrees, as configured
+		gitManager.SetRemotes(configManager.GetRemotes(absoluteRepoPath))
+		gitManager.SetPathTemplate(configManager.GetWorktreePathTemplate(absoluteRepoPath))
+		// An invalid template or pattern is reported when it's edited in settings
+		_ = gitManager.SetBranchNaming(configManager.GetBranchNaming(absoluteRepoPath))
+		gitManager.SetDependencySeeding(configManager.GetDependencySeeding(absoluteRepoPath))
+		// Bound how many worktrees compute their status or pull at once, for repos with many of them
+		gitManager.SetConcurrency(configManager.GetConcurrency())
+	}
 
 	// List of common editors
 	editors := []string{
@@ -350,11 +536,20 @@ func NewModel(repoPath string, autoClaude bool) Model {
 		"meta-llama/llama-2-70b-chat",
 	}
 
+	multiplexer := ""
+	if configManager != nil {
+		multiplexer = configManager.GetMultiplexer()
+	}
+
+	// Without file watching, statuses are polled instead (see statusPollTickMsg)
+	watcher, _ := git.NewWatcher(git.DefaultWatchDebounce)
+
 	m := Model{
 		gitManager:         gitManager,
-		sessionManager:     session.NewManager(),
+		watcher:            watcher,
+		sessionManager:     session.NewMultiplexer(multiplexer),
+		tmuxManager:        session.NewManager(),
 		configManager:      configManager,
-		githubManager:      github.NewManager(),
 		nameInput:          nameInput,
 		pathInput:          pathInput,
 		searchInput:        searchInput,
@@ -362,11 +557,24 @@ func NewModel(repoPath string, autoClaude bool) Model {
 		commitSubjectInput: commitSubjectInput,
 		prTitleInput:       prTitleInput,
 		prDescriptionInput: prDescriptionInput,
+		prReviewersInput:   prReviewersInput,
+		prLabelsInput:      prLabelsInput,
+		prAssigneesInput:   prAssigneesInput,
+		prMilestoneInput:   prMilestoneInput,
+		pushRemoteInput:    pushRemoteInput,
+		worktreePathInput:  worktreePathInput,
+		branchTemplateInput: branchTemplateInput,
+		branchPatternInput: branchPatternInput,
+		upstreamRemoteInput: upstreamRemoteInput,
 		aiAPIKeyInput:      aiAPIKeyInput,
 		prSearchInput:      prSearchInput,
 		aiPromptCommitInput: aiPromptCommitInput,
 		aiPromptBranchInput: aiPromptBranchInput,
 		aiPromptPRInput:     aiPromptPRInput,
+		promptInput:         promptInput,
+		transcriptSearch:    transcriptSearch,
+		maintenanceInput:    maintenanceInput,
+		detachedRefInput:    detachedRefInput,
 		aiModels:           aiModels,
 		autoClaude:         autoClaude,
 		repoPath:           absoluteRepoPath,
@@ -430,6 +638,10 @@ func (m Model) Init() tea.Cmd {
 		m.loadBaseBranch(),
 		m.loadSessions(),
 		m.scheduleActivityCheck(),
+		m.scheduleAutoMergeCheck(),
+		m.waitForWorktreeChanges(),
+		m.scheduleStatusPoll(),
+		m.scheduleAutoFetch(),
 		m.checkForUpdates(),
 		tea.EnterAltScreen,
 	)
@@ -452,7 +664,7 @@ type (
 	}
 
 	prsLoadedMsg struct {
-		prs []github.PRInfo
+		prs []forge.PRInfo
 		err error
 	}
 
@@ -484,6 +696,7 @@ type (
 
 	worktreeStatusUpdatedMsg struct {
 		index    int  // Index of worktree in list
+		path     string // Worktree path, the list may have been reloaded since the status was requested
 		hasUncommitted bool
 		aheadCount int
 		behindCount int
@@ -526,6 +739,8 @@ type (
 		prTitle      string // PR title for storing in config
 		author       string // PR author for storing in config
 		isDraft      bool   // Whether the PR is a draft
+		metadataErr  error  // Set if reviewers, labels, assignees, or milestone could not be applied
+		headCommit   string // Commit the description was generated for (for description sync)
 	}
 
 	branchPulledMsg struct {
@@ -545,6 +760,7 @@ type (
 	localMergeCompletedMsg struct {
 		branch       string // Branch that was merged
 		worktreePath string // Worktree path
+		checkout     string // Checkout the base branch was merged in, "" for a temporary one
 		err          error
 		hadConflict  bool   // Whether there was a merge conflict
 	}
@@ -676,8 +892,9 @@ type (
 	}
 
 	pushCompletedMsg struct {
-		branch string
-		err    error
+		branch       string
+		worktreePath string
+		err          error
 	}
 
 	worktreeEnsuredMsg struct {
@@ -689,11 +906,12 @@ type (
 // Commands
 func (m Model) loadWorktrees() tea.Cmd {
 	return func() tea.Msg {
-		worktrees, err := m.gitManager.List(m.baseBranch)
+		// Statuses are loaded afterwards, row by row (see loadWorktreeStatus)
+		worktrees, err := m.gitManager.ListLightweight()
 		// Calculate sanitized Claude session names for each worktree
-		repoName := filepath.Base(m.repoPath)
+		repoName := git.RepoName(m.repoPath)
 		for i := range worktrees {
-			worktrees[i].ClaudeSessionName = m.sessionManager.SanitizeName(repoName, worktrees[i].Branch)
+			worktrees[i].ClaudeSessionName = m.sessionManager.SanitizeName(repoName, worktreeSessionBranch(worktrees[i]))
 		}
 		return worktreesLoadedMsg{worktrees: worktrees, err: err}
 	}
@@ -703,39 +921,32 @@ func (m Model) loadWorktreesLightweight() tea.Cmd {
 	return func() tea.Msg {
 		worktrees, err := m.gitManager.ListLightweight()
 		// Calculate sanitized Claude session names for each worktree
-		repoName := filepath.Base(m.repoPath)
+		repoName := git.RepoName(m.repoPath)
 		for i := range worktrees {
-			worktrees[i].ClaudeSessionName = m.sessionManager.SanitizeName(repoName, worktrees[i].Branch)
+			worktrees[i].ClaudeSessionName = m.sessionManager.SanitizeName(repoName, worktreeSessionBranch(worktrees[i]))
 		}
 		return worktreesLoadedMsg{worktrees: worktrees, err: err}
 	}
 }
 
 // loadWorktreeStatus loads status (uncommitted changes, ahead/behind counts) for a single worktree
-// This is called asynchronously after the initial lightweight load
+// This is called asynchronously after the initial lightweight load; the git manager's worker pool
+// bounds how many worktrees load at once
 func (m Model) loadWorktreeStatus(index int, worktree git.Worktree) tea.Cmd {
 	return func() tea.Msg {
-		// Check for uncommitted changes
-		hasUncommitted := false
-		if uncommitted, err := m.gitManager.HasUncommittedChanges(worktree.Path); err == nil {
-			hasUncommitted = uncommitted
-		}
-
-		// Check branch status (ahead/behind counts)
-		aheadCount := 0
-		behindCount := 0
-		if m.baseBranch != "" && !strings.HasPrefix(worktree.Branch, "(detached") {
-			if ahead, behind, err := m.gitManager.GetBranchStatus(worktree.Path, worktree.Branch, m.baseBranch); err == nil {
-				aheadCount = ahead
-				behindCount = behind
-			}
-		}
+		var status git.WorktreeStatus
+		queued := time.Now()
+		m.gitManager.Pool().Do(func() {
+			status = m.gitManager.GetWorktreeStatus(worktree, m.baseBranch)
+		})
+		m.debugLog(fmt.Sprintf("loadWorktreeStatus: %s took %s (%s waiting for a worker)", worktree.Branch, status.Duration.Round(time.Millisecond), (time.Since(queued) - status.Duration).Round(time.Millisecond)))
 
 		return worktreeStatusUpdatedMsg{
 			index:          index,
-			hasUncommitted: hasUncommitted,
-			aheadCount:     aheadCount,
-			behindCount:    behindCount,
+			path:           worktree.Path,
+			hasUncommitted: status.HasUncommitted,
+			aheadCount:     status.AheadCount,
+			behindCount:    status.BehindCount,
 			err:            nil,
 		}
 	}
@@ -748,8 +959,13 @@ func (m Model) loadBranches() tea.Msg {
 
 func (m Model) loadPRs() tea.Cmd {
 	return func() tea.Msg {
-		m.debugLog("loadPRs() called - fetching PRs from GitHub for repo: " + m.repoPath)
-		prs, err := m.githubManager.ListPRs(m.repoPath)
+		m.debugLog("loadPRs() called - fetching PRs from forge for repo: " + m.repoPath)
+		f, err := m.repoForge()
+		if err != nil {
+			m.debugLog("loadPRs() failed to resolve forge: " + err.Error())
+			return prsLoadedMsg{err: err}
+		}
+		prs, err := f.ListPRs(m.repoPath)
 		if err != nil {
 			m.debugLog("loadPRs() failed with error: " + err.Error())
 		} else {
@@ -762,12 +978,17 @@ func (m Model) loadPRs() tea.Cmd {
 	}
 }
 
-// loadPRDetailsForBranch fetches PR details for a specific branch from GitHub
+// loadPRDetailsForBranch fetches PR details for a specific branch from the forge
 func (m Model) loadPRDetailsForBranch(worktreePath, branch string) tea.Cmd {
 	return func() tea.Msg {
 		m.debugLog(fmt.Sprintf("loadPRDetailsForBranch() called for branch: %s, worktree: %s", branch, worktreePath))
 
-		prInfo, err := m.githubManager.GetPRForBranch(worktreePath, branch)
+		f, err := m.repoForge()
+		if err != nil {
+			return prDetailsLoadedForBranchMsg{branch: branch, prURL: "", err: err}
+		}
+
+		prInfo, err := f.GetPRForBranch(worktreePath, m.prHead(branch))
 		if err != nil {
 			m.debugLog(fmt.Sprintf("loadPRDetailsForBranch() failed with error: %s", err.Error()))
 			return prDetailsLoadedForBranchMsg{branch: branch, prURL: "", err: err}
@@ -828,25 +1049,42 @@ func (m Model) createWorktreeWithSession(path, sessionName string, newBranch boo
 
 func (m Model) deleteWorktree(path, branch string, force bool) tea.Cmd {
 	return func() tea.Msg {
-		// First remove the worktree
-		err := m.gitManager.Remove(path, force)
-		if err != nil {
-			return worktreeDeletedMsg{err: err}
-		}
+		return worktreeDeletedMsg{err: m.removeWorktree(path, branch, force)}
+	}
+}
 
-		// Clean up branch-specific config data (PRs, Claude initialization, etc.)
-		// This prevents config file bloat and removes stale references
-		if m.configManager != nil {
-			_ = m.configManager.CleanupBranch(m.repoPath, branch) // Ignore error, not critical
-		}
+// removeWorktree removes a worktree with its local branch, config entries, and tmux session
+func (m Model) removeWorktree(path, branch string, force bool) error {
+	// First remove the worktree (this also deletes the local branch)
+	if err := m.gitManager.Remove(path, force); err != nil {
+		return err
+	}
+
+	// Clean up branch-specific config data (PRs, Claude initialization, etc.)
+	// This prevents config file bloat and removes stale references
+	if m.configManager != nil {
+		_ = m.configManager.CleanupBranch(m.repoPath, branch) // Ignore error, not critical
+	}
+
+	// Then keep the agent's transcript and kill the associated tmux session if it exists
+	_ = m.captureTranscript(m.worktreeSessionName(branch), branch, "delete") // Ignore error if there is no agent window
+	_ = m.sessionManager.Kill(m.worktreeSessionName(branch))                 // Ignore error if session doesn't exist
 
-		// Then kill the associated tmux session if it exists
-		repoName := filepath.Base(m.repoPath)
-		sessionName := m.sessionManager.SanitizeName(repoName, branch)
-		_ = m.sessionManager.Kill(sessionName) // Ignore error if session doesn't exist
+	return nil
+}
 
-		return worktreeDeletedMsg{err: nil}
+// worktreeSessionBranch returns what the tmux session of a worktree is named after: its branch, or for a detached
+// worktree its directory, as the "(detached at …)" label changes with every checkout (e.g. while bisecting)
+func worktreeSessionBranch(wt git.Worktree) string {
+	if wt.Detached {
+		return filepath.Base(wt.Path)
 	}
+	return wt.Branch
+}
+
+// worktreeSessionName returns the tmux session name used for a branch
+func (m Model) worktreeSessionName(branch string) string {
+	return m.sessionManager.SanitizeName(git.RepoName(m.repoPath), branch)
 }
 
 func (m Model) createWorktreeFromPR(branch string) tea.Cmd {
@@ -919,7 +1157,7 @@ func (m Model) renameBranch(oldName, newName, worktreePath string) tea.Cmd {
 func (m Model) renameSessionsForBranch(oldBranch, newBranch string) tea.Cmd {
 	return func() tea.Msg {
 		// Sanitize both branch names for session names (including repo basename)
-		repoName := filepath.Base(m.repoPath)
+		repoName := git.RepoName(m.repoPath)
 		oldSessionName := m.sessionManager.SanitizeName(repoName, oldBranch)
 		newSessionName := m.sessionManager.SanitizeName(repoName, newBranch)
 
@@ -993,16 +1231,15 @@ func (m Model) openInEditor(path string) tea.Cmd {
 	}
 }
 
-func (m Model) createPR(worktreePath, branch string, optionalTitle string, optionalDescription string) tea.Cmd {
+// createPR pushes the branch and creates a PR; generated marks an AI-generated description, which is wrapped
+// so later syncs replace only it (a description typed by hand is used as is)
+func (m Model) createPR(worktreePath, branch string, optionalTitle string, optionalDescription string, generated bool) tea.Cmd {
 	return func() tea.Msg {
-		// Check if it's a GitHub repo
-		isGitHub, err := m.gitManager.IsGitHubRepo()
+		// Resolve the forge hosting the repository
+		f, err := m.repoForge()
 		if err != nil {
 			return prCreatedMsg{err: fmt.Errorf("failed to check repository: %w", err), isDraft: m.prIsDraft}
 		}
-		if !isGitHub {
-			return prCreatedMsg{err: fmt.Errorf("not a GitHub repository"), isDraft: m.prIsDraft}
-		}
 
 		// Check if base branch is set
 		if m.baseBranch == "" {
@@ -1054,10 +1291,14 @@ func (m Model) createPR(worktreePath, branch string, optionalTitle string, optio
 		}
 
 		// Use provided description or default to empty
+		// Mark a generated one so later syncs only replace this part
 		description := optionalDescription
+		if generated {
+			description = forge.WrapGeneratedDescription(description)
+		}
 
 		// Create PR (draft or ready for review based on user selection)
-		prURL, err := m.githubManager.CreatePR(worktreePath, branch, m.baseBranch, title, description, m.prIsDraft)
+		prURL, err := f.CreatePR(worktreePath, m.prHead(branch), m.baseBranch, title, description, m.prIsDraft)
 		if err != nil {
 			return prCreatedMsg{err: err, branch: branch, worktreePath: worktreePath}
 		}
@@ -1068,12 +1309,16 @@ func (m Model) createPR(worktreePath, branch string, optionalTitle string, optio
 			author = user
 		}
 
-		return prCreatedMsg{prURL: prURL, branch: branch, worktreePath: worktreePath, prTitle: title, author: author, isDraft: m.prIsDraft}
+		metadataErr := m.applyPRMetadata(f, worktreePath, prURL)
+		headCommit, _ := m.gitManager.GetHeadCommit(worktreePath)
+
+		return prCreatedMsg{prURL: prURL, branch: branch, worktreePath: worktreePath, prTitle: title, author: author, isDraft: m.prIsDraft, metadataErr: metadataErr, headCommit: headCommit}
 	}
 }
 
 // createOrUpdatePR creates a new PR or updates existing one if it already exists
-func (m Model) createOrUpdatePR(worktreePath, branch string, title string, description string) tea.Cmd {
+// Like for createPR, only a generated description is marked as such
+func (m Model) createOrUpdatePR(worktreePath, branch string, title string, description string, generated bool) tea.Cmd {
 	return func() tea.Msg {
 		if branch == "" {
 			return prCreatedMsg{err: fmt.Errorf("branch name is empty"), branch: branch, worktreePath: worktreePath, isDraft: m.prIsDraft}
@@ -1084,8 +1329,13 @@ func (m Model) createOrUpdatePR(worktreePath, branch string, title string, descr
 			return prCreatedMsg{err: fmt.Errorf("base branch not set. Press 'b' to set base branch"), branch: branch, worktreePath: worktreePath, isDraft: m.prIsDraft}
 		}
 
-		// Check if a PR already exists for this branch
-		existingPR, err := m.githubManager.GetPRForBranch(worktreePath, branch)
+		f, err := m.repoForge()
+		if err != nil {
+			return prCreatedMsg{err: fmt.Errorf("failed to check repository: %w", err), branch: branch, worktreePath: worktreePath, isDraft: m.prIsDraft}
+		}
+
+		// Check if a PR already exists for this branch (in a fork workflow, opened from the fork)
+		existingPR, err := f.GetPRForBranch(worktreePath, m.prHead(branch))
 		if err != nil {
 			return prCreatedMsg{err: fmt.Errorf("failed to check for existing PR: %w", err), branch: branch, worktreePath: worktreePath, isDraft: m.prIsDraft}
 		}
@@ -1096,28 +1346,238 @@ func (m Model) createOrUpdatePR(worktreePath, branch string, title string, descr
 			author = user
 		}
 
+		headCommit, _ := m.gitManager.GetHeadCommit(worktreePath)
+
 		// If PR exists, update it instead of creating a new one
 		if existingPR != nil {
-			if err := m.githubManager.UpdatePR(worktreePath, branch, title, description); err != nil {
+			// Only replace the generated part of the description, keeping text added by hand
+			if description != "" && generated {
+				if body, err := f.GetPRBody(worktreePath, existingPR.URL); err == nil {
+					description = forge.ReplaceGeneratedDescription(body, description)
+				} else {
+					description = forge.WrapGeneratedDescription(description)
+				}
+			}
+			if err := f.UpdatePR(worktreePath, existingPR.URL, title, description); err != nil {
 				return prCreatedMsg{err: err, branch: branch, worktreePath: worktreePath, isDraft: m.prIsDraft}
 			}
-			return prCreatedMsg{prURL: existingPR.URL, branch: branch, worktreePath: worktreePath, prTitle: title, author: author, isDraft: m.prIsDraft}
+			metadataErr := m.applyPRMetadata(f, worktreePath, existingPR.URL)
+			return prCreatedMsg{prURL: existingPR.URL, branch: branch, worktreePath: worktreePath, prTitle: title, author: author, isDraft: m.prIsDraft, metadataErr: metadataErr, headCommit: headCommit}
 		}
 
 		// PR doesn't exist, create a new one (draft or ready for review based on user selection)
-		prURL, err := m.githubManager.CreatePR(worktreePath, branch, m.baseBranch, title, description, m.prIsDraft)
+		if generated {
+			description = forge.WrapGeneratedDescription(description)
+		}
+		prURL, err := f.CreatePR(worktreePath, m.prHead(branch), m.baseBranch, title, description, m.prIsDraft)
 		if err != nil {
 			return prCreatedMsg{err: err, branch: branch, worktreePath: worktreePath, isDraft: m.prIsDraft}
 		}
 
-		return prCreatedMsg{prURL: prURL, branch: branch, worktreePath: worktreePath, prTitle: title, author: author, isDraft: m.prIsDraft}
+		metadataErr := m.applyPRMetadata(f, worktreePath, prURL)
+		return prCreatedMsg{prURL: prURL, branch: branch, worktreePath: worktreePath, prTitle: title, author: author, isDraft: m.prIsDraft, metadataErr: metadataErr, headCommit: headCommit}
+	}
+}
+
+// pendingPRMetadata returns the metadata to apply to the PR being created or updated:
+// what was confirmed in the PR content modal, or the repo defaults when the modal was skipped
+func (m Model) pendingPRMetadata() forge.PRMetadata {
+	if m.prMetadata != nil {
+		return *m.prMetadata
+	}
+	if m.configManager == nil {
+		return forge.PRMetadata{}
+	}
+	defaults := m.configManager.GetPRDefaults(m.repoPath)
+	return forge.PRMetadata{
+		Reviewers: defaults.Reviewers,
+		Labels:    defaults.Labels,
+		Assignees: defaults.Assignees,
+		Milestone: defaults.Milestone,
+	}
+}
+
+// applyPRMetadata applies the pending reviewers, labels, assignees, and milestone to a PR
+func (m Model) applyPRMetadata(f forge.Forge, worktreePath, prURL string) error {
+	meta := m.pendingPRMetadata()
+	if meta.IsEmpty() {
+		return nil
+	}
+
+	editor, ok := f.(forge.MetadataEditor)
+	if !ok {
+		return fmt.Errorf("%s does not support setting PR metadata", f.Name())
+	}
+	return editor.SetPRMetadata(worktreePath, prURL, meta)
+}
+
+// resetPRMetadataInputs prefills the PR metadata inputs from the repo defaults
+// and starts loading reviewer suggestions from CODEOWNERS
+func (m *Model) resetPRMetadataInputs() tea.Cmd {
+	defaults := config.PRDefaults{}
+	if m.configManager != nil {
+		defaults = m.configManager.GetPRDefaults(m.repoPath)
+	}
+	m.prReviewersInput.SetValue(strings.Join(defaults.Reviewers, ", "))
+	m.prLabelsInput.SetValue(strings.Join(defaults.Labels, ", "))
+	m.prAssigneesInput.SetValue(strings.Join(defaults.Assignees, ", "))
+	m.prMilestoneInput.SetValue(defaults.Milestone)
+	m.prSuggestedReviewers = nil
+	m.prMetadata = nil
+	return tea.Batch(m.loadReviewerSuggestions(m.prModalWorktreePath), m.loadPRTemplates(m.prModalWorktreePath))
+}
+
+// prMetadataFromInputs builds PR metadata from the PR content modal inputs
+func (m Model) prMetadataFromInputs() forge.PRMetadata {
+	return forge.PRMetadata{
+		Reviewers: splitList(m.prReviewersInput.Value()),
+		Labels:    splitList(m.prLabelsInput.Value()),
+		Assignees: splitList(m.prAssigneesInput.Value()),
+		Milestone: strings.TrimSpace(m.prMilestoneInput.Value()),
+	}
+}
+
+// loadReviewerSuggestions suggests reviewers from CODEOWNERS for the files changed on the branch
+func (m Model) loadReviewerSuggestions(worktreePath string) tea.Cmd {
+	baseBranch := m.baseBranch
+	return func() tea.Msg {
+		if worktreePath == "" || baseBranch == "" {
+			return reviewerSuggestionsLoadedMsg{}
+		}
+		reviewers, err := m.gitManager.SuggestReviewers(worktreePath, baseBranch)
+		if err != nil {
+			m.debugLog("loadReviewerSuggestions: " + err.Error())
+		}
+		return reviewerSuggestionsLoadedMsg{worktreePath: worktreePath, reviewers: reviewers}
+	}
+}
+
+// loadPRTemplates finds the PR templates in a worktree for the PR content modal
+func (m Model) loadPRTemplates(worktreePath string) tea.Cmd {
+	return func() tea.Msg {
+		if worktreePath == "" {
+			return prTemplatesLoadedMsg{}
+		}
+		return prTemplatesLoadedMsg{worktreePath: worktreePath, templates: git.FindPRTemplates(worktreePath)}
+	}
+}
+
+// prTemplateFor returns the PR template to fill in for a worktree, or "" if it has none
+// Uses the template picked in the PR content modal, otherwise the first template found
+func (m Model) prTemplateFor(worktreePath string) string {
+	if worktreePath == m.prModalWorktreePath && m.prTemplateIndex < len(m.prTemplates) {
+		return m.prTemplates[m.prTemplateIndex].Content
+	}
+	if templates := git.FindPRTemplates(worktreePath); len(templates) > 0 {
+		return templates[0].Content
+	}
+	return ""
+}
+
+// splitList splits a comma-separated list, dropping empty and duplicate entries
+func splitList(value string) []string {
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimPrefix(strings.TrimSpace(item), "@")
+		if item != "" && !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// preparePRDescriptionSync builds the updated description of a PR after new commits were pushed
+// mode is "regenerate" (replace the generated region with a fresh AI description) or
+// "append" (add the subjects of the new commits to the generated region)
+func (m Model) preparePRDescriptionSync(worktreePath, branch string, pr config.PRInfo, mode string) tea.Cmd {
+	baseBranch := m.baseBranch
+	return func() tea.Msg {
+		result := prDescriptionSyncPreparedMsg{worktreePath: worktreePath, branch: branch, prURL: pr.URL}
+
+		head, err := m.gitManager.GetHeadCommit(worktreePath)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		result.headCommit = head
+		if head == pr.SyncedCommit {
+			// Nothing new since the last sync
+			return result
+		}
+
+		f, err := m.repoForge()
+		if err != nil {
+			result.err = err
+			return result
+		}
+		body, err := f.GetPRBody(worktreePath, pr.URL)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		result.oldBody = body
+		result.newBody = body
+
+		switch mode {
+		case "regenerate":
+			apiKey := m.configManager.GetOpenRouterAPIKey()
+			if apiKey == "" {
+				result.err = fmt.Errorf("API key not configured")
+				return result
+			}
+			diff, err := m.gitManager.GetDiffFromBase(worktreePath, baseBranch)
+			if err != nil || diff == "" {
+				result.err = fmt.Errorf("no changes to generate PR description from")
+				return result
+			}
+			client := openrouter.NewClient(apiKey, m.configManager.GetOpenRouterModel())
+			_, description, err := client.GeneratePRContentWithTemplate(diff, m.configManager.GetCustomPRPrompt(), m.prTemplateFor(worktreePath))
+			if err != nil {
+				result.err = err
+				return result
+			}
+			description += m.transcriptSummary(client, branch)
+			result.newBody = forge.ReplaceGeneratedDescription(body, description)
+
+		case "append":
+			// Without a recorded sync point, list every commit on the branch
+			from := pr.SyncedCommit
+			if from == "" {
+				from = baseBranch
+			}
+			subjects, err := m.gitManager.GetCommitSubjects(worktreePath, from)
+			if err != nil {
+				result.err = err
+				return result
+			}
+			if len(subjects) > 0 {
+				result.newBody = forge.AppendGeneratedDescription(body, "- "+strings.Join(subjects, "\n- "))
+			}
+		}
+
+		return result
+	}
+}
+
+// applyPRDescriptionSync updates the PR with the confirmed description
+func (m Model) applyPRDescriptionSync() tea.Cmd {
+	worktreePath, branch, prURL := m.prSyncWorktreePath, m.prSyncBranch, m.prSyncURL
+	body, commit := m.prSyncNewBody, m.prSyncCommit
+	return func() tea.Msg {
+		f, err := m.repoForge()
+		if err == nil {
+			err = f.UpdatePR(worktreePath, prURL, "", body)
+		}
+		return prDescriptionSyncedMsg{branch: branch, prURL: prURL, headCommit: commit, err: err}
 	}
 }
 
 // createPRRetry creates a PR without re-pushing (for when PR already exists with different title/description)
 func (m Model) createPRRetry(worktreePath, branch string, title string, description string) tea.Cmd {
 	// Use the new createOrUpdatePR instead
-	return m.createOrUpdatePR(worktreePath, branch, title, description)
+	return m.createOrUpdatePR(worktreePath, branch, title, description, true)
 }
 
 // createCommit creates a commit with the given subject and body
@@ -1289,6 +1749,10 @@ func (m Model) generateBranchNameForPR(worktreePath, oldBranch, baseBranch strin
 		client := openrouter.NewClient(apiKey, model)
 		customPrompt := m.configManager.GetBranchNamePrompt()
 		newName, err := client.GenerateBranchName(diff, customPrompt)
+		if err == nil {
+			// Keep the user, type, and ticket of a branch created from the branch name template
+			newName = m.gitManager.ApplyGeneratedName(oldBranch, newName)
+		}
 
 		return prBranchNameGeneratedMsg{
 			oldBranchName: oldBranch,
@@ -1327,13 +1791,7 @@ func (m Model) renameBranchForPR(oldName, newName, worktreePath string) tea.Cmd
 		}
 
 		// Step 2: Rename directory if it's a workspace worktree
-		workspacesDir, err := m.gitManager.GetWorkspacesDir()
-		if err == nil && strings.HasPrefix(worktreePath, workspacesDir) {
-			// Sanitize the new branch name for use as directory name
-			sanitizedDirName := git.SanitizeBranchName(newName)
-			sanitizedDirName = strings.ReplaceAll(sanitizedDirName, "/", "-")
-			newPath := filepath.Join(workspacesDir, sanitizedDirName)
-
+		if newPath, ok := m.gitManager.RenamedWorktreePath(worktreePath, newName); ok {
 			// Move the worktree directory (non-critical if it fails)
 			_ = m.gitManager.MoveWorktree(worktreePath, newPath)
 		}
@@ -1381,8 +1839,12 @@ func (m Model) generatePRContent(worktreePath, branchName, baseBranch string) te
 		// Call AI to generate title and description
 		model := m.configManager.GetOpenRouterModel()
 		client := openrouter.NewClient(apiKey, model)
-		customPrompt := m.configManager.GetPRPrompt()
-		title, description, err := client.GeneratePRContent(diff, customPrompt)
+		customPrompt := m.configManager.GetCustomPRPrompt()
+		// Fill in the repository's PR template if it has one
+		title, description, err := client.GeneratePRContentWithTemplate(diff, customPrompt, m.prTemplateFor(worktreePath))
+		if err == nil {
+			description += m.transcriptSummary(client, branchName)
+		}
 
 		return prContentGeneratedMsg{
 			title:        title,
@@ -1394,6 +1856,197 @@ func (m Model) generatePRContent(worktreePath, branchName, baseBranch string) te
 	}
 }
 
+// transcriptSummary returns a PR description section summarizing the branch's latest agent transcript
+// Returns "" if the option is off, there is no transcript, or summarizing fails
+func (m Model) transcriptSummary(client *openrouter.Client, branch string) string {
+	if !m.configManager.GetPRTranscriptSummary(m.repoPath) {
+		return ""
+	}
+	transcript, err := m.configManager.LatestTranscript(m.repoPath, branch)
+	if err != nil || strings.TrimSpace(transcript) == "" {
+		return ""
+	}
+	summary, err := client.SummarizeTranscript(transcript)
+	if err != nil {
+		return ""
+	}
+	return "\n\n### Agent Session\n\n" + summary
+}
+
+// worktreeMigration is a workspace to move to the location given by a new path template
+type worktreeMigration struct {
+	worktree git.Worktree
+	newPath  string
+}
+
+// plannedWorktreeMigrations returns the workspaces that are not where the path template puts them
+// Worktrees with a running session and protected ones (e.g. the one jean runs in) are skipped, moving them would pull
+// the directory out from under their shells
+func (m Model) plannedWorktreeMigrations(workspaces []git.Worktree) []worktreeMigration {
+	var migrations []worktreeMigration
+	for _, wt := range workspaces {
+		if wt.Branch == "" || wt.Protected || m.sessionManager.SessionExists(wt.ClaudeSessionName) {
+			continue
+		}
+		newPath, err := m.gitManager.GetDefaultPath(wt.Branch)
+		if err != nil || newPath == wt.Path {
+			continue
+		}
+		if _, err := os.Stat(newPath); err == nil {
+			continue // Don't move onto an existing directory
+		}
+		migrations = append(migrations, worktreeMigration{worktree: wt, newPath: newPath})
+	}
+	return migrations
+}
+
+// migrateWorktrees moves workspaces to their new location
+func (m Model) migrateWorktrees(migrations []worktreeMigration) tea.Cmd {
+	return func() tea.Msg {
+		var msg worktreesMigratedMsg
+		for _, migration := range migrations {
+			if err := m.gitManager.MoveWorktree(migration.worktree.Path, migration.newPath); err != nil {
+				msg.failed = append(msg.failed, fmt.Sprintf("%s (%v)", migration.worktree.Branch, err))
+				continue
+			}
+			msg.moved = append(msg.moved, migration)
+		}
+		return msg
+	}
+}
+
+// planIncludes previews what the include rules of jean.json put into a worktree
+func (m Model) planIncludes(wt git.Worktree) tea.Cmd {
+	return func() tea.Msg {
+		actions, err := m.gitManager.PlanIncludes(wt.Path)
+		return includesPlannedMsg{branch: wt.Branch, actions: actions, err: err}
+	}
+}
+
+// syncIncludes copies or links the include files into a worktree again, replacing what's there
+func (m Model) syncIncludes(branch string, actions []git.IncludeAction) tea.Cmd {
+	return func() tea.Msg {
+		return includesSyncedMsg{branch: branch, count: len(actions), err: git.ApplyIncludes(actions, true)}
+	}
+}
+
+// worktreeDiskUsage is the disk space taken by a worktree, for the disk usage modal
+type worktreeDiskUsage struct {
+	worktree git.Worktree
+	usage    git.DiskUsage
+	err      error
+}
+
+// measureDiskUsage measures the disk space taken by each worktree
+func (m Model) measureDiskUsage() tea.Cmd {
+	worktrees := m.worktrees
+	return func() tea.Msg {
+		var paths []string
+		for _, wt := range worktrees {
+			paths = append(paths, wt.Path)
+		}
+
+		var usages []worktreeDiskUsage
+		for _, wt := range worktrees {
+			// Worktrees nested in this one (the root checkout's .workspaces) are measured on their own
+			var nested []string
+			for _, path := range paths {
+				if path != wt.Path && strings.HasPrefix(path, wt.Path+string(filepath.Separator)) {
+					nested = append(nested, path)
+				}
+			}
+			usage, err := m.gitManager.DiskUsage(wt.Path, nested)
+			usages = append(usages, worktreeDiskUsage{worktree: wt, usage: usage, err: err})
+		}
+		sort.Slice(usages, func(i, j int) bool { return usages[i].usage.Size > usages[j].usage.Size })
+		return diskUsageMeasuredMsg{usages: usages}
+	}
+}
+
+// transcriptEntry is a transcript with its content, loaded when the transcripts modal opens
+type transcriptEntry struct {
+	config.Transcript
+	content string
+}
+
+// openTranscripts loads the repository's transcripts and opens the transcripts modal
+func (m *Model) openTranscripts() tea.Cmd {
+	if m.configManager == nil {
+		return nil
+	}
+	transcripts, err := m.configManager.ListTranscripts(m.repoPath)
+	if err != nil {
+		return m.showErrorNotification("Failed to load transcripts: "+err.Error(), 3*time.Second)
+	}
+	if len(transcripts) == 0 {
+		return m.showInfoNotification("No transcripts yet. They are captured when a session is killed, or with c in the sessions view")
+	}
+
+	m.transcripts = nil
+	for _, transcript := range transcripts {
+		data, err := os.ReadFile(transcript.Path)
+		if err != nil {
+			continue
+		}
+		m.transcripts = append(m.transcripts, transcriptEntry{Transcript: transcript, content: string(data)})
+	}
+	m.transcriptIndex = 0
+	m.transcriptSearch.SetValue("")
+	m.transcriptSearch.Focus()
+	m.modal = transcriptsModal
+	return textinput.Blink
+}
+
+// filteredTranscripts returns the transcripts containing the search query (case-insensitive)
+func (m Model) filteredTranscripts() []transcriptEntry {
+	query := strings.ToLower(strings.TrimSpace(m.transcriptSearch.Value()))
+	if query == "" {
+		return m.transcripts
+	}
+	var filtered []transcriptEntry
+	for _, transcript := range m.transcripts {
+		if strings.Contains(strings.ToLower(transcript.content), query) {
+			filtered = append(filtered, transcript)
+		}
+	}
+	return filtered
+}
+
+// transcriptMatches returns up to limit lines of content containing query (case-insensitive),
+// or the last limit lines if query is empty
+func transcriptMatches(content, query string, limit int) []string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		if len(lines) > limit {
+			lines = lines[len(lines)-limit:]
+		}
+		return lines
+	}
+	var matches []string
+	for _, line := range lines {
+		if strings.Contains(strings.ToLower(line), query) {
+			matches = append(matches, strings.TrimSpace(line))
+			if len(matches) == limit {
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// captureTranscript appends the claude window of a session to the branch's transcript
+func (m Model) captureTranscript(sessionName, branch, reason string) error {
+	if m.configManager == nil {
+		return nil
+	}
+	content, err := m.sessionManager.CaptureWindow(sessionName, "claude")
+	if err != nil {
+		return err
+	}
+	return m.configManager.AppendTranscript(m.repoPath, branch, reason, content)
+}
+
 // testOpenRouterAPIKey tests the OpenRouter API key to verify it works
 func (m Model) testOpenRouterAPIKey(apiKey, model string) tea.Cmd {
 	return func() tea.Msg {
@@ -1428,16 +2081,21 @@ func (m Model) refreshPRStatuses() tea.Cmd {
 		worktree := m.worktrees[m.selectedIndex]
 		prs := m.configManager.GetPRs(m.repoPath, worktree.Branch)
 
+		f, err := m.repoForge()
+		if err != nil {
+			return prStatusesRefreshedMsg{err: err}
+		}
+
 		// Update the status of each PR
 		for _, pr := range prs {
-			status, err := m.githubManager.GetPRStatus(pr.URL)
+			status, err := f.GetPRStatus(pr.URL)
 			if err == nil {
 				_ = m.configManager.UpdatePRStatus(m.repoPath, worktree.Branch, pr.URL, status)
 			}
 		}
 
 		// Reload worktrees to get updated PR info
-		worktrees, err := m.gitManager.List(m.baseBranch)
+		worktrees, err := m.gitManager.ListLightweight()
 		if err != nil {
 			return prStatusesRefreshedMsg{err: err}
 		}
@@ -1451,17 +2109,59 @@ func (m Model) refreshPRStatuses() tea.Cmd {
 	}
 }
 
-// loadPRDetailsForAllWorktrees loads PR details from GitHub for all worktrees asynchronously
+// loadPRDetailsForAllWorktrees loads PR details from the forge for all worktrees asynchronously
 // This is used during refresh to discover PRs that may have been created outside jean
 func (m Model) loadPRDetailsForAllWorktrees() tea.Cmd {
 	return func() tea.Msg {
 		m.debugLog("loadPRDetailsForAllWorktrees() called - checking all worktrees for PRs")
 
+		f, err := m.repoForge()
+		if err != nil {
+			m.debugLog("loadPRDetailsForAllWorktrees: failed to resolve forge: " + err.Error())
+			return prStatusesRefreshedMsg{err: nil}
+		}
+
+		// Forges that support batching look up every branch in a single request,
+		// which both discovers new PRs and refreshes the status of known ones
+		if lookup, ok := f.(forge.BranchPRLookup); ok {
+			// Looked up by PR head ("owner:branch" in a fork workflow), saved by branch
+			var heads []string
+			branchByHead := make(map[string]string)
+			for _, wt := range m.worktrees {
+				if !wt.IsCurrent && wt.Branch != "" && !wt.Detached {
+					head := m.prHead(wt.Branch)
+					heads = append(heads, head)
+					branchByHead[head] = wt.Branch
+				}
+			}
+
+			start := time.Now()
+			prsByHead, err := lookup.GetPRsForBranches(m.repoPath, heads)
+			if err == nil {
+				m.debugLog(fmt.Sprintf("loadPRDetailsForAllWorktrees: batched lookup of %d branches found %d PR(s) in %s", len(heads), len(prsByHead), time.Since(start)))
+
+				for head, prInfo := range prsByHead {
+					branch := branchByHead[head]
+					// AddPR is a no-op for PRs already in config, so this only saves new ones
+					if err := m.configManager.AddPR(m.repoPath, branch, prInfo.URL, prInfo.Number, prInfo.Title, prInfo.Author.Login); err != nil {
+						m.debugLog(fmt.Sprintf("loadPRDetailsForAllWorktrees: failed to save PR to config: %s", err.Error()))
+						continue
+					}
+					_ = m.configManager.UpdatePRStatus(m.repoPath, branch, prInfo.URL, strings.ToLower(prInfo.Status))
+				}
+
+				return prStatusesRefreshedMsg{err: nil}
+			}
+			if !errors.Is(err, forge.ErrBatchUnsupported) {
+				m.debugLog("loadPRDetailsForAllWorktrees: batched lookup failed, falling back to per-branch lookups: " + err.Error())
+			}
+		}
+
 		// For each worktree, check if it has any PRs in config
-		// If not, fetch from GitHub to see if a PR exists
+		// If not, fetch from the forge to see if a PR exists
 		for _, wt := range m.worktrees {
-			// Skip root worktree (main repo) - identified by IsCurrent
-			if wt.IsCurrent {
+			// Skip root worktree (main repo) - identified by IsCurrent - and detached worktrees, which have no branch
+			if wt.IsCurrent || wt.Detached {
 				continue
 			}
 
@@ -1472,9 +2172,9 @@ func (m Model) loadPRDetailsForAllWorktrees() tea.Cmd {
 				continue
 			}
 
-			// No PRs in config - fetch from GitHub
-			m.debugLog(fmt.Sprintf("loadPRDetailsForAllWorktrees: checking GitHub for PR on branch %s", wt.Branch))
-			prInfo, err := m.githubManager.GetPRForBranch(wt.Path, wt.Branch)
+			// No PRs in config - fetch from the forge
+			m.debugLog(fmt.Sprintf("loadPRDetailsForAllWorktrees: checking %s for PR on branch %s", f.Name(), wt.Branch))
+			prInfo, err := f.GetPRForBranch(wt.Path, m.prHead(wt.Branch))
 			if err != nil {
 				m.debugLog(fmt.Sprintf("loadPRDetailsForAllWorktrees: error fetching PR for branch %s: %s", wt.Branch, err.Error()))
 				continue
@@ -1543,6 +2243,10 @@ func (m Model) generateBranchNameForPush(worktreePath, oldBranch, baseBranch str
 		client := openrouter.NewClient(apiKey, model)
 		customPrompt := m.configManager.GetBranchNamePrompt()
 		newName, err := client.GenerateBranchName(diff, customPrompt)
+		if err == nil {
+			// Keep the user, type, and ticket of a branch created from the branch name template
+			newName = m.gitManager.ApplyGeneratedName(oldBranch, newName)
+		}
 
 		return pushBranchNameGeneratedMsg{
 			oldBranchName: oldBranch,
@@ -1582,13 +2286,7 @@ func (m Model) renameBranchForPush(oldName, newName, worktreePath string) tea.Cm
 
 		// Step 2: Rename directory if it's a workspace worktree
 		newWorktreePath := worktreePath
-		workspacesDir, err := m.gitManager.GetWorkspacesDir()
-		if err == nil && strings.HasPrefix(worktreePath, workspacesDir) {
-			// Sanitize the new branch name for use as directory name
-			sanitizedDirName := git.SanitizeBranchName(newName)
-			sanitizedDirName = strings.ReplaceAll(sanitizedDirName, "/", "-")
-			newPath := filepath.Join(workspacesDir, sanitizedDirName)
-
+		if newPath, ok := m.gitManager.RenamedWorktreePath(worktreePath, newName); ok {
 			// Move the worktree directory (non-critical if it fails)
 			if moveErr := m.gitManager.MoveWorktree(worktreePath, newPath); moveErr == nil {
 				newWorktreePath = newPath
@@ -1621,8 +2319,25 @@ func (m Model) pushBranch(worktreePath, branch string) tea.Cmd {
 			return pushCompletedMsg{branch: branch, err: fmt.Errorf("failed to push: %w", err)}
 		}
 
-		return pushCompletedMsg{branch: branch, err: nil}
+		return pushCompletedMsg{branch: branch, worktreePath: worktreePath, err: nil}
+	}
+}
+
+// worktreeCreatedWarning returns the warning to show when a worktree was created but getting it ready
+// (include files, setup script) failed; false if creating the worktree itself failed
+func worktreeCreatedWarning(err error) (string, bool) {
+	var prepareErr *git.PrepareError
+	if !errors.As(err, &prepareErr) {
+		return "", false
+	}
+	var problems []string
+	if prepareErr.IncludeErr != nil {
+		problems = append(problems, "Include files failed:\n"+prepareErr.IncludeErr.Error())
 	}
+	if prepareErr.SetupErr != nil {
+		problems = append(problems, "Setup script failed:\n"+prepareErr.SetupErr.Error())
+	}
+	return "Worktree created, but:\n" + strings.Join(problems, "\n"), true
 }
 
 // Helper methods
@@ -1633,6 +2348,14 @@ func (m Model) selectedWorktree() *git.Worktree {
 	return &m.worktrees[m.selectedIndex]
 }
 
+// branchNameFromInput turns a typed name into a branch name, filling the branch name template if there is one
+func (m Model) branchNameFromInput(input string) string {
+	if m.gitManager.BranchTemplate() != "" {
+		return m.gitManager.BranchNameFromInput(input)
+	}
+	return m.sessionManager.SanitizeBranchName(input)
+}
+
 func (m Model) selectedBranch() string {
 	// Use filtered branches if search is active
 	branches := m.branches
@@ -1661,12 +2384,12 @@ func (m Model) filterBranches(query string) []string {
 	return filtered
 }
 
-func (m Model) filterPRs(query string) []github.PRInfo {
+func (m Model) filterPRs(query string) []forge.PRInfo {
 	if query == "" {
 		return m.prs
 	}
 
-	var filtered []github.PRInfo
+	var filtered []forge.PRInfo
 	queryLower := strings.ToLower(query)
 	for _, pr := range m.prs {
 		// Search in PR number, title, author, and head branch name
@@ -1694,7 +2417,7 @@ func (m Model) GetConfigManager() *config.Manager {
 // loadSessions loads tmux sessions for the current repository only
 func (m Model) loadSessions() tea.Cmd {
 	return func() tea.Msg {
-		sessions, err := m.sessionManager.List(m.repoPath)
+		sessions, err := m.sessionManager.ListWithAgentState(m.repoPath)
 		if err != nil {
 			return statusMsg("Failed to load sessions")
 		}
@@ -1791,37 +2514,17 @@ func (m Model) prepareLocalMerge(worktreePath, branch, baseBranch string) tea.Cm
 
 // executeLocalMerge performs the local merge of worktree branch into base branch
 // This switches to base branch in main repo and merges the worktree branch
+// (in a bare repository, it merges in the base branch's worktree, see git.MergeIntoBaseBranch)
 func (m Model) executeLocalMerge(worktreePath, branch, baseBranch string) tea.Cmd {
 	return func() tea.Msg {
-		// Get repo root
-		repoRoot, err := m.gitManager.GetRepoRoot()
-		if err != nil {
-			return localMergeCompletedMsg{
-				branch:       branch,
-				worktreePath: worktreePath,
-				err:          fmt.Errorf("failed to get repo root: %w", err),
-				hadConflict:  false,
-			}
-		}
-
-		// First: Checkout base branch in main repository
-		if err := m.gitManager.CheckoutBranch(baseBranch); err != nil {
-			return localMergeCompletedMsg{
-				branch:       branch,
-				worktreePath: worktreePath,
-				err:          fmt.Errorf("failed to checkout base branch: %w", err),
-				hadConflict:  false,
-			}
-		}
-
-		// Second: Merge worktree branch into base branch (in main repo)
-		err = m.gitManager.MergeBranch(repoRoot, branch)
+		checkout, err := m.gitManager.MergeIntoBaseBranch(branch, baseBranch)
 		if err != nil {
 			// Check if it's a merge conflict
 			if strings.Contains(err.Error(), "merge conflict") {
 				return localMergeCompletedMsg{
 					branch:       branch,
 					worktreePath: worktreePath,
+					checkout:     checkout,
 					err:          err,
 					hadConflict:  true,
 				}
@@ -1829,6 +2532,7 @@ func (m Model) executeLocalMerge(worktreePath, branch, baseBranch string) tea.Cm
 			return localMergeCompletedMsg{
 				branch:       branch,
 				worktreePath: worktreePath,
+				checkout:     checkout,
 				err:          err,
 				hadConflict:  false,
 			}
@@ -1837,6 +2541,7 @@ func (m Model) executeLocalMerge(worktreePath, branch, baseBranch string) tea.Cm
 		return localMergeCompletedMsg{
 			branch:       branch,
 			worktreePath: worktreePath,
+			checkout:     checkout,
 			err:          nil,
 			hadConflict:  false,
 		}
@@ -1858,16 +2563,21 @@ func (m Model) refreshWithPull() tea.Cmd {
 			return refreshWithPullMsg{err: fmt.Errorf("failed to fetch updates: %w", err)}
 		}
 
-		// Pull all worktrees (both main repo and workspace branches)
-		for _, wt := range m.worktrees {
-			if wt.Branch == "" {
-				continue // Skip if no branch is checked out
+		// Pull all worktrees (both main repo and workspace branches), several at a time
+		worktrees := m.worktrees
+		var mu sync.Mutex
+		m.gitManager.Pool().Run(len(worktrees), func(i int) {
+			wt := worktrees[i]
+			if wt.Branch == "" || wt.Detached {
+				return // Skip if no branch is checked out
 			}
+			start := time.Now()
 
 			// Check if this worktree has uncommitted changes
 			hasUncommitted, _ := m.gitManager.HasUncommittedChanges(wt.Path)
 			if hasUncommitted {
-				continue // Skip pulling if there are uncommitted changes
+				m.debugLog(fmt.Sprintf("refreshWithPull: %s skipped, uncommitted changes", wt.Branch))
+				return // Skip pulling if there are uncommitted changes
 			}
 
 			// Pull this worktree's current branch
@@ -1881,14 +2591,17 @@ func (m Model) refreshWithPull() tea.Cmd {
 				// For workspace branches, use PullBranchInPathWithOutput
 				output, err = m.gitManager.PullBranchInPathWithOutput(wt.Path, wt.Branch)
 			}
+			m.debugLog(fmt.Sprintf("refreshWithPull: %s pulled in %s (err: %v)", wt.Branch, time.Since(start).Round(time.Millisecond), err))
 
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
 				// Pull failed for this worktree, but continue with others
 				// Store the first error if no error was already recorded
 				if msg.pullErr == nil {
 					msg.pullErr = fmt.Errorf("failed to pull %s: %w", wt.Branch, err)
 				}
-				continue
+				return
 			}
 
 			// Parse the output to extract commit count
@@ -1897,7 +2610,7 @@ func (m Model) refreshWithPull() tea.Cmd {
 				msg.updatedBranches[wt.Branch] = commitCount
 				msg.upToDate = false
 			}
-		}
+		})
 
 		// Worktree list will be reloaded by the Update handler
 		// This recalculates ahead/behind counts based on fetched refs
@@ -1930,7 +2643,7 @@ func (m Model) animateRenameSpinner() tea.Cmd {
 // checkSessionActivity checks for recent session activity in current repository
 func (m Model) checkSessionActivity() tea.Cmd {
 	return func() tea.Msg {
-		sessions, err := m.sessionManager.List(m.repoPath)
+		sessions, err := m.sessionManager.ListWithAgentState(m.repoPath)
 		if err != nil {
 			return activityCheckedMsg{sessions: []session.Session{}, err: err}
 		}
@@ -1938,23 +2651,451 @@ func (m Model) checkSessionActivity() tea.Cmd {
 	}
 }
 
-// checkForUpdates checks if a new version of jean is available
-func (m Model) checkForUpdates() tea.Cmd {
-	return func() tea.Msg {
-		current, latest, updateAvailable, err := version.CheckLatestVersionOfCli(false)
-		return versionCheckMsg{
-			currentVersion:  current,
-			latestVersion:   latest,
-			updateAvailable: updateAvailable,
-			err:             err,
+// updateSessions replaces the session list, records new or changed sessions so they can be restored,
+// forgets the ones that ended, and raises a notification for each Claude session that just started
+// waiting for input
+func (m *Model) updateSessions(sessions []session.Session) tea.Cmd {
+	previous := make(map[string]session.AgentState, len(m.sessions))
+	for _, sess := range m.sessions {
+		previous[sess.Name] = sess.AgentState
+	}
+	recordCmd := m.recordSessions(changedSessions(m.sessions, sessions))
+	m.forgetEndedSessions(sessions)
+	m.sessions = sessions
+
+	var waiting []string
+	for _, sess := range sessions {
+		if sess.AgentState == session.AgentStateWaiting && previous[sess.Name] != session.AgentStateWaiting {
+			waiting = append(waiting, m.sessionBranch(sess))
 		}
 	}
+	if len(waiting) == 0 {
+		return recordCmd
+	}
+	return tea.Batch(recordCmd, m.showWarningNotification(fmt.Sprintf("🔔 Claude is waiting for input on %s", strings.Join(waiting, ", "))))
 }
 
-// showNotification displays a notification and optionally schedules it to be hidden after a duration
-func (m *Model) showNotification(message string, notifType NotificationType, autoClearAfter *time.Duration) tea.Cmd {
-	// Generate unique ID for this notification
-	m.notificationID++
+// sessionBranch returns the worktree branch a session belongs to, falling back to the session's own branch name
+func (m Model) sessionBranch(sess session.Session) string {
+	for _, wt := range m.worktrees {
+		if wt.ClaudeSessionName == sess.Name {
+			return wt.Branch
+		}
+	}
+	return sess.Branch
+}
+
+// agentStateFor returns the state of the Claude session of a worktree
+func (m Model) agentStateFor(wt git.Worktree) session.AgentState {
+	// A plain shell has no agent state (it would always look exited)
+	if m.agentProfile(wt.Branch).Command == "" {
+		return session.AgentStateNone
+	}
+	for _, sess := range m.sessions {
+		if sess.Name == wt.ClaudeSessionName {
+			return sess.AgentState
+		}
+	}
+	return session.AgentStateNone
+}
+
+// agentProfile returns the agent profile configured for a worktree branch
+func (m Model) agentProfile(branch string) config.AgentProfile {
+	if m.configManager == nil {
+		return config.AgentPresets[0]
+	}
+	return m.configManager.GetAgentProfile(m.repoPath, branch)
+}
+
+// agentCommand returns the command starting a worktree's agent and whether it resumes a previous run
+func (m Model) agentCommand(path, branch string) (string, bool) {
+	profile := m.agentProfile(branch)
+	initialized := m.configManager != nil && m.configManager.IsAgentInitialized(m.repoPath, branch, profile.Name)
+	return session.AgentCommand(profile, path, initialized), initialized
+}
+
+// openRestoreSessions opens the restore sessions modal for recorded sessions that are no longer running
+func (m *Model) openRestoreSessions(sessions []restorableSession) {
+	m.restoreSessions = sessions
+	m.modal = restoreSessionsModal
+}
+
+// openAgentSelect opens the agent select modal for a worktree branch, or for the repository if branch is empty
+func (m *Model) openAgentSelect(branch string) {
+	m.agentSelectBranch = branch
+	m.agentOptions = nil
+	if branch != "" {
+		m.agentOptions = append(m.agentOptions, "")
+	}
+	current := config.DefaultAgent
+	profiles := config.AgentPresets
+	if m.configManager != nil {
+		profiles = m.configManager.GetAgentProfiles()
+		if branch != "" {
+			current = m.configManager.GetWorktreeAgent(m.repoPath, branch)
+		} else {
+			current = m.configManager.GetAgent(m.repoPath, "")
+		}
+	}
+	for _, profile := range profiles {
+		m.agentOptions = append(m.agentOptions, profile.Name)
+	}
+
+	m.agentIndex = 0
+	for i, name := range m.agentOptions {
+		if name == current {
+			m.agentIndex = i
+			break
+		}
+	}
+	m.modal = agentSelectModal
+}
+
+// loadLayout returns the session layout declared in the repository's jean.json, or nil if there is none
+func (m Model) loadLayout() (*config.Layout, error) {
+	repoRoot, err := m.gitManager.CheckoutRoot()
+	if err != nil {
+		return nil, err
+	}
+	scripts, err := config.LoadScripts(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jean.json: %w", err)
+	}
+	return scripts.Layout, nil
+}
+
+// prepareSession creates the worktree's session from the jean.json layout before switching to it
+// Without a layout, or if the session already exists, the shell wrapper creates and attaches a tmux session as usual;
+// zellij sessions are always created here (with the default layout if jean.json has none)
+func (m Model) prepareSession(info SwitchInfo) tea.Cmd {
+	return func() tea.Msg {
+		info.Multiplexer = m.sessionManager.Name()
+		if m.sessionManager.SessionExists(info.SessionName) {
+			return sessionPreparedMsg{info: info}
+		}
+		layout, err := m.loadLayout()
+		if err != nil {
+			return sessionPreparedMsg{info: info, err: err}
+		}
+		declared := layout != nil
+		if !declared {
+			if info.Multiplexer == session.MultiplexerTmux {
+				return sessionPreparedMsg{info: info}
+			}
+			layout = &config.DefaultLayout
+		}
+
+		agentCommand := ""
+		if m.autoClaude {
+			agentCommand, _ = m.agentCommand(info.Path, info.Branch)
+		}
+		if err := m.sessionManager.CreateWithLayout(info.SessionName, info.Path, *layout, agentCommand); err != nil {
+			return sessionPreparedMsg{info: info, err: err}
+		}
+
+		// Open the focus window of a declared layout, unless a window the layout has was asked for explicitly
+		if declared && (info.TargetWindow == "claude" || !layout.HasWindow(info.TargetWindow)) {
+			info.TargetWindow = layout.FocusWindow()
+		}
+		return sessionPreparedMsg{info: info, agentStarted: agentCommand != ""}
+	}
+}
+
+// resetSessionLayout recreates a session from the jean.json layout (or the default terminal and agent windows)
+func (m Model) resetSessionLayout(sess session.Session) tea.Cmd {
+	return func() tea.Msg {
+		layout, err := m.loadLayout()
+		if err != nil {
+			return sessionLayoutResetMsg{err: err}
+		}
+		if layout == nil {
+			layout = &config.DefaultLayout
+		}
+
+		// zellij does not report a session's directory, use the worktree's
+		path := sess.Path
+		for _, wt := range m.worktrees {
+			if wt.ClaudeSessionName == sess.Name {
+				path = wt.Path
+			}
+		}
+		if path == "" {
+			return sessionLayoutResetMsg{branch: sess.Branch, err: fmt.Errorf("no worktree found for session %s", sess.Name)}
+		}
+
+		branch := m.sessionBranch(sess)
+		agentCommand := ""
+		if m.autoClaude {
+			agentCommand, _ = m.agentCommand(path, branch)
+		}
+		_ = m.captureTranscript(sess.Name, branch, "reset") // Keep the conversation that is about to be stopped
+		err = m.sessionManager.ResetLayout(sess.Name, path, *layout, agentCommand)
+		return sessionLayoutResetMsg{branch: branch, agentStarted: agentCommand != "", err: err}
+	}
+}
+
+// scrollbackCaptureInterval is how often session scrollback is captured when enabled
+const scrollbackCaptureInterval = 30 * time.Second
+
+// restorableSession is a recorded session that is no longer running
+type restorableSession struct {
+	name   string
+	record config.SessionRecord
+}
+
+// changedSessions returns the sessions that are new or whose window count changed since the previous list
+func changedSessions(previous, current []session.Session) []session.Session {
+	windows := make(map[string]int, len(previous))
+	for _, sess := range previous {
+		windows[sess.Name] = sess.Windows
+	}
+	var changed []session.Session
+	for _, sess := range current {
+		if count, ok := windows[sess.Name]; !ok || count != sess.Windows {
+			changed = append(changed, sess)
+		}
+	}
+	return changed
+}
+
+// recordSessions looks up what is needed to restore running sessions (windows, layout, agent)
+func (m Model) recordSessions(sessions []session.Session) tea.Cmd {
+	if m.configManager == nil || len(sessions) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		layout, _ := m.loadLayout()
+		records := make(map[string]config.SessionRecord)
+		for _, sess := range sessions {
+			// zellij does not report a session's directory, use the worktree's
+			path := sess.Path
+			for _, wt := range m.worktrees {
+				if wt.ClaudeSessionName == sess.Name {
+					path = wt.Path
+				}
+			}
+			windows, err := m.sessionManager.WindowNames(sess.Name)
+			if err != nil || path == "" {
+				continue
+			}
+
+			branch := m.sessionBranch(sess)
+			agent := m.configManager.GetAgent(m.repoPath, branch)
+			record := config.SessionRecord{
+				Branch:           branch,
+				Path:             path,
+				Multiplexer:      m.sessionManager.Name(),
+				Windows:          windows,
+				Agent:            agent,
+				AgentInitialized: m.configManager.IsAgentInitialized(m.repoPath, branch, agent),
+			}
+			// Keep the jean.json layout if the session was created from it
+			if layout != nil && hasAllWindows(windows, *layout) {
+				record.Layout = layout
+			}
+			records[sess.Name] = record
+		}
+		return sessionsRecordedMsg{records: records}
+	}
+}
+
+// forgetEndedSessions drops the records of sessions that were running and are gone from sessions,
+// as the user closed them; only sessions that were already gone when jean started are offered for restoring
+func (m Model) forgetEndedSessions(sessions []session.Session) {
+	if m.configManager == nil {
+		return
+	}
+	running := make(map[string]bool, len(sessions))
+	for _, sess := range sessions {
+		running[sess.Name] = true
+	}
+	for _, sess := range m.sessions {
+		if !running[sess.Name] {
+			_ = m.configManager.RemoveSessionRecord(m.repoPath, sess.Name)
+		}
+	}
+}
+
+// hasAllWindows reports whether every window of a layout is in a list of window names
+func hasAllWindows(windows []string, layout config.Layout) bool {
+	for _, window := range layout.Windows {
+		found := false
+		for _, name := range windows {
+			if name == window.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// findRestorableSessions returns the recorded sessions of the current multiplexer that are no longer running
+// Records of worktrees that no longer exist are forgotten
+func (m Model) findRestorableSessions() []restorableSession {
+	if m.configManager == nil {
+		return nil
+	}
+	running := make(map[string]bool, len(m.sessions))
+	for _, sess := range m.sessions {
+		running[sess.Name] = true
+	}
+
+	var restorable []restorableSession
+	for name, record := range m.configManager.GetSessionRecords(m.repoPath) {
+		if running[name] {
+			continue
+		}
+		if _, err := os.Stat(record.Path); err != nil {
+			_ = m.configManager.RemoveSessionRecord(m.repoPath, name)
+			continue
+		}
+		multiplexer := record.Multiplexer
+		if multiplexer == "" {
+			multiplexer = session.MultiplexerTmux
+		}
+		if multiplexer == m.sessionManager.Name() {
+			restorable = append(restorable, restorableSession{name: name, record: record})
+		}
+	}
+	sort.Slice(restorable, func(i, j int) bool { return restorable[i].name < restorable[j].name })
+	return restorable
+}
+
+// restoreRecordedSessions recreates sessions from their records, resuming their agents
+func (m Model) restoreRecordedSessions(sessions []restorableSession) tea.Cmd {
+	return func() tea.Msg {
+		var msg sessionsRestoredMsg
+		for _, sess := range sessions {
+			record := sess.record
+			if m.sessionManager.SessionExists(sess.name) {
+				continue
+			}
+
+			layout := config.LayoutFromWindows(record.Windows)
+			if record.Layout != nil {
+				layout = *record.Layout
+			}
+
+			agentCommand := ""
+			if m.autoClaude {
+				profile := m.agentProfile(record.Branch)
+				for _, p := range m.configManager.GetAgentProfiles() {
+					if p.Name == record.Agent {
+						profile = p
+						break
+					}
+				}
+				resume := record.AgentInitialized || m.configManager.IsAgentInitialized(m.repoPath, record.Branch, profile.Name)
+				agentCommand = session.AgentCommand(profile, record.Path, resume)
+				record.Agent = profile.Name
+			}
+
+			if err := m.sessionManager.CreateWithLayout(sess.name, record.Path, layout, agentCommand); err != nil {
+				msg.failed = append(msg.failed, fmt.Sprintf("%s (%v)", record.Branch, err))
+				continue
+			}
+			if m.sessionManager.Name() == session.MultiplexerTmux && m.configManager.GetSessionScrollback() {
+				_ = m.tmuxManager.RestoreScrollback(sess.name, record.Path, m.configManager.ScrollbackDir(sess.name), layout)
+			}
+			msg.restored = append(msg.restored, record.Branch)
+			if agentCommand != "" {
+				msg.agentsStarted = append(msg.agentsStarted, record)
+			}
+		}
+		return msg
+	}
+}
+
+// captureScrollback saves the scrollback of the repository's tmux sessions
+func (m Model) captureScrollback(sessions []session.Session) tea.Cmd {
+	return func() tea.Msg {
+		for _, sess := range sessions {
+			_ = m.tmuxManager.CaptureScrollback(sess.Name, m.configManager.ScrollbackDir(sess.Name))
+		}
+		return nil
+	}
+}
+
+// promptTarget is a worktree the prompt composer can send to
+type promptTarget struct {
+	worktree git.Worktree
+	selected bool
+}
+
+// openPromptComposer opens the prompt composer for a worktree
+// Broadcast targets are the worktrees whose Claude session is running
+func (m *Model) openPromptComposer(wt git.Worktree) tea.Cmd {
+	m.promptTargets = nil
+	for _, w := range m.worktrees {
+		if state := m.agentStateFor(w); state != session.AgentStateNone && state != session.AgentStateExited {
+			m.promptTargets = append(m.promptTargets, promptTarget{worktree: w, selected: w.Branch == wt.Branch})
+		}
+	}
+
+	m.promptBranch = wt.Branch
+	m.promptBroadcast = false
+	m.promptTargetCursor = 0
+	m.promptFocus = 0
+	m.promptHistoryIndex = -1
+	m.promptDraft = ""
+	m.promptHistory = nil
+	if m.configManager != nil {
+		m.promptHistory = m.configManager.GetPromptHistory(m.repoPath, wt.Branch)
+	}
+
+	m.promptInput.SetValue("")
+	m.promptInput.SetWidth(max(m.width-12, 20))
+	m.modal = promptComposerModal
+	return m.promptInput.Focus()
+}
+
+// selectedPromptTargets returns the worktrees the composed prompt is sent to
+func (m Model) selectedPromptTargets() []git.Worktree {
+	var targets []git.Worktree
+	for _, t := range m.promptTargets {
+		if (m.promptBroadcast && t.selected) || (!m.promptBroadcast && t.worktree.Branch == m.promptBranch) {
+			targets = append(targets, t.worktree)
+		}
+	}
+	return targets
+}
+
+// sendPrompt delivers a prompt to the Claude session of each target worktree
+func (m Model) sendPrompt(prompt string, targets []git.Worktree) tea.Cmd {
+	return func() tea.Msg {
+		result := promptSentMsg{prompt: prompt}
+		for _, wt := range targets {
+			if err := m.sessionManager.SendPrompt(wt.ClaudeSessionName, prompt); err != nil {
+				result.failed = append(result.failed, fmt.Sprintf("%s (%v)", wt.Branch, err))
+				continue
+			}
+			result.sent = append(result.sent, wt.Branch)
+		}
+		return result
+	}
+}
+
+// checkForUpdates checks if a new version of jean is available
+func (m Model) checkForUpdates() tea.Cmd {
+	return func() tea.Msg {
+		current, latest, updateAvailable, err := version.CheckLatestVersionOfCli(false)
+		return versionCheckMsg{
+			currentVersion:  current,
+			latestVersion:   latest,
+			updateAvailable: updateAvailable,
+			err:             err,
+		}
+	}
+}
+
+// showNotification displays a notification and optionally schedules it to be hidden after a duration
+func (m *Model) showNotification(message string, notifType NotificationType, autoClearAfter *time.Duration) tea.Cmd {
+	// Generate unique ID for this notification
+	m.notificationID++
 	notifID := m.notificationID
 
 	notif := &Notification{
@@ -2026,12 +3167,17 @@ func (m Model) openGitRepo() tea.Cmd {
 			return gitRepoOpenedMsg{err: fmt.Errorf("no worktree selected")}
 		}
 
-		// Get the branch URL for the selected worktree
-		url, err := m.gitManager.GetBranchRemoteURL(selected.Branch)
+		f, err := m.repoForge()
 		if err != nil {
 			return gitRepoOpenedMsg{err: err}
 		}
 
+		// Open the branch page if the branch exists on remote, otherwise the repo page
+		url := f.RepoURL()
+		if exists, err := m.gitManager.RemoteBranchExists(selected.Path, selected.Branch); err == nil && exists {
+			url = f.BranchURL(selected.Branch)
+		}
+
 		// Open in browser
 		if err := git.OpenInBrowser(url); err != nil {
 			return gitRepoOpenedMsg{err: err}
@@ -2041,6 +3187,97 @@ func (m Model) openGitRepo() tea.Cmd {
 	}
 }
 
+// repoForge resolves the forge (GitHub, GitLab, Gitea) hosting the repository from the origin remote
+// A per-repo "forge" config value overrides detection for self-hosted instances
+func (m Model) repoForge() (forge.Forge, error) {
+	remoteURL, err := m.gitManager.GetRemoteURL()
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := forge.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, token := "", ""
+	if m.configManager != nil {
+		kind = m.configManager.GetForge(m.repoPath)
+		token = m.configManager.GetForgeToken(remote.Host)
+	}
+
+	return forge.New(remote, kind, token)
+}
+
+// detachedWarning warns that a branch-only action doesn't work in a detached worktree
+func (m Model) detachedWarning(action string) tea.Cmd {
+	return m.showWarningNotification("Cannot " + action + " a detached worktree, press 'W' to promote it to a branch first")
+}
+
+// repoForgeKind returns the forge kind of the repository: configured, or detected from the remote host
+func (m Model) repoForgeKind() string {
+	if m.configManager != nil {
+		if kind := m.configManager.GetForge(m.repoPath); kind != "" {
+			return kind
+		}
+	}
+	remoteURL, err := m.gitManager.GetRemoteURL()
+	if err != nil {
+		return forge.KindGitHub
+	}
+	remote, err := forge.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return forge.KindGitHub
+	}
+	return forge.DetectKind(remote.Host)
+}
+
+// prHead returns the PR head for a branch: "owner:branch" when branches are pushed to a fork
+func (m Model) prHead(branch string) string {
+	if !m.gitManager.IsForkWorkflow() {
+		return branch
+	}
+	remoteURL, err := m.gitManager.GetRemoteURLFor(m.gitManager.PushRemote())
+	if err != nil {
+		return branch
+	}
+	remote, err := forge.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return branch
+	}
+	owner, _ := remote.OwnerAndRepo()
+	return owner + ":" + branch
+}
+
+// createFork forks the upstream repository and adds the fork as the push remote
+func (m Model) createFork(remoteName string) tea.Cmd {
+	return func() tea.Msg {
+		f, err := m.repoForge()
+		if err != nil {
+			return forkCreatedMsg{remote: remoteName, err: err}
+		}
+		creator, ok := f.(forge.ForkCreator)
+		if !ok {
+			return forkCreatedMsg{remote: remoteName, err: fmt.Errorf("creating forks is not supported on %s", f.Name())}
+		}
+
+		// Use the same protocol as the upstream remote
+		useSSH := false
+		if upstreamURL, err := m.gitManager.GetRemoteURL(); err == nil {
+			useSSH = forge.IsSSHRemoteURL(upstreamURL)
+		}
+
+		forkURL, err := creator.CreateFork(m.repoPath, useSSH)
+		if err != nil {
+			return forkCreatedMsg{remote: remoteName, err: err}
+		}
+		if err := m.gitManager.AddRemote(remoteName, forkURL); err != nil {
+			return forkCreatedMsg{remote: remoteName, err: err}
+		}
+		return forkCreatedMsg{remote: remoteName, url: forkURL}
+	}
+}
+
 // sortWorktrees sorts the worktree list by last modified time (most recent first)
 func (m *Model) sortWorktrees() {
 	if len(m.worktrees) == 0 {
@@ -2070,7 +3307,12 @@ func (m Model) markPRReady(worktreePath, prURL string) tea.Cmd {
 			return prMarkedReadyMsg{prURL: prURL, err: fmt.Errorf("no worktree selected")}
 		}
 
-		err := m.githubManager.MarkPRReady(selected.Path, prURL)
+		f, err := m.repoForge()
+		if err != nil {
+			return prMarkedReadyMsg{prURL: prURL, err: err}
+		}
+
+		err = f.MarkPRReady(selected.Path, prURL)
 		return prMarkedReadyMsg{prURL: prURL, err: err}
 	}
 }
@@ -2083,11 +3325,291 @@ func (m Model) mergePR(worktreePath, prURL, mergeMethod string) tea.Cmd {
 			return prMergedMsg{prURL: prURL, branch: "", err: fmt.Errorf("no worktree selected")}
 		}
 
-		err := m.githubManager.MergePR(selected.Path, prURL, mergeMethod)
+		f, err := m.repoForge()
+		if err != nil {
+			return prMergedMsg{prURL: prURL, branch: selected.Branch, err: err}
+		}
+
+		err = f.MergePR(selected.Path, prURL, mergeMethod)
 		return prMergedMsg{prURL: prURL, branch: selected.Branch, err: err}
 	}
 }
 
+// updateAutoMerge enables auto-merge, adds the PR to the merge queue, or disables auto-merge
+// action is "auto", "queue", or "disable-auto"; method is only used for "auto"
+func (m Model) updateAutoMerge(worktreePath, branch, prURL, action, method string) tea.Cmd {
+	return func() tea.Msg {
+		f, err := m.repoForge()
+		if err != nil {
+			return autoMergeUpdatedMsg{prURL: prURL, branch: branch, err: err}
+		}
+
+		autoMerger, ok := f.(forge.AutoMerger)
+		if !ok {
+			return autoMergeUpdatedMsg{prURL: prURL, branch: branch, err: fmt.Errorf("auto-merge is not supported on %s", f.Name())}
+		}
+
+		switch action {
+		case "auto":
+			err = autoMerger.EnableAutoMerge(worktreePath, prURL, method)
+			return autoMergeUpdatedMsg{prURL: prURL, branch: branch, autoMerge: method, err: err}
+		case "queue":
+			err = autoMerger.AddToMergeQueue(worktreePath, prURL)
+			return autoMergeUpdatedMsg{prURL: prURL, branch: branch, autoMerge: "queue", err: err}
+		default:
+			err = autoMerger.DisableAutoMerge(worktreePath, prURL)
+			return autoMergeUpdatedMsg{prURL: prURL, branch: branch, autoMerge: "", err: err}
+		}
+	}
+}
+
+// pendingAutoMerge returns the pending auto-merge state of a worktree's open PRs ("" if none)
+func pendingAutoMerge(wt git.Worktree) string {
+	if prs, ok := wt.PRs.([]config.PRInfo); ok {
+		for _, pr := range prs {
+			if pr.AutoMerge != "" && pr.Status == "open" {
+				return pr.AutoMerge
+			}
+		}
+	}
+	return ""
+}
+
+// statusPollInterval is how often the statuses of worktrees that aren't watched are refreshed
+const statusPollInterval = 10 * time.Second
+
+// syncWatcher watches the listed worktrees, and stops watching removed ones
+func (m Model) syncWatcher() tea.Cmd {
+	if m.watcher == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(m.worktrees))
+	for _, wt := range m.worktrees {
+		if !wt.Prunable { // Its directory is gone
+			paths = append(paths, wt.Path)
+		}
+	}
+	baseBranch := m.baseBranch
+	return func() tea.Msg {
+		m.watcher.SetBaseBranch(baseBranch)
+		unwatched, err := m.watcher.Sync(paths)
+		return watcherSyncedMsg{unwatched: unwatched, err: err}
+	}
+}
+
+// waitForWorktreeChanges waits for the watcher to report changed worktrees
+func (m Model) waitForWorktreeChanges() tea.Cmd {
+	if m.watcher == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return worktreesChangedMsg{paths: <-m.watcher.Events}
+	}
+}
+
+// scheduleStatusPoll schedules the next refresh of the statuses of worktrees that aren't watched
+func (m Model) scheduleStatusPoll() tea.Cmd {
+	return tea.Tick(statusPollInterval, func(t time.Time) tea.Msg {
+		return statusPollTickMsg(t)
+	})
+}
+
+// autoFetchMaxBackoff caps how long auto-fetch waits after failures (unless the interval is longer)
+const autoFetchMaxBackoff = 5 * time.Minute
+
+// scheduleAutoFetch schedules the next background fetch after the configured interval,
+// waiting longer after each consecutive failure
+func (m Model) scheduleAutoFetch() tea.Cmd {
+	if m.configManager == nil {
+		return nil
+	}
+	seconds := m.configManager.GetAutoFetchInterval(m.repoPath)
+	if seconds <= 0 {
+		return nil // Off
+	}
+	delay := time.Duration(seconds) * time.Second
+	for i := 0; i < m.autoFetchFailures && delay < autoFetchMaxBackoff; i++ {
+		delay *= 2
+		if delay > autoFetchMaxBackoff {
+			delay = autoFetchMaxBackoff
+		}
+	}
+	gen := m.autoFetchGen
+	return tea.Tick(delay, func(t time.Time) tea.Msg {
+		return autoFetchTickMsg{gen: gen}
+	})
+}
+
+// autoFetch fetches the remotes in the background
+func (m Model) autoFetch() tea.Cmd {
+	gen := m.autoFetchGen
+	return func() tea.Msg {
+		updated, err := m.gitManager.BackgroundFetch()
+		return autoFetchedMsg{gen: gen, updated: updated, err: err}
+	}
+}
+
+// scheduleAutoMergeCheck schedules the next poll of PRs with a pending auto-merge
+func (m Model) scheduleAutoMergeCheck() tea.Cmd {
+	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
+		return autoMergeTickMsg(t)
+	})
+}
+
+// checkAutoMergePRs polls the status of every PR with a pending auto-merge or merge queue entry
+func (m Model) checkAutoMergePRs() tea.Cmd {
+	worktrees := m.worktrees
+	return func() tea.Msg {
+		var results []autoMergeResult
+		var f forge.Forge
+
+		for _, wt := range worktrees {
+			prs, ok := wt.PRs.([]config.PRInfo)
+			if !ok {
+				continue
+			}
+
+			for _, pr := range prs {
+				if pr.AutoMerge == "" || pr.Status != "open" {
+					continue
+				}
+
+				// Resolve the forge lazily so repos without pending auto-merges don't pay for it
+				if f == nil {
+					var err error
+					if f, err = m.repoForge(); err != nil {
+						m.debugLog("checkAutoMergePRs: failed to resolve forge: " + err.Error())
+						return autoMergeStatusMsg{}
+					}
+				}
+
+				status, err := f.GetPRStatus(pr.URL)
+				if err != nil {
+					m.debugLog(fmt.Sprintf("checkAutoMergePRs: failed to get status for %s: %s", pr.URL, err.Error()))
+					continue
+				}
+
+				m.debugLog(fmt.Sprintf("checkAutoMergePRs: %s (auto-merge: %s) is %s", pr.URL, pr.AutoMerge, status))
+				results = append(results, autoMergeResult{
+					branch:       wt.Branch,
+					worktreePath: wt.Path,
+					prURL:        pr.URL,
+					status:       status,
+				})
+			}
+		}
+
+		return autoMergeStatusMsg{results: results}
+	}
+}
+
+// finishedPRReason returns why a worktree's PRs make it a cleanup candidate ("" if any PR is still open)
+func finishedPRReason(prs []config.PRInfo) string {
+	if len(prs) == 0 {
+		return ""
+	}
+	for _, pr := range prs {
+		if pr.Status == "open" || pr.Status == "" {
+			return ""
+		}
+	}
+	latest := prs[len(prs)-1]
+	return fmt.Sprintf("PR #%d %s", latest.PRNumber, latest.Status)
+}
+
+// suggestCleanup notifies about worktrees whose PRs were merged or closed since the last suggestion
+func (m *Model) suggestCleanup() tea.Cmd {
+	if m.modal != noModal {
+		return nil
+	}
+	if m.cleanupSuggested == nil {
+		m.cleanupSuggested = make(map[string]bool)
+	}
+
+	count := 0
+	for _, wt := range m.worktrees {
+		if wt.Protected || m.cleanupSuggested[wt.Branch] {
+			continue
+		}
+		if prs, ok := wt.PRs.([]config.PRInfo); ok && finishedPRReason(prs) != "" {
+			m.cleanupSuggested[wt.Branch] = true
+			count++
+		}
+	}
+
+	if count == 0 {
+		return nil
+	}
+	return m.showInfoNotification(fmt.Sprintf("%d worktree(s) have merged or closed PRs. Press C to clean up", count))
+}
+
+// scanCleanupCandidates finds worktrees whose PR is merged/closed, whose branch was deleted
+// on the remote, or which are fully contained in the base branch
+func (m Model) scanCleanupCandidates() tea.Cmd {
+	worktrees := m.worktrees
+	return func() tea.Msg {
+		// Prune first so branches deleted on the remote show up as gone
+		if err := m.gitManager.FetchRemotePrune(); err != nil {
+			m.debugLog("scanCleanupCandidates: fetch failed, using stale remote state: " + err.Error())
+		}
+
+		var candidates []cleanupCandidate
+		for _, wt := range worktrees {
+			if wt.Protected || wt.Branch == "" || wt.Branch == m.baseBranch || wt.Detached {
+				continue
+			}
+
+			var reasons []string
+			if m.configManager != nil {
+				if reason := finishedPRReason(m.configManager.GetPRs(m.repoPath, wt.Branch)); reason != "" {
+					reasons = append(reasons, reason)
+				}
+			}
+			if gone, err := m.gitManager.IsUpstreamGone(wt.Branch); err == nil && gone {
+				reasons = append(reasons, "branch deleted on remote")
+			}
+			if merged, err := m.gitManager.IsBranchMerged(wt.Branch, m.baseBranch); err == nil && merged {
+				reasons = append(reasons, "merged into "+m.baseBranch)
+			}
+
+			if len(reasons) == 0 {
+				continue
+			}
+
+			hasUncommitted, _ := m.gitManager.HasUncommittedChanges(wt.Path)
+			m.debugLog(fmt.Sprintf("scanCleanupCandidates: %s (%s, uncommitted: %v)", wt.Branch, strings.Join(reasons, ", "), hasUncommitted))
+			candidates = append(candidates, cleanupCandidate{
+				worktree:       wt,
+				reasons:        reasons,
+				hasUncommitted: hasUncommitted,
+				selected:       !hasUncommitted, // Never preselect worktrees with work in progress
+			})
+		}
+
+		return cleanupCandidatesLoadedMsg{candidates: candidates}
+	}
+}
+
+// removeCleanupCandidates removes every selected cleanup candidate
+func (m Model) removeCleanupCandidates(candidates []cleanupCandidate) tea.Cmd {
+	return func() tea.Msg {
+		var msg cleanupCompletedMsg
+		for _, c := range candidates {
+			if !c.selected {
+				continue
+			}
+			// Force is only needed to discard uncommitted changes, which the summary warned about
+			if err := m.removeWorktree(c.worktree.Path, c.worktree.Branch, c.hasUncommitted); err != nil {
+				m.debugLog(fmt.Sprintf("removeCleanupCandidates: failed to remove %s: %s", c.worktree.Branch, err.Error()))
+				msg.failed = append(msg.failed, c.worktree.Branch)
+				continue
+			}
+			msg.removed = append(msg.removed, c.worktree.Branch)
+		}
+		return msg
+	}
+}
+
 // loadAIPrompts loads the current AI prompts from config
 func (m Model) loadAIPrompts() tea.Cmd {
 	return func() tea.Msg {
@@ -2146,6 +3668,77 @@ type prMergedMsg struct {
 	err    error
 }
 
+type autoMergeUpdatedMsg struct {
+	prURL     string
+	branch    string
+	autoMerge string // New pending auto-merge state ("" when disabled)
+	err       error
+}
+
+type autoMergeTickMsg time.Time
+
+// autoMergeResult is the polled status of a PR with a pending auto-merge
+type autoMergeResult struct {
+	branch       string
+	worktreePath string
+	prURL        string
+	status       string // "open", "merged", or "closed"
+}
+
+type autoMergeStatusMsg struct {
+	results []autoMergeResult
+}
+
+type forkCreatedMsg struct {
+	remote string // Name of the remote the fork was added as
+	url    string
+	err    error
+}
+
+type prDescriptionSyncPreparedMsg struct {
+	worktreePath string
+	branch       string
+	prURL        string
+	oldBody      string
+	newBody      string
+	headCommit   string // HEAD the new description covers
+	err          error
+}
+
+type prDescriptionSyncedMsg struct {
+	branch     string
+	prURL      string
+	headCommit string
+	err        error
+}
+
+type prTemplatesLoadedMsg struct {
+	worktreePath string
+	templates    []git.PRTemplate
+}
+
+type reviewerSuggestionsLoadedMsg struct {
+	worktreePath string
+	reviewers    []string
+}
+
+// cleanupCandidate is a worktree suggested for removal in the cleanup modal
+type cleanupCandidate struct {
+	worktree       git.Worktree
+	reasons        []string // Why the worktree can be removed (e.g., "PR #12 merged")
+	hasUncommitted bool
+	selected       bool
+}
+
+type cleanupCandidatesLoadedMsg struct {
+	candidates []cleanupCandidate
+}
+
+type cleanupCompletedMsg struct {
+	removed []string // Branches removed
+	failed  []string // Branches that could not be removed
+}
+
 // Message types for AI prompts modal
 
 type aiPromptsSavedMsg struct {
@@ -2185,3 +3778,184 @@ func (m Model) checkOnboardingStatus() tea.Cmd {
 type tmuxConfigInstalledMsg struct {
 	err error
 }
+
+type promptSentMsg struct {
+	prompt string
+	sent   []string // Branches the prompt was delivered to
+	failed []string // "branch (error)" for each delivery that failed
+}
+
+type sessionPreparedMsg struct {
+	info         SwitchInfo
+	agentStarted bool // Whether the layout started the worktree's agent
+	err          error
+}
+
+type sessionLayoutResetMsg struct {
+	branch       string
+	agentStarted bool
+	err          error
+}
+
+type worktreesMigratedMsg struct {
+	moved  []worktreeMigration
+	failed []string // "branch (error)" for each worktree that couldn't be moved
+}
+
+type sessionsRecordedMsg struct {
+	records map[string]config.SessionRecord // Session name -> record
+}
+
+type sessionsRestoredMsg struct {
+	restored      []string               // Branches whose session was recreated
+	agentsStarted []config.SessionRecord // Records of restored sessions whose agent was started
+	failed        []string               // "branch (error)" for each session that couldn't be recreated
+}
+
+type includesPlannedMsg struct {
+	branch  string
+	actions []git.IncludeAction
+	err     error
+}
+
+type includesSyncedMsg struct {
+	branch string
+	count  int
+	err    error
+}
+
+type diskUsageMeasuredMsg struct {
+	usages []worktreeDiskUsage
+}
+
+type watcherSyncedMsg struct {
+	unwatched []string // Worktrees that couldn't be watched, polled instead
+	err       error
+}
+
+type worktreesChangedMsg struct {
+	paths []string // Worktrees whose files, HEAD, index, or refs changed
+}
+
+type statusPollTickMsg time.Time
+
+type autoFetchTickMsg struct {
+	gen int
+}
+
+type autoFetchedMsg struct {
+	gen     int
+	updated bool // Remote-tracking refs changed
+	err     error
+}
+
+// maintenanceAction is an entry of the worktree maintenance modal
+type maintenanceAction struct {
+	id          string // "promote", "lock", "unlock", "repair", or "prune"
+	name        string
+	description string
+}
+
+// maintenanceActions returns the actions available for the worktree of the maintenance modal
+func (m Model) maintenanceActions() []maintenanceAction {
+	wt := m.maintenanceWorktree
+	var actions []maintenanceAction
+	if wt.Detached && !wt.Prunable {
+		actions = append(actions, maintenanceAction{"promote", "Promote to branch", "Create a branch at " + wt.Commit[:min(7, len(wt.Commit))] + " and switch this worktree to it, to commit and push"})
+	}
+	if wt.Locked {
+		reason := wt.LockReason
+		if reason == "" {
+			reason = "no reason given"
+		}
+		actions = append(actions, maintenanceAction{"unlock", "Unlock", "Locked: " + reason})
+	} else if !wt.IsCurrent {
+		// The main checkout can't be locked
+		actions = append(actions, maintenanceAction{"lock", "Lock", "Keep git from pruning, moving, or removing it, e.g. on a removable drive"})
+	}
+	actions = append(actions, maintenanceAction{"repair", "Repair", "Fix its links to the repository after its directory was moved"})
+
+	prunable := 0
+	for _, w := range m.worktrees {
+		if w.Prunable && !w.Locked {
+			prunable++
+		}
+	}
+	actions = append(actions, maintenanceAction{"prune", "Prune", fmt.Sprintf("Forget worktrees whose directory is gone (%d now, locked ones are kept)", prunable)})
+	return actions
+}
+
+// maintainWorktree runs a maintenance action on a worktree; value is the lock reason, the repaired path,
+// or the branch to promote a detached worktree to
+func (m Model) maintainWorktree(action string, wt git.Worktree, value string) tea.Cmd {
+	return func() tea.Msg {
+		result := worktreeMaintainedMsg{action: action, branch: wt.Branch}
+		switch action {
+		case "lock":
+			result.err = m.gitManager.LockWorktree(wt.Path, value)
+		case "unlock":
+			result.err = m.gitManager.UnlockWorktree(wt.Path)
+		case "repair":
+			result.err = m.gitManager.RepairWorktree(value)
+		case "prune":
+			result.pruned, result.err = m.gitManager.PruneWorktrees()
+		case "promote":
+			result.branch = value
+			result.err = m.gitManager.PromoteToBranch(wt.Path, value)
+			if result.err == nil {
+				// The session was named after the directory, now it's named after the branch
+				_ = m.sessionManager.RenameSession(wt.ClaudeSessionName, m.worktreeSessionName(value))
+			}
+		}
+		return result
+	}
+}
+
+// createDetachedWorktree creates a worktree with a detached HEAD at a tag, commit, or PR, for looking
+// around, reviewing, or bisecting without a branch
+// PRs ("#123") are fetched from the upstream remote, preferring the forge's merge ref
+func (m Model) createDetachedWorktree(ref string) tea.Cmd {
+	return func() tea.Msg {
+		var commit, name string
+		var err error
+		if number, ok := forge.ParsePRReference(ref); ok {
+			name = fmt.Sprintf("pr-%d", number)
+			commit, err = m.gitManager.FetchPullRef(forge.PullRefs(m.repoForgeKind(), number))
+		} else {
+			name = ref
+			commit, err = m.gitManager.ResolveCommit(ref)
+			if err == nil && strings.HasPrefix(commit, strings.ToLower(ref)) {
+				// Name worktrees at a commit after the short SHA
+				name = commit[:min(7, len(commit))]
+			}
+		}
+		if err != nil {
+			return detachedWorktreeCreatedMsg{ref: ref, err: err}
+		}
+
+		if err := m.gitManager.EnsureWorkspacesDir(); err != nil {
+			return detachedWorktreeCreatedMsg{ref: ref, err: err}
+		}
+		path, err := m.gitManager.GetDefaultPath(name)
+		if err != nil {
+			return detachedWorktreeCreatedMsg{ref: ref, err: err}
+		}
+		m.debugLog(fmt.Sprintf("createDetachedWorktree: %s resolved to %s, creating %s", ref, commit, path))
+		err = m.gitManager.CreateDetached(path, commit)
+		return detachedWorktreeCreatedMsg{ref: ref, path: path, branch: git.DetachedBranchLabel(commit), err: err}
+	}
+}
+
+type detachedWorktreeCreatedMsg struct {
+	ref    string // Tag, commit, or PR as typed
+	path   string
+	branch string // "(detached at …)" label, to select the worktree once the list reloads
+	err    error
+}
+
+type worktreeMaintainedMsg struct {
+	action string
+	branch string
+	pruned []string // What was pruned, one line per worktree
+	err    error
+}
@@ -1,17 +1,18 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/coollabsio/jean-tui/config"
 	"github.com/coollabsio/jean-tui/git"
-	"github.com/coollabsio/jean-tui/github"
+	"github.com/coollabsio/jean-tui/forge"
+	"github.com/coollabsio/jean-tui/session"
 )
 
 // debugLog writes a message to the debug log file if debug logging is enabled
@@ -70,6 +71,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			for i, wt := range msg.worktrees {
 				m.debugLog(fmt.Sprintf("  [%d] %s - HasUncommitted: %v", i, wt.Branch, wt.HasUncommitted))
 			}
+			// Keep the statuses loaded before until the new ones arrive, so rows don't flicker
+			previous := make(map[string]git.Worktree, len(m.worktrees))
+			for _, wt := range m.worktrees {
+				previous[wt.Path] = wt
+			}
+			for i := range msg.worktrees {
+				if old, ok := previous[msg.worktrees[i].Path]; ok {
+					msg.worktrees[i].HasUncommitted = old.HasUncommitted
+					msg.worktrees[i].AheadCount = old.AheadCount
+					msg.worktrees[i].BehindCount = old.BehindCount
+					msg.worktrees[i].IsOutdated = old.IsOutdated
+				}
+			}
 			m.worktrees = msg.worktrees
 
 			// Mark initialization as complete after first successful worktree load
@@ -132,6 +146,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// This enables progressive status updates as each worktree's data loads
 			statusLoaders := make([]tea.Cmd, 0, len(m.worktrees))
 			for i := range m.worktrees {
+				if m.worktrees[i].Prunable {
+					continue // Its directory is gone
+				}
 				statusLoaders = append(statusLoaders, m.loadWorktreeStatus(i, m.worktrees[i]))
 			}
 			if len(statusLoaders) > 0 {
@@ -141,10 +158,138 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		// After first successful worktree load, check if we need to show onboarding
-		return m, tea.Batch(cmd, m.checkOnboardingStatus())
+		return m, tea.Batch(cmd, m.syncWatcher(), m.suggestCleanup(), m.checkOnboardingStatus())
+
+	case watcherSyncedMsg:
+		m.unwatchedPaths = make(map[string]bool, len(msg.unwatched))
+		for _, path := range msg.unwatched {
+			m.unwatchedPaths[path] = true
+		}
+		if msg.err != nil {
+			m.debugLog(fmt.Sprintf("Watching worktrees: %v, polling %d worktrees instead", msg.err, len(msg.unwatched)))
+			if errors.Is(msg.err, git.ErrWatchLimit) && !m.watchLimitNotified {
+				m.watchLimitNotified = true
+				return m, m.showWarningNotification(fmt.Sprintf("File watch limit reached, polling %d worktrees. Raise fs.inotify.max_user_watches for instant updates", len(msg.unwatched)))
+			}
+		}
+		return m, nil
+
+	case worktreesChangedMsg:
+		// Refresh only the worktrees that changed, then wait for the next changes
+		cmds := []tea.Cmd{m.waitForWorktreeChanges()}
+		for _, path := range msg.paths {
+			for i, wt := range m.worktrees {
+				if wt.Path == path && !wt.Prunable {
+					m.debugLog(fmt.Sprintf("Worktree changed: %s", wt.Branch))
+					cmds = append(cmds, m.loadWorktreeStatus(i, wt))
+					break
+				}
+			}
+		}
+		return m, tea.Batch(cmds...)
+
+	case detachedWorktreeCreatedMsg:
+		warning, created := worktreeCreatedWarning(msg.err)
+		if msg.err != nil && !created {
+			return m, m.showErrorNotification("Failed to create worktree at "+msg.ref+": "+msg.err.Error(), 5*time.Second)
+		}
+		m.lastCreatedBranch = msg.branch
+		if msg.err != nil {
+			// The worktree was created, only getting it ready failed
+			cmd = m.showWarningNotification(warning)
+		} else {
+			cmd = m.showSuccessNotification("Created detached worktree at "+msg.ref, 3*time.Second)
+		}
+		return m, tea.Batch(cmd, m.loadWorktrees())
+
+	case worktreeMaintainedMsg:
+		if msg.err != nil {
+			return m, tea.Batch(m.showErrorNotification(msg.err.Error(), 5*time.Second), m.loadWorktrees())
+		}
+		var notification string
+		switch msg.action {
+		case "lock":
+			notification = "Locked " + msg.branch
+		case "unlock":
+			notification = "Unlocked " + msg.branch
+		case "repair":
+			notification = "Repaired " + msg.branch
+		case "promote":
+			notification = "Promoted to branch " + msg.branch
+			m.lastRenamedBranch = msg.branch
+		case "prune":
+			m.debugLog(fmt.Sprintf("Pruned worktrees: %v", msg.pruned))
+			switch len(msg.pruned) {
+			case 0:
+				notification = "Nothing to prune"
+			case 1:
+				notification = "Pruned 1 worktree"
+			default:
+				notification = fmt.Sprintf("Pruned %d worktrees", len(msg.pruned))
+			}
+		}
+		return m, tea.Batch(m.showSuccessNotification(notification, 3*time.Second), m.loadWorktrees())
+
+	case autoFetchTickMsg:
+		if msg.gen != m.autoFetchGen {
+			return m, nil // The interval changed since, a newer loop is running
+		}
+		if m.refreshing {
+			// Its pulls would fight over ref locks with the fetch, try again on the next tick
+			return m, m.scheduleAutoFetch()
+		}
+		return m, m.autoFetch()
+
+	case autoFetchedMsg:
+		if msg.gen != m.autoFetchGen {
+			return m, nil
+		}
+		if errors.Is(msg.err, git.ErrFetchInProgress) {
+			// A user-started fetch is running, not a failure
+			return m, m.scheduleAutoFetch()
+		}
+		if msg.err != nil {
+			// Back off quietly, the offline indicator tells the user the remote is unreachable
+			m.autoFetchFailures++
+			m.offline = errors.Is(msg.err, git.ErrOffline)
+			m.debugLog(fmt.Sprintf("Auto-fetch failed (%d in a row): %v", m.autoFetchFailures, msg.err))
+			return m, m.scheduleAutoFetch()
+		}
+		m.autoFetchFailures = 0
+		m.offline = false
+		cmds := []tea.Cmd{m.scheduleAutoFetch()}
+		if msg.updated {
+			// Recompute behind counts; watched worktrees already refresh when the refs change
+			for i, wt := range m.worktrees {
+				if (m.watcher == nil || m.unwatchedPaths[wt.Path]) && !wt.Prunable {
+					cmds = append(cmds, m.loadWorktreeStatus(i, wt))
+				}
+			}
+		}
+		return m, tea.Batch(cmds...)
+
+	case statusPollTickMsg:
+		// Poll the worktrees that aren't watched (all of them where file watching isn't supported)
+		cmds := []tea.Cmd{m.scheduleStatusPoll()}
+		for i, wt := range m.worktrees {
+			if (m.watcher == nil || m.unwatchedPaths[wt.Path]) && !wt.Prunable {
+				cmds = append(cmds, m.loadWorktreeStatus(i, wt))
+			}
+		}
+		return m, tea.Batch(cmds...)
 
 	case worktreeStatusUpdatedMsg:
 		// Update individual worktree with loaded status data (no blocking, progressive update)
+		if msg.index >= 0 && msg.index < len(m.worktrees) && m.worktrees[msg.index].Path != msg.path {
+			// The list was reloaded or resorted since, find the worktree again
+			msg.index = -1
+			for i := range m.worktrees {
+				if m.worktrees[i].Path == msg.path {
+					msg.index = i
+					break
+				}
+			}
+		}
 		if msg.index >= 0 && msg.index < len(m.worktrees) {
 			m.worktrees[msg.index].HasUncommitted = msg.hasUncommitted
 			m.worktrees[msg.index].AheadCount = msg.aheadCount
@@ -214,18 +359,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case worktreeCreatedMsg:
 		if msg.err != nil {
-			// Check if this is a setup script error (warning) or a git error (error)
-			errMsg := msg.err.Error()
-			if strings.Contains(errMsg, "setup script failed") {
-				// Setup script failed - show warning but worktree was created
-				// Extract just the relevant error message (skip "setup script failed: " prefix)
-				warningMsg := strings.TrimPrefix(errMsg, "setup script failed: ")
-				cmd = m.showWarningNotification(fmt.Sprintf("Worktree created but setup script failed:\n%s", warningMsg))
+			// Check if getting the worktree ready failed (warning) or creating it failed (error)
+			if warning, ok := worktreeCreatedWarning(msg.err); ok {
+				// Include files or the setup script failed - show warning but worktree was created
+				cmd = m.showWarningNotification(warning)
 				m.modal = noModal
 				m.lastCreatedBranch = msg.branch
 
 				// OPTIMISTIC UI UPDATE: Add worktree immediately even though setup failed
-				repoName := filepath.Base(m.repoPath)
+				repoName := git.RepoName(m.repoPath)
 				tempWorktree := git.Worktree{
 					Path:              msg.path,
 					Branch:            msg.branch,
@@ -271,7 +413,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// OPTIMISTIC UI UPDATE: Add worktree to list immediately for instant feedback
 			// This eliminates the delay between notification and list update
-			repoName := filepath.Base(m.repoPath)
+			repoName := git.RepoName(m.repoPath)
 			tempWorktree := git.Worktree{
 				Path:              msg.path,
 				Branch:            msg.branch,
@@ -301,24 +443,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case worktreeCreatedWithSessionMsg:
 		if msg.err != nil {
-			// Check if this is a setup script error (warning) or a git error (error)
-			errMsg := msg.err.Error()
-			if strings.Contains(errMsg, "setup script failed") {
-				// Setup script failed - show warning but worktree was created
-				warningMsg := strings.TrimPrefix(errMsg, "setup script failed: ")
-				cmd = m.showWarningNotification(fmt.Sprintf("Worktree created but setup script failed:\n%s", warningMsg))
+			// Check if getting the worktree ready failed (warning) or creating it failed (error)
+			if warning, ok := worktreeCreatedWarning(msg.err); ok {
+				// Include files or the setup script failed - show warning but worktree was created
+				cmd = m.showWarningNotification(warning)
 				m.modal = noModal
 				m.lastCreatedBranch = msg.branch
 				// Store session name for switch
+				agentCommand, _ := m.agentCommand(msg.path, msg.branch)
 				m.switchInfo = SwitchInfo{
 					Path:       msg.path,
 					Branch:     msg.branch,
 					SessionName: msg.sessionName,
 					AutoClaude: m.autoClaude,
+					AgentCommand: agentCommand,
 				}
 
 				// OPTIMISTIC UI UPDATE: Add worktree immediately even though setup failed
-				repoName := filepath.Base(m.repoPath)
+				repoName := git.RepoName(m.repoPath)
 				tempWorktree := git.Worktree{
 					Path:              msg.path,
 					Branch:            msg.branch,
@@ -350,16 +492,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.lastCreatedBranch = msg.branch
 
 			// Store session name for switch
+			agentCommand, _ := m.agentCommand(msg.path, msg.branch)
 			m.switchInfo = SwitchInfo{
-				Path:        msg.path,
-				Branch:      msg.branch,
-				SessionName: msg.sessionName,
-				AutoClaude:  m.autoClaude,
+				Path:         msg.path,
+				Branch:       msg.branch,
+				SessionName:  msg.sessionName,
+				AutoClaude:   m.autoClaude,
+				AgentCommand: agentCommand,
 			}
 
 			// OPTIMISTIC UI UPDATE: Add worktree to list immediately for instant feedback
 			// This eliminates the delay between notification and list update
-			repoName := filepath.Base(m.repoPath)
+			repoName := git.RepoName(m.repoPath)
 			tempWorktree := git.Worktree{
 				Path:              msg.path,
 				Branch:            msg.branch,
@@ -475,9 +619,84 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case sessionsLoadedMsg:
-		m.sessions = msg.sessions
+		cmd = m.updateSessions(msg.sessions)
+		// Offer to restore recorded sessions that are gone (e.g. after a reboot) once at startup
+		if !m.restoreChecked {
+			m.restoreChecked = true
+			if restorable := m.findRestorableSessions(); len(restorable) > 0 && m.modal == noModal {
+				m.openRestoreSessions(restorable)
+			}
+		}
+		return m, cmd
+
+	case worktreesMigratedMsg:
+		// Sessions recorded for restoring follow their worktree
+		if m.configManager != nil {
+			records := m.configManager.GetSessionRecords(m.repoPath)
+			for _, migration := range msg.moved {
+				for name, record := range records {
+					if record.Path == migration.worktree.Path {
+						record.Path = migration.newPath
+						_ = m.configManager.SetSessionRecord(m.repoPath, name, record)
+					}
+				}
+			}
+		}
+		if len(msg.failed) > 0 {
+			return m, tea.Batch(m.showErrorNotification("Failed to move: "+strings.Join(msg.failed, ", "), 5*time.Second), m.loadWorktrees())
+		}
+		return m, tea.Batch(m.showSuccessNotification(fmt.Sprintf("Moved %d worktree(s)", len(msg.moved)), 3*time.Second), m.loadWorktrees())
+
+	case includesPlannedMsg:
+		if msg.err != nil {
+			return m, m.showErrorNotification("Failed to plan includes: "+msg.err.Error(), 4*time.Second)
+		}
+		if len(msg.actions) == 0 {
+			return m, m.showInfoNotification("Nothing to include. List gitignored files under \"include\" in jean.json")
+		}
+		m.includeBranch = msg.branch
+		m.includeActions = msg.actions
+		m.modal = includeModal
 		return m, nil
 
+	case diskUsageMeasuredMsg:
+		m.diskUsage = msg.usages
+		m.diskUsageIndex = 0
+		m.modal = diskUsageModal
+		return m, nil
+
+	case includesSyncedMsg:
+		if msg.err != nil {
+			return m, m.showErrorNotification(msg.err.Error(), 5*time.Second)
+		}
+		return m, m.showSuccessNotification(fmt.Sprintf("Resynced %d include(s) into %s", msg.count, msg.branch), 3*time.Second)
+
+	case sessionsRecordedMsg:
+		if m.configManager != nil {
+			running := make(map[string]bool, len(m.sessions))
+			for _, sess := range m.sessions {
+				running[sess.Name] = true
+			}
+			for name, record := range msg.records {
+				if !running[name] {
+					continue // It ended while being looked up, and was forgotten already
+				}
+				_ = m.configManager.SetSessionRecord(m.repoPath, name, record) // Ignore error, not critical
+			}
+		}
+		return m, nil
+
+	case sessionsRestoredMsg:
+		if m.configManager != nil {
+			for _, record := range msg.agentsStarted {
+				_ = m.configManager.SetAgentInitialized(m.repoPath, record.Branch, record.Agent)
+			}
+		}
+		if len(msg.failed) > 0 {
+			return m, tea.Batch(m.showErrorNotification("Failed to restore: "+strings.Join(msg.failed, ", "), 5*time.Second), m.loadSessions())
+		}
+		return m, tea.Batch(m.showSuccessNotification(fmt.Sprintf("Restored %d session(s)", len(msg.restored)), 3*time.Second), m.loadSessions())
+
 	case editorOpenedMsg:
 		if msg.err != nil {
 			cmd = m.showErrorNotification("Failed to open editor: " + msg.err.Error(), 4*time.Second)
@@ -529,6 +748,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prRetryBranch = ""
 			m.prRetryTitle = ""
 			m.prRetryDescription = ""
+			m.prMetadata = nil
 
 			cmd = m.showErrorNotification("Failed to create PR: " + errMsg, 4*time.Second)
 			return m, cmd
@@ -539,6 +759,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prRetryBranch = ""
 			m.prRetryTitle = ""
 			m.prRetryDescription = ""
+			m.prMetadata = nil
 
 			m.debugLog(fmt.Sprintf("PR created successfully: %s", msg.prURL))
 			// Use the branch from the message (the one we actually created the PR for)
@@ -550,12 +771,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Save PR to config
 			if prBranch != "" {
 				// Extract PR number from URL (e.g., github.com/owner/repo/pull/123)
-				prNumber := 0
-				if parts := strings.Split(msg.prURL, "/pull/"); len(parts) == 2 {
-					fmt.Sscanf(parts[1], "%d", &prNumber)
-				}
+				prNumber := forge.ParsePRNumber(msg.prURL)
 				m.debugLog(fmt.Sprintf("Extracted PR number: %d from URL: %s", prNumber, msg.prURL))
 				_ = m.configManager.AddPR(m.repoPath, prBranch, msg.prURL, prNumber, msg.prTitle, msg.author)
+				if msg.headCommit != "" {
+					_ = m.configManager.SetPRSyncedCommit(m.repoPath, prBranch, msg.prURL, msg.headCommit)
+				}
 			}
 
 			m.debugLog("Triggering worktree refresh after PR creation")
@@ -565,7 +786,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if msg.isDraft {
 				statusMsg = "Draft PR created / updated"
 			}
-			cmd = m.showSuccessNotification(statusMsg + ": " + msg.prURL, 5*time.Second)
+			if msg.metadataErr != nil {
+				// The PR exists, so only warn that reviewers/labels/assignees/milestone are missing
+				m.debugLog(fmt.Sprintf("Failed to apply PR metadata: %v", msg.metadataErr))
+				cmd = m.showWarningNotification(statusMsg + ", but metadata was not applied: " + msg.metadataErr.Error())
+			} else {
+				cmd = m.showSuccessNotification(statusMsg + ": " + msg.prURL, 5*time.Second)
+			}
 			return m, tea.Batch(
 				cmd,
 				m.loadWorktrees(),
@@ -583,7 +810,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if hasAPIKey && aiContentEnabled {
 				return m, tea.Batch(cmd, m.generatePRContent(msg.worktreePath, msg.oldBranchName, m.baseBranch))
 			}
-			return m, tea.Batch(cmd, m.createPR(msg.worktreePath, msg.oldBranchName, "", ""))
+			return m, tea.Batch(cmd, m.createPR(msg.worktreePath, msg.oldBranchName, "", "", false))
+		}
+
+		// Generated names must follow the repository's naming convention too
+		if err := m.gitManager.ValidateBranchName(msg.newBranchName); err != nil {
+			cmd = m.showWarningNotification(err.Error() + ", using current name...")
+			hasAPIKey := m.configManager != nil && m.configManager.GetOpenRouterAPIKey() != ""
+			aiContentEnabled := m.configManager != nil && m.configManager.GetAICommitEnabled()
+			if hasAPIKey && aiContentEnabled {
+				return m, tea.Batch(cmd, m.generatePRContent(msg.worktreePath, msg.oldBranchName, m.baseBranch))
+			}
+			return m, tea.Batch(cmd, m.createPR(msg.worktreePath, msg.oldBranchName, "", "", false))
 		}
 
 		// Check if target branch already exists locally
@@ -597,7 +835,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if hasAPIKey && aiContentEnabled {
 				return m, tea.Batch(cmd, m.generatePRContent(msg.worktreePath, msg.oldBranchName, m.baseBranch))
 			}
-			return m, tea.Batch(cmd, m.createPR(msg.worktreePath, msg.oldBranchName, "", ""))
+			return m, tea.Batch(cmd, m.createPR(msg.worktreePath, msg.oldBranchName, "", "", false))
 		}
 
 		// Store pending rename state
@@ -636,7 +874,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if hasAPIKey && aiContentEnabled {
 				return m, tea.Batch(cmd, m.generatePRContent(msg.worktreePath, msg.oldBranchName, m.baseBranch))
 			}
-			return m, tea.Batch(cmd, m.createPR(msg.worktreePath, msg.oldBranchName, "", ""))
+			return m, tea.Batch(cmd, m.createPR(msg.worktreePath, msg.oldBranchName, "", "", false))
 		}
 
 		cmd = m.showInfoNotification("Renaming branch locally...")
@@ -675,10 +913,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prTitleInput.SetValue(defaultTitle)
 			m.prTitleInput.Focus()
 			m.prDescriptionInput.SetValue("")
+			m.prGeneratedDescription = ""
 
 			// Rename tmux sessions
 			cmd = m.renameSessionsForBranch(msg.oldBranchName, msg.newBranchName)
-			return m, cmd
+			return m, tea.Batch(cmd, m.resetPRMetadataInputs())
 		}
 
 	case commitCreatedMsg:
@@ -755,7 +994,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.prModalFocused = 0
 					m.prTitleInput.Focus()
 					m.prDescriptionInput.Blur()
-					return m, m.showSuccessNotification("Committed successfully. Enter PR details:", 2*time.Second)
+					return m, tea.Batch(m.showSuccessNotification("Committed successfully. Enter PR details:", 2*time.Second), m.resetPRMetadataInputs())
 				}
 			}
 
@@ -836,7 +1075,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prModalFocused = 0
 			m.prTitleInput.Focus()
 			m.prDescriptionInput.Blur()
-			return m, nil
+			return m, m.resetPRMetadataInputs()
 		}
 
 	case prContentGeneratedMsg:
@@ -885,13 +1124,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Fill in the generated content but don't create PR yet - let user confirm
 			m.prTitleInput.SetValue(msg.title)
 			m.prDescriptionInput.SetValue(msg.description)
+			m.prGeneratedDescription = m.prDescriptionInput.Value()
 			cmd = m.showSuccessNotification("PR content generated! Review and press Enter to create", 3*time.Second)
 			return m, cmd
 		}
 
 		// Not in modal - auto-create or update PR with generated content (for auto-generation flow)
 		cmd = m.showInfoNotification("Creating or updating draft PR...")
-		return m, tea.Batch(cmd, m.createOrUpdatePR(msg.worktreePath, msg.branch, msg.title, msg.description))
+		return m, tea.Batch(cmd, m.createOrUpdatePR(msg.worktreePath, msg.branch, msg.title, msg.description, true))
 
 	case pushBranchNameGeneratedMsg:
 		// AI branch name generated for push
@@ -901,6 +1141,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmd, m.pushBranch(msg.worktreePath, msg.oldBranchName))
 		}
 
+		// Generated names must follow the repository's naming convention too
+		if err := m.gitManager.ValidateBranchName(msg.newBranchName); err != nil {
+			cmd = m.showWarningNotification(err.Error() + ", using current name...")
+			return m, tea.Batch(cmd, m.pushBranch(msg.worktreePath, msg.oldBranchName))
+		}
+
 		// Check if target branch already exists locally
 		targetExists, _ := m.gitManager.BranchExists(msg.worktreePath, msg.newBranchName)
 		if targetExists {
@@ -971,11 +1217,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Push succeeded
-		cmd = m.showSuccessNotification("Pushed to origin/"+msg.branch, 3*time.Second)
-		return m, tea.Batch(
-			cmd,
-			m.loadWorktrees(),
-		)
+		cmd = m.showSuccessNotification("Pushed to "+m.gitManager.PushRemote()+"/"+msg.branch, 3*time.Second)
+		cmds := []tea.Cmd{cmd, m.loadWorktrees()}
+
+		// Bring the open PR's description up to date with the pushed commits
+		if m.configManager != nil && msg.worktreePath != "" {
+			if mode := m.configManager.GetPRDescriptionSync(m.repoPath); mode != "" {
+				if pr := m.configManager.GetLatestPR(m.repoPath, msg.branch); pr != nil && pr.Status == "open" {
+					m.debugLog(fmt.Sprintf("Syncing PR description (%s) for %s", mode, pr.URL))
+					cmds = append(cmds, m.preparePRDescriptionSync(msg.worktreePath, msg.branch, *pr, mode))
+				}
+			}
+		}
+		return m, tea.Batch(cmds...)
 
 	case themeChangedMsg:
 		if msg.err != nil {
@@ -1029,7 +1283,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			if msg.hadConflict {
 				// Show error with abort option
-				cmd = m.showWarningNotification("Merge conflict! Resolve conflicts and commit, or run 'git merge --abort' in main repo.")
+				cmd = m.showWarningNotification(fmt.Sprintf("Merge conflict! Resolve conflicts and commit, or run 'git merge --abort' in %s.", msg.checkout))
 				return m, tea.Batch(
 					cmd,
 					m.loadWorktrees(), // Refresh to show updated state
@@ -1052,6 +1306,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.loadWorktrees()
 
 	case refreshWithPullMsg:
+		m.refreshing = false
 		if msg.err != nil {
 			cmd = m.showErrorNotification("Failed to refresh: " + msg.err.Error(), 5*time.Second)
 			return m, cmd
@@ -1078,6 +1333,94 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			)
 		}
 
+	case reviewerSuggestionsLoadedMsg:
+		// Ignore stale suggestions for a PR modal that was closed or reopened for another worktree
+		if m.modal == prContentModal && msg.worktreePath == m.prModalWorktreePath {
+			m.prSuggestedReviewers = msg.reviewers
+		}
+		return m, nil
+
+	case prDescriptionSyncPreparedMsg:
+		if msg.err != nil {
+			m.debugLog(fmt.Sprintf("PR description sync failed: %v", msg.err))
+			return m, m.showWarningNotification("Could not sync PR description: " + msg.err.Error())
+		}
+		if msg.newBody == msg.oldBody {
+			// Nothing to change, just remember that the description covers this commit
+			if msg.headCommit != "" {
+				_ = m.configManager.SetPRSyncedCommit(m.repoPath, msg.branch, msg.prURL, msg.headCommit)
+			}
+			return m, nil
+		}
+		if m.modal != noModal {
+			// Don't interrupt another dialog; the next push will offer the update again
+			m.debugLog("Skipping PR description sync: another modal is open")
+			return m, nil
+		}
+		m.prSyncWorktreePath = msg.worktreePath
+		m.prSyncBranch = msg.branch
+		m.prSyncURL = msg.prURL
+		m.prSyncOldBody = msg.oldBody
+		m.prSyncNewBody = msg.newBody
+		m.prSyncCommit = msg.headCommit
+		m.prSyncScroll = 0
+		m.modal = prDescriptionSyncModal
+		return m, nil
+
+	case forkCreatedMsg:
+		if msg.err != nil {
+			return m, m.showErrorNotification("Failed to create fork: " + msg.err.Error(), 5*time.Second)
+		}
+		cmd = m.showSuccessNotification("Fork added as remote '" + msg.remote + "': " + msg.url, 4*time.Second)
+		return m, tea.Batch(cmd, m.loadWorktrees())
+
+	case prDescriptionSyncedMsg:
+		if msg.err != nil {
+			return m, m.showErrorNotification("Failed to update PR description: " + msg.err.Error(), 4*time.Second)
+		}
+		_ = m.configManager.SetPRSyncedCommit(m.repoPath, msg.branch, msg.prURL, msg.headCommit)
+		return m, m.showSuccessNotification("PR description updated", 3*time.Second)
+
+	case promptSentMsg:
+		if m.configManager != nil {
+			for _, branch := range msg.sent {
+				_ = m.configManager.AddPromptHistory(m.repoPath, branch, msg.prompt)
+			}
+		}
+		if len(msg.failed) > 0 {
+			return m, m.showErrorNotification("Failed to send prompt to "+strings.Join(msg.failed, ", "), 5*time.Second)
+		}
+		return m, m.showSuccessNotification("Prompt sent to "+strings.Join(msg.sent, ", "), 3*time.Second)
+
+	case prTemplatesLoadedMsg:
+		// Ignore stale templates for a PR modal that was closed or reopened for another worktree
+		if m.modal == prContentModal && msg.worktreePath == m.prModalWorktreePath {
+			m.prTemplates = msg.templates
+			m.prTemplateIndex = 0
+		}
+		return m, nil
+
+	case cleanupCandidatesLoadedMsg:
+		if len(msg.candidates) == 0 {
+			return m, m.showSuccessNotification("Nothing to clean up", 3*time.Second)
+		}
+		m.cleanupCandidates = msg.candidates
+		m.cleanupCursor = 0
+		m.cleanupConfirming = false
+		m.modal = cleanupModal
+		return m, nil
+
+	case cleanupCompletedMsg:
+		m.modal = noModal
+		m.cleanupCandidates = nil
+		m.selectedIndex = 0
+		if len(msg.failed) > 0 {
+			cmd = m.showWarningNotification(fmt.Sprintf("Removed %d worktree(s), failed to remove: %s", len(msg.removed), strings.Join(msg.failed, ", ")))
+		} else {
+			cmd = m.showSuccessNotification(fmt.Sprintf("Removed %d worktree(s)", len(msg.removed)), 3*time.Second)
+		}
+		return m, tea.Batch(cmd, m.loadWorktrees())
+
 	case prStatusesRefreshedMsg:
 		if msg.err != nil {
 			// Silently handle PR status refresh errors
@@ -1096,13 +1439,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.scheduleActivityCheck()
 
 	case activityCheckedMsg:
+		var notifyCmd, captureCmd tea.Cmd
 		if msg.err == nil {
-			// Update sessions with activity information
-			m.sessions = msg.sessions
+			// Update sessions with activity and agent state information
+			notifyCmd = m.updateSessions(msg.sessions)
+
+			// Capture scrollback of tmux sessions now and then, so it can be restored with the session
+			if m.configManager != nil && m.configManager.GetSessionScrollback() && m.sessionManager.Name() == session.MultiplexerTmux &&
+				time.Since(m.lastScrollbackCapture) >= scrollbackCaptureInterval {
+				m.lastScrollbackCapture = time.Now()
+				captureCmd = m.captureScrollback(msg.sessions)
+			}
 		}
 		// Continue scheduling activity checks
-		cmd = m.scheduleActivityCheck()
-		return m, cmd
+		return m, tea.Batch(notifyCmd, captureCmd, m.scheduleActivityCheck())
 
 	case versionCheckMsg:
 		// Silently handle errors (don't show error notification for version check failures)
@@ -1304,7 +1654,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.prModalFocused = 0
 				m.prTitleInput.Focus()
 				m.prDescriptionInput.Blur()
-				return m, nil
+				return m, m.resetPRMetadataInputs()
 			}
 		}
 
@@ -1329,10 +1679,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.renameModalStatus = "❌ Error: " + msg.err.Error()
 			m.renameModalStatusTime = time.Now()
 		} else {
-			// Populate the name input with AI-generated branch name
-			m.nameInput.SetValue(msg.name)
+			// Populate the name input with AI-generated branch name, in the branch name template's place
+			name := msg.name
+			if wt := m.selectedWorktree(); wt != nil {
+				name = m.gitManager.ApplyGeneratedName(wt.Branch, name)
+			}
+			m.nameInput.SetValue(name)
 			m.nameInput.CursorEnd()
 			m.renameModalStatus = "✅ Generated from changes"
+			if err := m.gitManager.ValidateBranchName(name); err != nil {
+				m.renameModalStatus = "⚠️ " + err.Error()
+			}
 			m.renameModalStatusTime = time.Now()
 		}
 		return m, nil
@@ -1352,12 +1709,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.pendingSwitchInfo = nil
 			return m, cmd
 		}
-		// Worktree is now ensured to exist, proceed with switch
+		// Worktree is now ensured to exist, create the session from the jean.json layout and switch
 		if m.pendingSwitchInfo != nil {
-			m.switchInfo = *m.pendingSwitchInfo
+			info := *m.pendingSwitchInfo
 			m.pendingSwitchInfo = nil
-			return m, tea.Quit
+			return m, m.prepareSession(info)
+		}
+
+	case sessionPreparedMsg:
+		if msg.err != nil {
+			return m, m.showErrorNotification("Failed to apply session layout: "+msg.err.Error(), 5*time.Second)
+		}
+		if msg.agentStarted && m.configManager != nil {
+			_ = m.configManager.SetAgentInitialized(m.repoPath, msg.info.Branch, m.agentProfile(msg.info.Branch).Name)
+		}
+		m.switchInfo = msg.info
+		return m, tea.Quit
+
+	case sessionLayoutResetMsg:
+		if msg.err != nil {
+			return m, m.showErrorNotification("Failed to reset layout: "+msg.err.Error(), 5*time.Second)
 		}
+		if msg.agentStarted && m.configManager != nil {
+			_ = m.configManager.SetAgentInitialized(m.repoPath, msg.branch, m.agentProfile(msg.branch).Name)
+		}
+		return m, tea.Batch(m.showSuccessNotification("Layout reset for "+msg.branch, 3*time.Second), m.loadSessions())
 
 	case prMarkedReadyMsg:
 		// PR has been marked as ready for review
@@ -1408,6 +1784,74 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Show success and reload worktrees
 		cmd = m.showSuccessNotification("PR merged successfully!", 3*time.Second)
 		return m, tea.Batch(cmd, m.loadWorktrees())
+
+	case autoMergeUpdatedMsg:
+		if msg.err != nil {
+			cmd = m.showErrorNotification(msg.err.Error(), 5*time.Second)
+			return m, cmd
+		}
+
+		// Remember the pending state so the list can show it and polling can pick it up
+		if m.configManager != nil && msg.branch != "" {
+			_ = m.configManager.SetPRAutoMerge(m.repoPath, msg.branch, msg.prURL, msg.autoMerge)
+		}
+
+		switch msg.autoMerge {
+		case "":
+			cmd = m.showSuccessNotification("Auto-merge disabled", 3*time.Second)
+		case "queue":
+			cmd = m.showSuccessNotification("PR added to merge queue. Cleanup will be offered once it merges.", 4*time.Second)
+		default:
+			cmd = m.showSuccessNotification("Auto-merge enabled ("+msg.autoMerge+"). Cleanup will be offered once it merges.", 4*time.Second)
+		}
+		return m, tea.Batch(cmd, m.loadWorktrees())
+
+	case autoMergeTickMsg:
+		// Only poll when something is pending, and wait while a modal is open so the
+		// post-merge cleanup prompt doesn't interrupt another flow
+		pending := false
+		for _, wt := range m.worktrees {
+			if pendingAutoMerge(wt) != "" {
+				pending = true
+				break
+			}
+		}
+		if !pending || m.modal != noModal {
+			return m, m.scheduleAutoMergeCheck()
+		}
+		return m, m.checkAutoMergePRs()
+
+	case autoMergeStatusMsg:
+		var cmds []tea.Cmd
+		cleanupOffered := false
+
+		for _, result := range msg.results {
+			switch result.status {
+			case "merged":
+				// Offer cleanup for one PR at a time; others stay pending until the next poll
+				if cleanupOffered || m.modal != noModal {
+					continue
+				}
+				_ = m.configManager.UpdatePRStatus(m.repoPath, result.branch, result.prURL, "merged")
+				_ = m.configManager.SetPRAutoMerge(m.repoPath, result.branch, result.prURL, "")
+
+				m.debugLog(fmt.Sprintf("Auto-merge completed for %s (branch: %s)", result.prURL, result.branch))
+				m.localMergeBranch = result.branch
+				m.localMergeTarget = m.baseBranch
+				m.localMergeWorktree = result.worktreePath
+				m.postMergeDeleteIndex = 0 // Default to delete option
+				m.modal = postMergeCleanupModal
+				cleanupOffered = true
+
+			case "closed":
+				_ = m.configManager.UpdatePRStatus(m.repoPath, result.branch, result.prURL, "closed")
+				_ = m.configManager.SetPRAutoMerge(m.repoPath, result.branch, result.prURL, "")
+				cmds = append(cmds, m.showWarningNotification("PR for "+result.branch+" was closed without merging. Auto-merge cancelled."))
+			}
+		}
+
+		cmds = append(cmds, m.loadWorktrees(), m.scheduleAutoMergeCheck())
+		return m, tea.Batch(cmds...)
 	}
 
 	return m, cmd
@@ -1442,6 +1886,7 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "r":
 		// Refresh: pull latest commits, refresh PR statuses, and load PR details for all worktrees
 		cmd = m.showInfoNotification("Pulling latest commits and refreshing...")
+		m.refreshing = true
 		return m, tea.Batch(cmd, m.refreshWithPull(), m.refreshPRStatuses(), m.loadPRDetailsForAllWorktrees(), m.checkSessionActivity())
 
 	case "n":
@@ -1476,7 +1921,11 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "d":
 		// Open delete modal
-		if wt := m.selectedWorktree(); wt != nil && !wt.IsCurrent {
+		if wt := m.selectedWorktree(); wt != nil && wt.Locked {
+			return m, m.showWarningNotification("Worktree is locked, press 'W' to unlock it first")
+		} else if wt != nil && wt.Prunable {
+			return m, m.showWarningNotification("Worktree directory is missing, press 'W' to repair or prune it")
+		} else if wt != nil && !wt.Protected {
 			// Check for uncommitted changes
 			hasUncommitted, err := m.gitManager.HasUncommittedChanges(wt.Path)
 			if err != nil {
@@ -1490,6 +1939,8 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		} else if wt != nil && wt.IsCurrent {
 			return m, m.showWarningNotification("Cannot delete current worktree")
+		} else if wt != nil {
+			return m, m.showWarningNotification("Cannot delete the default branch worktree or the one jean was launched from")
 		}
 
 	case "enter":
@@ -1499,15 +1950,13 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.configManager != nil {
 				_ = m.configManager.SetLastSelectedBranch(m.repoPath, wt.Branch)
 			}
-			// Check if this Claude session has been initialized before
-			isInitialized := false
-			if m.configManager != nil {
-				isInitialized = m.configManager.IsClaudeInitialized(m.repoPath, wt.Branch)
+			// Check if this worktree's agent has been started before (so it resumes instead of starting fresh)
+			// Tracked like the session name, so a detached worktree's agent resumes after checking out another commit
+			sessionBranch := worktreeSessionBranch(*wt)
+			agentCommand, isInitialized := m.agentCommand(wt.Path, sessionBranch)
+			if m.configManager != nil && m.autoClaude && !isInitialized {
 				// Mark this branch as initialized for next time
-				// (so next run will use --continue instead of plain claude)
-				if m.autoClaude && !isInitialized {
-					_ = m.configManager.SetClaudeInitialized(m.repoPath, wt.Branch)
-				}
+				_ = m.configManager.SetAgentInitialized(m.repoPath, sessionBranch, m.agentProfile(sessionBranch).Name)
 			}
 			// Store pending switch info and ensure worktree exists
 			// SessionName includes repo basename for uniqueness across repositories (e.g., jean-reponame-branch)
@@ -1517,7 +1966,8 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				SessionName:          wt.ClaudeSessionName, // Pre-sanitized session name with repo basename
 				AutoClaude:           m.autoClaude,
 				TargetWindow:         "claude", // Attach to Claude window
-				IsClaudeInitialized:  isInitialized,
+				IsAgentInitialized:   isInitialized,
+				AgentCommand:         agentCommand,
 			}
 			m.ensuringWorktree = true
 			cmd = m.showInfoNotification("Preparing workspace...")
@@ -1527,10 +1977,13 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "B":
 		// Rename current branch (Shift+B)
 		if wt := m.selectedWorktree(); wt != nil {
-			// Check if this is a workspace worktree (in .workspaces directory)
-			if !strings.Contains(wt.Path, ".workspaces") {
+			// Check if this is a workspace worktree (created by jean, not the main checkout)
+			if !m.gitManager.IsWorkspacePath(wt.Path) {
 				return m, m.showWarningNotification("Cannot rename main branch. Only workspace branches can be renamed.")
 			}
+			if wt.Detached {
+				return m, m.detachedWarning("rename")
+			}
 
 			// Check if this branch has PRs
 			if m.configManager != nil && m.configManager.HasPRs(m.repoPath, wt.Branch) {
@@ -1619,6 +2072,10 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.settingsIndex = 0
 		return m, nil
 
+	case "T":
+		// Open transcripts modal (Shift+T)
+		return m, m.openTranscripts()
+
 	case "S":
 		// Open session list modal (Shift+S)
 		m.modal = sessionListModal
@@ -1635,9 +2092,12 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 
 			// Don't allow pull on main worktree
-			if !strings.Contains(wt.Path, ".workspaces") {
+			if !m.gitManager.IsWorkspacePath(wt.Path) {
 				return m, m.showWarningNotification("Cannot pull on main worktree. Use 'git pull' manually.")
 			}
+			if wt.Detached {
+				return m, m.detachedWarning("update")
+			}
 
 			// Fetch and check for updates (don't rely on cached status)
 			cmd = m.showInfoNotification("Checking for updates...")
@@ -1647,6 +2107,9 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "p":
 		// Push branch to remote (with AI branch naming) - lowercase p
 		if wt := m.selectedWorktree(); wt != nil {
+			if wt.Detached {
+				return m, m.detachedWarning("push")
+			}
 			// First check if there are uncommitted changes
 			hasUncommitted, err := m.gitManager.HasUncommittedChanges(wt.Path)
 			if err != nil {
@@ -1702,17 +2165,18 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "P":
-		// Create new PR on GitHub (Shift+P)
+		// Create new PR/MR on the repository's forge (Shift+P)
 		if wt := m.selectedWorktree(); wt != nil {
+			if wt.Detached {
+				return m, m.detachedWarning("create a PR from")
+			}
 			// Check if a PR already exists for this branch
 			if m.configManager != nil {
 				existingPR := m.configManager.GetLatestPR(m.repoPath, wt.Branch)
 				if existingPR != nil && existingPR.Status == "open" {
 					m.debugLog(fmt.Sprintf("P keybinding: found existing PR #%d for branch %s, opening in browser", existingPR.PRNumber, wt.Branch))
 					// Open the existing PR in the browser
-					cmd := exec.Command("gh", "pr", "view", existingPR.URL, "--web")
-					err := cmd.Start()
-					if err != nil {
+					if err := git.OpenInBrowser(existingPR.URL); err != nil {
 						return m, m.showErrorNotification("Failed to open PR in browser: "+err.Error(), 3*time.Second)
 					}
 					notifyMsg := fmt.Sprintf("PR #%d already exists for this branch. Opening in browser...", existingPR.PRNumber)
@@ -1728,7 +2192,7 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "N":
-		// Create worktree from existing PR on GitHub (Shift+N)
+		// Create worktree from existing PR/MR on the repository's forge (Shift+N)
 		m.debugLog("N keybinding pressed - opening PR list modal to create worktree from PR")
 		m.modal = prListModal
 		m.prListIndex = 0
@@ -1749,10 +2213,14 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 
 			// Safety check: only allow merge from workspace worktrees (not main repo)
-			if !strings.Contains(wt.Path, ".workspaces") {
+			if !m.gitManager.IsWorkspacePath(wt.Path) {
 				return m, m.showWarningNotification("Can only merge workspace worktrees. Use 'git merge' manually in main repo.")
 			}
 
+			if wt.Detached {
+				return m, m.detachedWarning("merge")
+			}
+
 			// Safety check: cannot merge base branch into itself
 			if wt.Branch == m.baseBranch {
 				return m, m.showWarningNotification("Cannot merge base branch into itself")
@@ -1776,6 +2244,9 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "c":
 		// Commit changes
 		if wt := m.selectedWorktree(); wt != nil {
+			if wt.Detached {
+				return m, m.detachedWarning("commit in")
+			}
 			// Check if worktree has uncommitted changes
 			hasUncommitted, err := m.gitManager.HasUncommittedChanges(wt.Path)
 			if err != nil {
@@ -1819,9 +2290,7 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if len(prs) == 1 {
 					// Only one PR - open it directly
 					m.debugLog(fmt.Sprintf("v keybinding: opening single PR %s", prs[0].URL))
-					cmd := exec.Command("gh", "pr", "view", prs[0].URL, "--web")
-					err := cmd.Start()
-					if err != nil {
+					if err := git.OpenInBrowser(prs[0].URL); err != nil {
 						return m, m.showErrorNotification("Failed to open PR in browser: "+err.Error(), 3*time.Second)
 					}
 					return m, m.showSuccessNotification("Opening PR in browser...", 2*time.Second)
@@ -1835,12 +2304,12 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.prListIndex = len(prs) - 1  // Default to most recent
 					m.prSearchInput.SetValue("")
 					m.prSearchInput.Focus()
-					// Convert config.PRInfo to github.PRInfo for display
-					m.prs = make([]github.PRInfo, len(prs))
+					// Convert config.PRInfo to forge.PRInfo for display
+					m.prs = make([]forge.PRInfo, len(prs))
 					for i, pr := range prs {
 						m.debugLog(fmt.Sprintf("v keybinding: PR[%d] #%d %s - %s", i, pr.PRNumber, pr.Title, pr.URL))
-						// Convert config.PRInfo to github.PRInfo for modal display
-						m.prs[i] = github.PRInfo{
+						// Convert config.PRInfo to forge.PRInfo for modal display
+						m.prs[i] = forge.PRInfo{
 							Number:      pr.PRNumber,
 							Title:       pr.Title, // Now we have the title from config
 							URL:         pr.URL,
@@ -1881,6 +2350,61 @@ func (m Model) handleMainInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case "C":
+		// Clean up worktrees whose work has landed (Shift+C)
+		cmd = m.showInfoNotification("Looking for worktrees to clean up...")
+		return m, tea.Batch(cmd, m.scanCleanupCandidates())
+
+	case "A":
+		// Choose the agent started in this worktree's session (Shift+A)
+		if wt := m.selectedWorktree(); wt != nil {
+			m.openAgentSelect(wt.Branch)
+			return m, nil
+		}
+
+	case "i":
+		// Send a prompt to the worktree's Claude session without attaching
+		if wt := m.selectedWorktree(); wt != nil {
+			state := m.agentStateFor(*wt)
+			if state == session.AgentStateNone || state == session.AgentStateExited {
+				cmd = m.showWarningNotification("No Claude session running for " + wt.Branch + " (press Enter to start one)")
+				return m, cmd
+			}
+			cmd = m.openPromptComposer(*wt)
+			return m, cmd
+		}
+
+	case "D":
+		// Show the disk space taken by each worktree (Shift+D)
+		cmd = m.showInfoNotification("Measuring disk usage...")
+		return m, tea.Batch(cmd, m.measureDiskUsage())
+
+	case "R":
+		// Create a detached worktree at a tag, commit, or PR for reviewing or bisecting (Shift+R)
+		m.modal = detachedWorktreeModal
+		m.detachedRefInput.SetValue("")
+		m.detachedRefInput.Focus()
+		return m, textinput.Blink
+
+	case "W":
+		// Worktree maintenance: promote, lock, unlock, repair, and prune (Shift+W)
+		if wt := m.selectedWorktree(); wt != nil {
+			m.maintenanceWorktree = *wt
+			m.maintenanceIndex = 0
+			m.maintenanceAction = ""
+			m.modal = worktreeMaintenanceModal
+		}
+		return m, nil
+
+	case "I":
+		// Preview and resync the jean.json include files of the worktree from the root checkout (Shift+I)
+		if wt := m.selectedWorktree(); wt != nil {
+			if !m.gitManager.IsWorkspacePath(wt.Path) {
+				return m, m.showWarningNotification("Includes come from the main checkout, select a workspace worktree")
+			}
+			return m, m.planIncludes(*wt)
+		}
+
 	case "h":
 		// Open help modal
 		m.modal = helperModal
@@ -1964,6 +2488,45 @@ func (m Model) handleModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case gitInitModal:
 		return m.handleGitInitModalInput(msg)
 
+	case cleanupModal:
+		return m.handleCleanupModalInput(msg)
+
+	case prDescriptionSyncModal:
+		return m.handlePRDescriptionSyncModalInput(msg)
+
+	case remotesModal:
+		return m.handleRemotesModalInput(msg)
+
+	case promptComposerModal:
+		return m.handlePromptComposerModalInput(msg)
+
+	case agentSelectModal:
+		return m.handleAgentSelectModalInput(msg)
+
+	case restoreSessionsModal:
+		return m.handleRestoreSessionsModalInput(msg)
+
+	case transcriptsModal:
+		return m.handleTranscriptsModalInput(msg)
+
+	case worktreeLocationModal:
+		return m.handleWorktreeLocationModalInput(msg)
+
+	case branchNamingModal:
+		return m.handleBranchNamingModalInput(msg)
+
+	case includeModal:
+		return m.handleIncludeModalInput(msg)
+
+	case diskUsageModal:
+		return m.handleDiskUsageModalInput(msg)
+
+	case worktreeMaintenanceModal:
+		return m.handleWorktreeMaintenanceModalInput(msg)
+
+	case detachedWorktreeModal:
+		return m.handleDetachedWorktreeModalInput(msg)
+
 	case helperModal:
 		return m.handleHelperModalInput(msg)
 	}
@@ -2215,21 +2778,29 @@ func (m Model) handleCreateWithNameModalInput(msg tea.KeyMsg) (tea.Model, tea.Cm
 			// Create button
 			sessionName := m.sessionNameInput.Value()
 
-			// If empty, generate a random name
+			var sanitizedName string
 			if sessionName == "" {
+				// If empty, generate a random name (from the branch name template, if any)
 				randomName, err := m.gitManager.GenerateRandomName()
 				if err != nil {
 					cmd := m.showWarningNotification("Failed to generate random name")
 					return m, cmd
 				}
-				sessionName = randomName
-			}
+				sanitizedName = randomName
+			} else {
+				// Sanitize the session name to ensure it's a valid branch name
+				sanitizedName = m.branchNameFromInput(sessionName)
+				if sanitizedName == "" {
+					cmd := m.showWarningNotification("Session name contains no valid characters")
+					return m, cmd
+				}
 
-			// Sanitize the session name to ensure it's a valid branch name
-			sanitizedName := m.sessionManager.SanitizeBranchName(sessionName)
-			if sanitizedName == "" {
-				cmd := m.showWarningNotification("Session name contains no valid characters")
-				return m, cmd
+				// Typed names must follow the repository's naming convention
+				if err := m.gitManager.ValidateBranchName(sanitizedName); err != nil {
+					m.modalFocused = 0
+					m.sessionNameInput.Focus()
+					return m, m.showWarningNotification(err.Error())
+				}
 			}
 
 			// Generate path from sanitized session name
@@ -2296,7 +2867,7 @@ func (m Model) handleDeleteModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.modalFocused == 0 || msg.String() == "y" {
 				if wt := m.selectedWorktree(); wt != nil {
 					m.modal = noModal
-					return m, m.deleteWorktree(wt.Path, wt.Branch, true) // force = true
+					return m, m.deleteWorktree(wt.Path, worktreeSessionBranch(*wt), true) // force = true
 				}
 			}
 			m.modal = noModal
@@ -2306,7 +2877,7 @@ func (m Model) handleDeleteModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.modalFocused == 0 || msg.String() == "y" {
 				if wt := m.selectedWorktree(); wt != nil {
 					m.modal = noModal
-					return m, m.deleteWorktree(wt.Path, wt.Branch, false)
+					return m, m.deleteWorktree(wt.Path, worktreeSessionBranch(*wt), false)
 				}
 			}
 			m.modal = noModal
@@ -2342,6 +2913,21 @@ func (m Model) handleBranchSelectModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 func (m Model) handleCheckoutBranchModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	config := searchModalConfig{
 		onConfirm: func(m Model, branch string) (tea.Model, tea.Cmd) {
+			if m.gitManager.IsBare() {
+				// No main checkout to switch: select the branch's worktree, or create one
+				local := strings.TrimPrefix(branch, "origin/")
+				for i, wt := range m.worktrees {
+					if wt.Branch == local {
+						m.selectedIndex = i
+						return m, m.showInfoNotification("Branch is checked out in " + wt.Path)
+					}
+				}
+				path, err := m.gitManager.GetDefaultPath(branch)
+				if err != nil {
+					return m, m.showWarningNotification("Failed to generate workspace path")
+				}
+				return m, tea.Batch(m.showInfoNotification("Creating worktree for branch: "+branch), m.createWorktree(path, branch, false))
+			}
 			cmd := m.showInfoNotification("Checking out branch: " + branch)
 			return m, tea.Batch(cmd, m.checkoutBranch(branch))
 		},
@@ -2373,12 +2959,46 @@ func (m Model) handleSessionListModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 			return m, nil
 		},
 		onCustomKey: func(m Model, key string) (tea.Model, tea.Cmd) {
+			if key == "l" && m.sessionIndex >= 0 && m.sessionIndex < len(m.sessions) {
+				// Recreate the selected session from the jean.json layout
+				sess := m.sessions[m.sessionIndex]
+				if strings.HasSuffix(sess.Name, "-terminal") {
+					return m, m.showWarningNotification("Only worktree sessions have a layout")
+				}
+				if sess.Active {
+					return m, m.showWarningNotification("Detach from " + sess.Branch + " before resetting its layout")
+				}
+				return m, tea.Batch(m.showInfoNotification("Resetting layout..."), m.resetSessionLayout(sess))
+			}
+			if key == "r" {
+				// Offer to restore recorded sessions that are no longer running
+				restorable := m.findRestorableSessions()
+				if len(restorable) == 0 {
+					return m, m.showInfoNotification("No sessions to restore")
+				}
+				m.openRestoreSessions(restorable)
+				return m, nil
+			}
+			if key == "c" && m.sessionIndex >= 0 && m.sessionIndex < len(m.sessions) {
+				// Capture the agent window of the selected session into its branch's transcript
+				sess := m.sessions[m.sessionIndex]
+				branch := m.sessionBranch(sess)
+				if err := m.captureTranscript(sess.Name, branch, "manual"); err != nil {
+					return m, m.showErrorNotification("Failed to capture transcript: "+err.Error(), 3*time.Second)
+				}
+				return m, m.showSuccessNotification("Transcript captured for "+branch+" (T to view)", 3*time.Second)
+			}
 			if key == "d" && m.sessionIndex >= 0 && m.sessionIndex < len(m.sessions) {
-				// Kill selected session
+				// Kill selected session, keeping its agent's transcript
 				sess := m.sessions[m.sessionIndex]
+				_ = m.captureTranscript(sess.Name, m.sessionBranch(sess), "kill") // Ignore error if there is no agent window
 				if err := m.sessionManager.Kill(sess.Name); err != nil {
 					return m, m.showErrorNotification("Failed to kill session", 3*time.Second)
 				} else {
+					// A session killed on purpose is not offered for restoring
+					if m.configManager != nil {
+						_ = m.configManager.RemoveSessionRecord(m.repoPath, sess.Name)
+					}
 					// Batch notification with session reload
 					return m, tea.Batch(
 						m.showSuccessNotification("Session killed", 3*time.Second),
@@ -2492,7 +3112,7 @@ func (m Model) handleChangeBaseBranchModalInput(msg tea.KeyMsg) (tea.Model, tea.
 			}
 			m.modal = settingsModal
 			m.settingsIndex = 2
-			return m, cmd
+			return m, tea.Batch(cmd, m.syncWatcher())
 		},
 		onCancel: func(m Model) (tea.Model, tea.Cmd) {
 			// Return to settings modal
@@ -2589,34 +3209,67 @@ func (m Model) handleCommitModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// PR content modal focus order
+const (
+	prFieldTitle = iota
+	prFieldDescription
+	prFieldReviewers
+	prFieldLabels
+	prFieldAssignees
+	prFieldMilestone
+	prButtonCreate
+	prButtonCancel
+	prModalFieldCount
+)
+
+// prContentInputs returns the PR content modal text inputs in focus order
+func (m *Model) prContentInputs() []*textinput.Model {
+	return []*textinput.Model{
+		&m.prTitleInput,
+		&m.prDescriptionInput,
+		&m.prReviewersInput,
+		&m.prLabelsInput,
+		&m.prAssigneesInput,
+		&m.prMilestoneInput,
+	}
+}
+
+// focusPRContentField focuses the given PR content modal field and blurs the others
+func (m *Model) focusPRContentField(field int) {
+	m.prModalFocused = field
+	for i, input := range m.prContentInputs() {
+		if i == field {
+			input.Focus()
+		} else {
+			input.Blur()
+		}
+	}
+}
+
 func (m Model) handlePRContentModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		m.modal = noModal
-		m.prTitleInput.Blur()
-		m.prDescriptionInput.Blur()
+		m.focusPRContentField(prButtonCancel)
 		return m, nil
 
 	case "tab", "shift+tab":
-		// Cycle through: title input -> description input -> create button -> cancel button
-		m.prModalFocused = (m.prModalFocused + 1) % 4
+		// Cycle through: title -> description -> reviewers -> labels -> assignees -> milestone -> create -> cancel
+		m.focusPRContentField((m.prModalFocused + 1) % prModalFieldCount)
+		return m, nil
 
-		// Update focus state
-		if m.prModalFocused == 0 {
-			m.prTitleInput.Focus()
-			m.prDescriptionInput.Blur()
-		} else if m.prModalFocused == 1 {
-			m.prTitleInput.Blur()
-			m.prDescriptionInput.Focus()
-		} else {
-			m.prTitleInput.Blur()
-			m.prDescriptionInput.Blur()
+	case "ctrl+o":
+		// Add the reviewers suggested by CODEOWNERS
+		if m.prModalFocused == prFieldReviewers && len(m.prSuggestedReviewers) > 0 {
+			reviewers := splitList(m.prReviewersInput.Value() + "," + strings.Join(m.prSuggestedReviewers, ","))
+			m.prReviewersInput.SetValue(strings.Join(reviewers, ", "))
+			m.prReviewersInput.CursorEnd()
+			return m, nil
 		}
-		return m, nil
 
 	case "g":
 		// Generate AI PR content (only if not focused on input fields and API key is configured)
-		if m.prModalFocused > 1 && m.configManager != nil && m.configManager.GetOpenRouterAPIKey() != "" {
+		if m.prModalFocused >= prButtonCreate && m.configManager != nil && m.configManager.GetOpenRouterAPIKey() != "" {
 			m.generatingPRContent = true
 			m.prSpinnerFrame = 0
 			return m, tea.Batch(
@@ -2624,21 +3277,37 @@ func (m Model) handlePRContentModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.generatePRContent(m.prModalWorktreePath, m.prModalBranch, m.baseBranch),
 			)
 		}
-		// If in input field (prModalFocused 0 or 1), fall through to handle text input
+		// If in an input field, fall through to handle text input
+
+	case "t":
+		// Switch the PR template used for AI generation
+		if m.prModalFocused >= prButtonCreate && len(m.prTemplates) > 1 {
+			m.prTemplateIndex = (m.prTemplateIndex + 1) % len(m.prTemplates)
+			return m, nil
+		}
+
+	case "d":
+		// Save reviewers, labels, assignees, and milestone as this repo's defaults
+		if m.prModalFocused >= prButtonCreate && m.configManager != nil {
+			meta := m.prMetadataFromInputs()
+			defaults := config.PRDefaults{
+				Reviewers: meta.Reviewers,
+				Labels:    meta.Labels,
+				Assignees: meta.Assignees,
+				Milestone: meta.Milestone,
+			}
+			if err := m.configManager.SetPRDefaults(m.repoPath, defaults); err != nil {
+				return m, m.showErrorNotification("Failed to save PR defaults: "+err.Error(), 3*time.Second)
+			}
+			return m, m.showSuccessNotification("Saved as default reviewers, labels, assignees, and milestone", 2*time.Second)
+		}
 
 	case "enter":
-		if m.prModalFocused == 0 {
-			// In title input, move to description
-			m.prModalFocused = 1
-			m.prTitleInput.Blur()
-			m.prDescriptionInput.Focus()
-			return m, nil
-		} else if m.prModalFocused == 1 {
-			// In description input, move to create button
-			m.prModalFocused = 2
-			m.prDescriptionInput.Blur()
+		if m.prModalFocused < prButtonCreate {
+			// In an input field, move to the next one (the last one moves to the create button)
+			m.focusPRContentField(m.prModalFocused + 1)
 			return m, nil
-		} else if m.prModalFocused == 2 {
+		} else if m.prModalFocused == prButtonCreate {
 			// Create button
 			title := m.prTitleInput.Value()
 			description := m.prDescriptionInput.Value()
@@ -2649,30 +3318,30 @@ func (m Model) handlePRContentModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 
-			// Create the PR
+			// Create the PR with the reviewers, labels, assignees, and milestone from the modal
+			meta := m.prMetadataFromInputs()
+			m.prMetadata = &meta
 			cmd := m.showInfoNotification("Creating draft PR...")
 			m.modal = noModal
-			m.prTitleInput.Blur()
-			m.prDescriptionInput.Blur()
+			m.focusPRContentField(prButtonCreate)
 			return m, tea.Batch(
 				cmd,
-				m.createPR(m.prModalWorktreePath, m.prModalBranch, title, description),
+				// Only mark the description as generated if it's still the one AI generated
+				m.createPR(m.prModalWorktreePath, m.prModalBranch, title, description, description != "" && description == m.prGeneratedDescription),
 			)
 		} else {
-			// Cancel button (prModalFocused == 3)
+			// Cancel button
 			m.modal = noModal
-			m.prTitleInput.Blur()
-			m.prDescriptionInput.Blur()
+			m.focusPRContentField(prButtonCancel)
 			return m, nil
 		}
 	}
 
 	// Handle text input
 	var cmd tea.Cmd
-	if m.prModalFocused == 0 {
-		m.prTitleInput, cmd = m.prTitleInput.Update(msg)
-	} else if m.prModalFocused == 1 {
-		m.prDescriptionInput, cmd = m.prDescriptionInput.Update(msg)
+	if m.prModalFocused < prButtonCreate {
+		input := m.prContentInputs()[m.prModalFocused]
+		*input, cmd = input.Update(msg)
 	}
 
 	return m, cmd
@@ -2756,9 +3425,7 @@ func (m Model) handlePRListModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.debugLog(fmt.Sprintf("handlePRListModalInput: VIEW MODE - opening selected PR in browser: %s", selectedPR.URL))
 			m.modal = noModal
 			m.prListViewMode = false
-			cmd := exec.Command("gh", "pr", "view", selectedPR.URL, "--web")
-			err := cmd.Start()
-			if err != nil {
+			if err := git.OpenInBrowser(selectedPR.URL); err != nil {
 				return m, m.showErrorNotification("Failed to open PR in browser: "+err.Error(), 3*time.Second)
 			}
 			return m, m.showSuccessNotification("Opening PR in browser...", 2*time.Second)
@@ -2825,6 +3492,26 @@ func (m Model) handlePRListModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// mergeOption is an entry in the merge strategy modal
+type mergeOption struct {
+	name        string
+	description string
+	action      string // "merge" (immediate), "auto", "queue", or "disable-auto"
+	method      string // "squash", "merge", or "rebase" (unused for "queue" and "disable-auto")
+}
+
+// mergeOptions lists the merge strategy modal entries in display order
+var mergeOptions = []mergeOption{
+	{"Squash and merge", "All commits squashed into one commit on the base branch", "merge", "squash"},
+	{"Create a merge commit", "Preserves all commits from the PR in the history with a merge commit", "merge", "merge"},
+	{"Rebase and merge", "Replays commits from PR onto base branch without a merge commit", "merge", "rebase"},
+	{"Enable auto-merge (squash)", "Squash and merge automatically once required checks pass", "auto", "squash"},
+	{"Enable auto-merge (merge commit)", "Merge with a merge commit automatically once required checks pass", "auto", "merge"},
+	{"Enable auto-merge (rebase)", "Rebase and merge automatically once required checks pass", "auto", "rebase"},
+	{"Add to merge queue", "Queue the PR so it merges after passing checks against the latest base branch", "queue", ""},
+	{"Disable auto-merge", "Cancel a pending auto-merge or remove the PR from the merge queue", "disable-auto", ""},
+}
+
 func (m Model) handleMergeStrategyModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -2839,7 +3526,7 @@ func (m Model) handleMergeStrategyModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd
 		return m, nil
 
 	case "down":
-		if m.mergeStrategyCursor < 2 {
+		if m.mergeStrategyCursor < len(mergeOptions)-1 {
 			m.mergeStrategyCursor++
 		}
 		return m, nil
@@ -2851,9 +3538,8 @@ func (m Model) handleMergeStrategyModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd
 			return m, m.showErrorNotification("No PR selected for merge", 3*time.Second)
 		}
 
-		// Get the selected merge method
-		mergeStrategies := []string{"squash", "merge", "rebase"}
-		mergeMethod := mergeStrategies[m.mergeStrategyCursor]
+		// Get the selected merge option
+		option := mergeOptions[m.mergeStrategyCursor]
 
 		// Get the selected worktree to determine if PR is draft
 		wt := m.selectedWorktree()
@@ -2883,10 +3569,23 @@ func (m Model) handleMergeStrategyModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd
 			return m, m.showErrorNotification("PR not found", 3*time.Second)
 		}
 
+		m.modal = noModal
+		m.selectedPRForMerge = ""
+
+		// Disabling auto-merge doesn't care about draft state
+		if option.action == "disable-auto" {
+			if selectedPR.AutoMerge == "" {
+				return m, m.showInfoNotification("Auto-merge is not enabled for this PR")
+			}
+			notifyCmd := m.showInfoNotification("⏳ Disabling auto-merge...")
+			return m, tea.Batch(
+				notifyCmd,
+				m.updateAutoMerge(wt.Path, wt.Branch, selectedPR.URL, option.action, option.method),
+			)
+		}
+
 		// Check PR status - if draft, mark as ready first
 		if selectedPR.Status == "draft" {
-			m.modal = noModal
-			m.selectedPRForMerge = ""
 			notifyCmd := m.showInfoNotification("⏳ Marking PR as ready...")
 			return m, tea.Batch(
 				notifyCmd,
@@ -2894,13 +3593,26 @@ func (m Model) handleMergeStrategyModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd
 			)
 		}
 
+		switch option.action {
+		case "auto":
+			notifyCmd := m.showInfoNotification("⏳ Enabling auto-merge with " + option.method + " strategy...")
+			return m, tea.Batch(
+				notifyCmd,
+				m.updateAutoMerge(wt.Path, wt.Branch, selectedPR.URL, option.action, option.method),
+			)
+		case "queue":
+			notifyCmd := m.showInfoNotification("⏳ Adding PR to merge queue...")
+			return m, tea.Batch(
+				notifyCmd,
+				m.updateAutoMerge(wt.Path, wt.Branch, selectedPR.URL, option.action, option.method),
+			)
+		}
+
 		// PR is already ready/open - proceed directly to merge
-		m.modal = noModal
-		m.selectedPRForMerge = ""
-		notifyCmd := m.showInfoNotification("⏳ Merging PR with " + mergeMethod + " strategy...")
+		notifyCmd := m.showInfoNotification("⏳ Merging PR with " + option.method + " strategy...")
 		return m, tea.Batch(
 			notifyCmd,
-			m.mergePR(wt.Path, selectedPR.URL, mergeMethod),
+			m.mergePR(wt.Path, selectedPR.URL, option.method),
 		)
 	}
 
@@ -3017,6 +3729,286 @@ func (m Model) handlePostMergeCleanupModalInput(msg tea.KeyMsg) (tea.Model, tea.
 	return m, nil
 }
 
+func (m Model) handleRemotesModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.remotesConfirmFork {
+		switch msg.String() {
+		case "y", "enter":
+			m.modal = noModal
+			m.remotesConfirmFork = false
+			cmd := m.showInfoNotification("Creating fork...")
+			return m, tea.Batch(cmd, m.createFork(m.gitManager.PushRemote()))
+
+		case "n", "esc":
+			// Keep the settings; pushing fails until the remote is added by hand
+			m.modal = noModal
+			m.remotesConfirmFork = false
+			return m, m.showWarningNotification("Remote '" + m.gitManager.PushRemote() + "' does not exist yet")
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.modal = settingsModal
+		return m, nil
+
+	case "tab", "shift+tab", "up", "down":
+		// Switch between the push and upstream remote inputs
+		m.remotesFocused = 1 - m.remotesFocused
+		if m.remotesFocused == 0 {
+			m.pushRemoteInput.Focus()
+			m.upstreamRemoteInput.Blur()
+		} else {
+			m.pushRemoteInput.Blur()
+			m.upstreamRemoteInput.Focus()
+		}
+		return m, nil
+
+	case "enter":
+		pushRemote := strings.TrimSpace(m.pushRemoteInput.Value())
+		upstreamRemote := strings.TrimSpace(m.upstreamRemoteInput.Value())
+		// Leave both empty ("origin") when they're the same remote
+		if pushRemote == upstreamRemote {
+			pushRemote, upstreamRemote = "", ""
+		}
+		if m.configManager != nil {
+			if err := m.configManager.SetRemotes(m.repoPath, pushRemote, upstreamRemote); err != nil {
+				return m, m.showErrorNotification("Failed to save remotes: " + err.Error(), 3*time.Second)
+			}
+		}
+		m.gitManager.SetRemotes(pushRemote, upstreamRemote)
+
+		// Offer to create the fork if the push remote doesn't exist yet
+		if m.gitManager.IsForkWorkflow() {
+			remotes, _ := m.gitManager.ListRemotes()
+			exists := false
+			for _, remote := range remotes {
+				if remote == m.gitManager.PushRemote() {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				m.remotesConfirmFork = true
+				return m, nil
+			}
+		}
+
+		m.modal = noModal
+		return m, tea.Batch(
+			m.showSuccessNotification("Remotes saved", 2*time.Second),
+			m.loadWorktrees(),
+		)
+	}
+
+	// Handle text input
+	var cmd tea.Cmd
+	if m.remotesFocused == 0 {
+		m.pushRemoteInput, cmd = m.pushRemoteInput.Update(msg)
+	} else {
+		m.upstreamRemoteInput, cmd = m.upstreamRemoteInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) handlePRDescriptionSyncModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "n":
+		// Skip this update; the description stays as it is until the next push
+		m.modal = noModal
+		return m, nil
+
+	case "enter", "y":
+		m.modal = noModal
+		cmd := m.showInfoNotification("Updating PR description...")
+		return m, tea.Batch(cmd, m.applyPRDescriptionSync())
+
+	case "up", "k":
+		if m.prSyncScroll > 0 {
+			m.prSyncScroll--
+		}
+
+	case "down", "j":
+		if m.prSyncScroll < len(diffLines(m.prSyncOldBody, m.prSyncNewBody))-1 {
+			m.prSyncScroll++
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handlePromptComposerModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc":
+		m.modal = noModal
+		m.promptInput.Blur()
+		return m, nil
+
+	case "ctrl+s":
+		prompt := strings.TrimSpace(m.promptInput.Value())
+		if prompt == "" {
+			return m, nil
+		}
+		targets := m.selectedPromptTargets()
+		if len(targets) == 0 {
+			return m, m.showWarningNotification("Select at least one worktree to send to")
+		}
+		m.modal = noModal
+		m.promptInput.Blur()
+		return m, m.sendPrompt(prompt, targets)
+
+	case "ctrl+b":
+		// Toggle broadcast to several worktrees
+		m.promptBroadcast = !m.promptBroadcast
+		if !m.promptBroadcast && m.promptFocus == 1 {
+			m.promptFocus = 0
+			return m, m.promptInput.Focus()
+		}
+		return m, nil
+
+	case "tab", "shift+tab":
+		// Switch between the prompt and the target list in broadcast mode
+		if !m.promptBroadcast {
+			return m, nil
+		}
+		if m.promptFocus == 0 {
+			m.promptFocus = 1
+			m.promptInput.Blur()
+			return m, nil
+		}
+		m.promptFocus = 0
+		return m, m.promptInput.Focus()
+	}
+
+	if m.promptFocus == 1 {
+		switch msg.String() {
+		case "up", "k":
+			if m.promptTargetCursor > 0 {
+				m.promptTargetCursor--
+			}
+		case "down", "j":
+			if m.promptTargetCursor < len(m.promptTargets)-1 {
+				m.promptTargetCursor++
+			}
+		case " ":
+			if m.promptTargetCursor < len(m.promptTargets) {
+				m.promptTargets[m.promptTargetCursor].selected = !m.promptTargets[m.promptTargetCursor].selected
+			}
+		case "a":
+			// Select all, or clear the selection if everything is already selected
+			all := true
+			for _, t := range m.promptTargets {
+				all = all && t.selected
+			}
+			for i := range m.promptTargets {
+				m.promptTargets[i].selected = !all
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+p":
+		// Older prompt from this branch's history
+		if m.promptHistoryIndex < len(m.promptHistory)-1 {
+			if m.promptHistoryIndex == -1 {
+				m.promptDraft = m.promptInput.Value()
+			}
+			m.promptHistoryIndex++
+			m.promptInput.SetValue(m.promptHistory[m.promptHistoryIndex])
+		}
+		return m, nil
+
+	case "ctrl+n":
+		// Newer prompt, back to the draft after the most recent one
+		if m.promptHistoryIndex >= 0 {
+			m.promptHistoryIndex--
+			if m.promptHistoryIndex == -1 {
+				m.promptInput.SetValue(m.promptDraft)
+			} else {
+				m.promptInput.SetValue(m.promptHistory[m.promptHistoryIndex])
+			}
+		}
+		return m, nil
+	}
+
+	m.promptInput, cmd = m.promptInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleCleanupModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.cleanupConfirming {
+		switch msg.String() {
+		case "esc":
+			// Back to the candidate list
+			m.cleanupConfirming = false
+			return m, nil
+
+		case "enter", "y":
+			m.debugLog(fmt.Sprintf("Cleanup: removing %d worktree(s)", m.countSelectedCleanupCandidates()))
+			cmd := m.showInfoNotification("Removing worktrees...")
+			return m, tea.Batch(cmd, m.removeCleanupCandidates(m.cleanupCandidates))
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.modal = noModal
+		m.cleanupCandidates = nil
+		return m, nil
+
+	case "up":
+		if m.cleanupCursor > 0 {
+			m.cleanupCursor--
+		}
+		return m, nil
+
+	case "down":
+		if m.cleanupCursor < len(m.cleanupCandidates)-1 {
+			m.cleanupCursor++
+		}
+		return m, nil
+
+	case " ":
+		// Toggle the selected candidate
+		if m.cleanupCursor < len(m.cleanupCandidates) {
+			m.cleanupCandidates[m.cleanupCursor].selected = !m.cleanupCandidates[m.cleanupCursor].selected
+		}
+		return m, nil
+
+	case "a":
+		// Select all, or deselect all if everything is already selected
+		selectAll := m.countSelectedCleanupCandidates() < len(m.cleanupCandidates)
+		for i := range m.cleanupCandidates {
+			m.cleanupCandidates[i].selected = selectAll
+		}
+		return m, nil
+
+	case "enter":
+		if m.countSelectedCleanupCandidates() == 0 {
+			return m, m.showWarningNotification("Select at least one worktree to remove")
+		}
+		// Show the summary of what will be deleted before removing anything
+		m.cleanupConfirming = true
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// countSelectedCleanupCandidates returns how many cleanup candidates are selected for removal
+func (m Model) countSelectedCleanupCandidates() int {
+	count := 0
+	for _, c := range m.cleanupCandidates {
+		if c.selected {
+			count++
+		}
+	}
+	return count
+}
+
 func (m Model) handlePRTypeModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -3057,7 +4049,7 @@ func (m Model) handlePRTypeModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		// Create PR with commit message as title and empty description
 		cmd := m.showInfoNotification("Creating draft PR...")
-		return m, tea.Batch(cmd, m.createOrUpdatePR(m.prModalWorktreePath, m.prModalBranch, title, ""))
+		return m, tea.Batch(cmd, m.createOrUpdatePR(m.prModalWorktreePath, m.prModalBranch, title, "", false))
 	}
 
 	return m, nil
@@ -3094,6 +4086,358 @@ func (m Model) handleEditorSelectModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	return m.handleListSelectionModalInput(msg, config)
 }
 
+func (m Model) handleWorktreeLocationModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if len(m.worktreeMigrations) > 0 {
+		switch msg.String() {
+		case "y", "enter":
+			migrations := m.worktreeMigrations
+			m.modal = noModal
+			m.worktreeMigrations = nil
+			cmd := m.showInfoNotification(fmt.Sprintf("Moving %d worktree(s)...", len(migrations)))
+			return m, tea.Batch(cmd, m.migrateWorktrees(migrations))
+
+		case "n", "esc":
+			// Existing worktrees stay where they are, new ones use the new location
+			m.modal = noModal
+			m.worktreeMigrations = nil
+			return m, nil
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.modal = settingsModal
+		m.worktreePathInput.Blur()
+		return m, nil
+
+	case "enter":
+		template := strings.TrimSpace(m.worktreePathInput.Value())
+		if template != "" {
+			if _, err := git.ExpandPathTemplate(template, m.repoPath, "branch"); err != nil {
+				return m, m.showWarningNotification(err.Error())
+			}
+		}
+
+		// Workspaces at the old location, to offer moving them
+		var workspaces []git.Worktree
+		for _, wt := range m.worktrees {
+			if m.gitManager.IsWorkspacePath(wt.Path) {
+				workspaces = append(workspaces, wt)
+			}
+		}
+
+		if m.configManager != nil {
+			if err := m.configManager.SetWorktreePathTemplate(m.repoPath, template); err != nil {
+				return m, m.showErrorNotification("Failed to save worktree location: "+err.Error(), 3*time.Second)
+			}
+			template = m.configManager.GetWorktreePathTemplate(m.repoPath)
+		}
+		m.gitManager.SetPathTemplate(template)
+		m.worktreePathInput.Blur()
+
+		if m.worktreeMigrations = m.plannedWorktreeMigrations(workspaces); len(m.worktreeMigrations) > 0 {
+			return m, nil
+		}
+		m.modal = noModal
+		return m, m.showSuccessNotification("Worktree location saved", 2*time.Second)
+	}
+
+	// Handle text input
+	var cmd tea.Cmd
+	m.worktreePathInput, cmd = m.worktreePathInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleBranchNamingModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.modal = settingsModal
+		return m, nil
+
+	case "tab", "shift+tab", "up", "down":
+		// Switch between the template and pattern inputs
+		m.branchNamingFocused = 1 - m.branchNamingFocused
+		if m.branchNamingFocused == 0 {
+			m.branchTemplateInput.Focus()
+			m.branchPatternInput.Blur()
+		} else {
+			m.branchTemplateInput.Blur()
+			m.branchPatternInput.Focus()
+		}
+		return m, nil
+
+	case "enter":
+		template := strings.TrimSpace(m.branchTemplateInput.Value())
+		pattern := strings.TrimSpace(m.branchPatternInput.Value())
+		if err := m.gitManager.SetBranchNaming(template, pattern); err != nil {
+			return m, m.showWarningNotification(err.Error())
+		}
+		if m.configManager != nil {
+			if err := m.configManager.SetBranchNaming(m.repoPath, template, pattern); err != nil {
+				return m, m.showErrorNotification("Failed to save branch naming: "+err.Error(), 3*time.Second)
+			}
+		}
+		m.modal = noModal
+		return m, m.showSuccessNotification("Branch naming saved", 2*time.Second)
+	}
+
+	// Handle text input
+	var cmd tea.Cmd
+	if m.branchNamingFocused == 0 {
+		m.branchTemplateInput, cmd = m.branchTemplateInput.Update(msg)
+	} else {
+		m.branchPatternInput, cmd = m.branchPatternInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) handleIncludeModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "n", "q":
+		// The preview was a dry run, nothing changed
+		m.modal = noModal
+		m.includeActions = nil
+		return m, nil
+
+	case "enter", "y":
+		actions := m.includeActions
+		m.modal = noModal
+		m.includeActions = nil
+		cmd := m.showInfoNotification("Resyncing includes from the root checkout...")
+		return m, tea.Batch(cmd, m.syncIncludes(m.includeBranch, actions))
+	}
+	return m, nil
+}
+
+func (m Model) handleDiskUsageModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.modal = noModal
+		m.diskUsage = nil
+		return m, nil
+
+	case "up":
+		if m.diskUsageIndex > 0 {
+			m.diskUsageIndex--
+		}
+		return m, nil
+
+	case "down":
+		if m.diskUsageIndex < len(m.diskUsage)-1 {
+			m.diskUsageIndex++
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleWorktreeMaintenanceModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	wt := m.maintenanceWorktree
+
+	// Entering the lock reason or the repaired path
+	if m.maintenanceAction != "" {
+		switch msg.String() {
+		case "esc":
+			m.maintenanceAction = ""
+			m.maintenanceInput.Blur()
+			return m, nil
+
+		case "enter":
+			action := m.maintenanceAction
+			value := strings.TrimSpace(m.maintenanceInput.Value())
+			if action == "repair" && value == "" {
+				return m, m.showWarningNotification("Enter where the worktree is now")
+			}
+			if action == "promote" {
+				if value == "" {
+					return m, m.showWarningNotification("Enter a branch name")
+				}
+				value = m.branchNameFromInput(value)
+			}
+			m.maintenanceAction = ""
+			m.maintenanceInput.Blur()
+			m.modal = noModal
+			return m, m.maintainWorktree(action, wt, value)
+		}
+		var cmd tea.Cmd
+		m.maintenanceInput, cmd = m.maintenanceInput.Update(msg)
+		return m, cmd
+	}
+
+	actions := m.maintenanceActions()
+	switch msg.String() {
+	case "esc", "q":
+		m.modal = noModal
+		return m, nil
+
+	case "up":
+		if m.maintenanceIndex > 0 {
+			m.maintenanceIndex--
+		}
+		return m, nil
+
+	case "down":
+		if m.maintenanceIndex < len(actions)-1 {
+			m.maintenanceIndex++
+		}
+		return m, nil
+
+	case "enter":
+		if m.maintenanceIndex >= len(actions) {
+			return m, nil
+		}
+		switch action := actions[m.maintenanceIndex].id; action {
+		case "lock":
+			m.maintenanceAction = action
+			m.maintenanceInput.Placeholder = "Reason (optional), e.g. on external drive"
+			m.maintenanceInput.SetValue("")
+			m.maintenanceInput.Focus()
+			return m, textinput.Blink
+		case "promote":
+			m.maintenanceAction = action
+			m.maintenanceInput.Placeholder = "Branch name"
+			m.maintenanceInput.SetValue("")
+			m.maintenanceInput.Focus()
+			return m, textinput.Blink
+		case "repair":
+			m.maintenanceAction = action
+			m.maintenanceInput.Placeholder = "Where the worktree is now"
+			m.maintenanceInput.SetValue(wt.Path)
+			m.maintenanceInput.CursorEnd()
+			m.maintenanceInput.Focus()
+			return m, textinput.Blink
+		default:
+			m.modal = noModal
+			return m, m.maintainWorktree(action, wt, "")
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleDetachedWorktreeModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.modal = noModal
+		m.detachedRefInput.Blur()
+		return m, nil
+
+	case "enter":
+		ref := strings.TrimSpace(m.detachedRefInput.Value())
+		if ref == "" {
+			return m, m.showWarningNotification("Enter a tag, commit, or PR number")
+		}
+		m.modal = noModal
+		m.detachedRefInput.Blur()
+		cmd := m.showInfoNotification("Creating worktree at " + ref + "...")
+		return m, tea.Batch(cmd, m.createDetachedWorktree(ref))
+	}
+
+	var cmd tea.Cmd
+	m.detachedRefInput, cmd = m.detachedRefInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleTranscriptsModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.modal = noModal
+		m.transcriptSearch.Blur()
+		return m, nil
+
+	case "up":
+		if m.transcriptIndex > 0 {
+			m.transcriptIndex--
+		}
+		return m, nil
+
+	case "down":
+		if m.transcriptIndex < len(m.filteredTranscripts())-1 {
+			m.transcriptIndex++
+		}
+		return m, nil
+
+	case "enter":
+		// Open the selected transcript in the editor
+		transcripts := m.filteredTranscripts()
+		if m.transcriptIndex >= 0 && m.transcriptIndex < len(transcripts) {
+			return m, m.openInEditor(transcripts[m.transcriptIndex].Path)
+		}
+		return m, nil
+	}
+
+	// Any other key edits the search query
+	var cmd tea.Cmd
+	m.transcriptSearch, cmd = m.transcriptSearch.Update(msg)
+	m.transcriptIndex = 0
+	return m, cmd
+}
+
+func (m Model) handleRestoreSessionsModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		// Keep the records, restoring is offered again next time
+		m.modal = noModal
+		return m, nil
+
+	case "enter":
+		m.modal = noModal
+		cmd := m.showInfoNotification(fmt.Sprintf("Restoring %d session(s)...", len(m.restoreSessions)))
+		return m, tea.Batch(cmd, m.restoreRecordedSessions(m.restoreSessions))
+
+	case "d":
+		// Forget the sessions
+		if m.configManager != nil {
+			for _, sess := range m.restoreSessions {
+				_ = m.configManager.RemoveSessionRecord(m.repoPath, sess.name)
+			}
+		}
+		m.modal = noModal
+		m.restoreSessions = nil
+		return m, m.showInfoNotification("Forgot the missing sessions")
+	}
+
+	return m, nil
+}
+
+func (m Model) handleAgentSelectModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Repository scope returns to settings, worktree scope to the worktree list
+	closeModal := func(m *Model) {
+		if m.agentSelectBranch == "" {
+			m.modal = settingsModal
+			m.settingsIndex = 9
+		} else {
+			m.modal = noModal
+		}
+	}
+
+	config := listSelectionConfig{
+		getCurrentIndex: func() int { return m.agentIndex },
+		getItemCount:    func(m Model) int { return len(m.agentOptions) },
+		incrementIndex:  func(m *Model) { m.agentIndex++ },
+		decrementIndex:  func(m *Model) { m.agentIndex-- },
+		onConfirm: func(m Model) (tea.Model, tea.Cmd) {
+			closeModal(&m)
+			if m.configManager == nil || m.agentIndex < 0 || m.agentIndex >= len(m.agentOptions) {
+				return m, nil
+			}
+			name := m.agentOptions[m.agentIndex]
+			if err := m.configManager.SetAgent(m.repoPath, m.agentSelectBranch, name); err != nil {
+				return m, m.showErrorNotification("Failed to save agent: "+err.Error(), 3*time.Second)
+			}
+			if name == "" {
+				name = m.configManager.GetAgent(m.repoPath, m.agentSelectBranch)
+			}
+			return m, m.showSuccessNotification("Agent set to "+name+" (used the next time the agent window starts)", 3*time.Second)
+		},
+		onCancel: func(m Model) (tea.Model, tea.Cmd) {
+			closeModal(&m)
+			return m, nil
+		},
+	}
+	return m.handleListSelectionModalInput(msg, config)
+}
+
 func (m Model) handleThemeSelectModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc", "q":
@@ -3159,7 +4503,7 @@ func (m Model) handleSettingsModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "down":
-		if m.settingsIndex < 6 { // Now 7 settings (editor, theme, base branch, tmux config, AI integration, debug logs, PR default state)
+		if m.settingsIndex < 16 { // Now 17 settings (editor, theme, base branch, tmux config, AI integration, debug logs, PR default state, PR description sync, remotes, agent, multiplexer, session scrollback, PR transcript summary, worktree location, branch naming, dependency seeding, auto-fetch)
 			m.settingsIndex++
 		}
 
@@ -3205,6 +4549,66 @@ func (m Model) handleSettingsModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		msg = tea.KeyMsg{Type: tea.KeyEnter}
 		return m.handleSettingsModalInput(msg)
 
+	case "y":
+		// Quick key for PR Description Sync
+		m.settingsIndex = 7
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "f":
+		// Quick key for Remotes (fork workflow)
+		m.settingsIndex = 8
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "g":
+		// Quick key for Agent
+		m.settingsIndex = 9
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "m":
+		// Quick key for Multiplexer
+		m.settingsIndex = 10
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "b":
+		// Quick key for Session Scrollback
+		m.settingsIndex = 11
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "r":
+		// Quick key for PR Transcript Summary
+		m.settingsIndex = 12
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "w":
+		// Quick key for Worktree Location
+		m.settingsIndex = 13
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "n":
+		// Quick key for Branch Naming
+		m.settingsIndex = 14
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "s":
+		// Quick key for Dependency Seeding
+		m.settingsIndex = 15
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
+	case "i":
+		// Quick key for Auto-Fetch
+		m.settingsIndex = 16
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+		return m.handleSettingsModalInput(msg)
+
 	case "enter":
 		// Open the selected setting's modal
 		switch m.settingsIndex {
@@ -3307,6 +4711,154 @@ func (m Model) handleSettingsModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
+
+		case 7:
+			// PR Description Sync setting - cycle off → regenerate → append
+			if m.configManager != nil {
+				next := map[string]string{"": "regenerate", "regenerate": "append", "append": ""}[m.configManager.GetPRDescriptionSync(m.repoPath)]
+				if err := m.configManager.SetPRDescriptionSync(m.repoPath, next); err != nil {
+					return m, m.showErrorNotification("Failed to save setting: " + err.Error(), 3*time.Second)
+				}
+			}
+			return m, nil
+
+		case 8:
+			// Remotes setting - open remotes modal with the current push and upstream remotes
+			m.modal = remotesModal
+			m.remotesFocused = 0
+			m.remotesConfirmFork = false
+			pushRemote, upstreamRemote := "", ""
+			if m.configManager != nil {
+				pushRemote, upstreamRemote = m.configManager.GetRemotes(m.repoPath)
+			}
+			m.pushRemoteInput.SetValue(pushRemote)
+			m.upstreamRemoteInput.SetValue(upstreamRemote)
+			m.pushRemoteInput.Focus()
+			m.upstreamRemoteInput.Blur()
+			return m, nil
+
+		case 9:
+			// Agent setting - open agent select modal for the repository default
+			m.openAgentSelect("")
+			return m, nil
+
+		case 10:
+			// Multiplexer setting - cycle through the supported multiplexers
+			next := session.Multiplexers[0]
+			for i, name := range session.Multiplexers {
+				if name == m.sessionManager.Name() {
+					next = session.Multiplexers[(i+1)%len(session.Multiplexers)]
+					break
+				}
+			}
+			if m.configManager != nil {
+				if err := m.configManager.SetMultiplexer(next); err != nil {
+					return m, m.showErrorNotification("Failed to save setting: "+err.Error(), 3*time.Second)
+				}
+			}
+			m.sessionManager = session.NewMultiplexer(next)
+			m.sessions = nil
+			if !m.sessionManager.IsAvailable() {
+				return m, tea.Batch(m.showWarningNotification(next+" is not installed, sessions can't be created until it is"), m.loadSessions())
+			}
+			return m, m.loadSessions()
+
+		case 11:
+			// Session Scrollback setting - toggle periodic scrollback capture
+			if m.configManager != nil {
+				enabled := !m.configManager.GetSessionScrollback()
+				if err := m.configManager.SetSessionScrollback(enabled); err != nil {
+					return m, m.showErrorNotification("Failed to save setting: "+err.Error(), 3*time.Second)
+				}
+				if enabled {
+					return m, m.showSuccessNotification("Session scrollback will be captured and restored", 2*time.Second)
+				}
+				return m, m.showSuccessNotification("Session scrollback capture disabled", 2*time.Second)
+			}
+			return m, nil
+
+		case 12:
+			// PR Transcript Summary setting - toggle for the repository
+			if m.configManager != nil {
+				enabled := !m.configManager.GetPRTranscriptSummary(m.repoPath)
+				if err := m.configManager.SetPRTranscriptSummary(m.repoPath, enabled); err != nil {
+					return m, m.showErrorNotification("Failed to save setting: "+err.Error(), 3*time.Second)
+				}
+				if enabled && m.configManager.GetOpenRouterAPIKey() == "" {
+					return m, m.showWarningNotification("Transcript summaries need AI Integration to be configured")
+				}
+			}
+			return m, nil
+
+		case 13:
+			// Worktree Location setting - open worktree location modal with the repository's template
+			m.modal = worktreeLocationModal
+			m.worktreeMigrations = nil
+			template := ""
+			if m.configManager != nil {
+				template = m.configManager.GetWorktreePathTemplate(m.repoPath)
+			}
+			m.worktreePathInput.SetValue(template)
+			m.worktreePathInput.Focus()
+			return m, textinput.Blink
+
+		case 14:
+			// Branch Naming setting - open branch naming modal with the repository's template and pattern
+			m.modal = branchNamingModal
+			m.branchNamingFocused = 0
+			template, pattern := "", ""
+			if m.configManager != nil {
+				template, pattern = m.configManager.GetBranchNaming(m.repoPath)
+			}
+			m.branchTemplateInput.SetValue(template)
+			m.branchPatternInput.SetValue(pattern)
+			m.branchTemplateInput.Focus()
+			m.branchPatternInput.Blur()
+			return m, textinput.Blink
+
+		case 15:
+			// Dependency Seeding setting - cycle off, reflink, and hardlink for the repository
+			if m.configManager == nil {
+				return m, nil
+			}
+			current := m.configManager.GetDependencySeeding(m.repoPath)
+			next := config.SeedModes[0]
+			for i, mode := range config.SeedModes {
+				if mode == current {
+					next = config.SeedModes[(i+1)%len(config.SeedModes)]
+					break
+				}
+			}
+			if err := m.configManager.SetDependencySeeding(m.repoPath, next); err != nil {
+				return m, m.showErrorNotification("Failed to save setting: "+err.Error(), 3*time.Second)
+			}
+			m.gitManager.SetDependencySeeding(next)
+			return m, nil
+
+		case 16:
+			// Auto-Fetch setting - cycle the background fetch interval for the repository
+			if m.configManager == nil {
+				return m, nil
+			}
+			current := m.configManager.GetAutoFetchInterval(m.repoPath)
+			if current == 0 {
+				current = -1
+			}
+			next := config.AutoFetchIntervals[0]
+			for i, interval := range config.AutoFetchIntervals {
+				if interval == current {
+					next = config.AutoFetchIntervals[(i+1)%len(config.AutoFetchIntervals)]
+					break
+				}
+			}
+			if err := m.configManager.SetAutoFetchInterval(m.repoPath, next); err != nil {
+				return m, m.showErrorNotification("Failed to save setting: "+err.Error(), 3*time.Second)
+			}
+			// Restart the loop with the new interval
+			m.autoFetchGen++
+			m.autoFetchFailures = 0
+			m.offline = false
+			return m, m.scheduleAutoFetch()
 		}
 	}
 
@@ -3598,7 +5150,7 @@ func (m Model) handleTmuxConfigModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Check if config exists to determine button count
 		hasConfig := false
 		if m.sessionManager != nil {
-			installed, _ := m.sessionManager.HasJeanTmuxConfig()
+			installed, _ := m.tmuxManager.HasJeanTmuxConfig()
 			hasConfig = installed
 		}
 
@@ -3617,7 +5169,7 @@ func (m Model) handleTmuxConfigModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		hasConfig, err := m.sessionManager.HasJeanTmuxConfig()
+		hasConfig, err := m.tmuxManager.HasJeanTmuxConfig()
 		if err != nil {
 			m.showErrorNotification("Error checking tmux config: " + err.Error(), 3*time.Second)
 			m.modal = settingsModal
@@ -3629,14 +5181,14 @@ func (m Model) handleTmuxConfigModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			switch m.modalFocused {
 			case 0:
 				// Update button - reinstalls config (remove + add)
-				if err := m.sessionManager.AddJeanTmuxConfig(); err != nil {
+				if err := m.tmuxManager.AddJeanTmuxConfig(); err != nil {
 					m.showErrorNotification("Failed to update tmux config: " + err.Error(), 3*time.Second)
 				} else {
 					m.showSuccessNotification("jean tmux config updated! New tmux sessions will use the updated config.", 3*time.Second)
 				}
 			case 1:
 				// Remove button
-				if err := m.sessionManager.RemoveJeanTmuxConfig(); err != nil {
+				if err := m.tmuxManager.RemoveJeanTmuxConfig(); err != nil {
 					m.showErrorNotification("Failed to remove tmux config: " + err.Error(), 3*time.Second)
 				} else {
 					m.showSuccessNotification("jean tmux config removed. New tmux sessions will use your default config.", 3*time.Second)
@@ -3649,7 +5201,7 @@ func (m Model) handleTmuxConfigModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			switch m.modalFocused {
 			case 0:
 				// Install button
-				if err := m.sessionManager.AddJeanTmuxConfig(); err != nil {
+				if err := m.tmuxManager.AddJeanTmuxConfig(); err != nil {
 					m.showErrorNotification("Failed to add tmux config: " + err.Error(), 3*time.Second)
 				} else {
 					m.showSuccessNotification("jean tmux config installed! New tmux sessions will use this config.", 3*time.Second)
@@ -3724,7 +5276,7 @@ func (m Model) handleOnboardingModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.onboardingFocused == 0 {
 			// Install tmux config
 			return m, func() tea.Msg {
-				err := m.sessionManager.AddJeanTmuxConfig()
+				err := m.tmuxManager.AddJeanTmuxConfig()
 				return tmuxConfigInstalledMsg{err: err}
 			}
 		} else {
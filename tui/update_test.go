@@ -1,10 +1,14 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/coollabsio/jean-tui/config"
+	"github.com/coollabsio/jean-tui/git"
 	"github.com/coollabsio/jean-tui/session"
 )
 
@@ -380,6 +384,331 @@ func TestListSelectionModalInput_BoundaryCheck_Down(t *testing.T) {
 	}
 }
 
+// TestMergeStrategyModal_CursorBounds tests that the cursor covers auto-merge and queue options
+func TestMergeStrategyModal_CursorBounds(t *testing.T) {
+	m := setupTestModel()
+	m.modal = mergeStrategyModal
+	m.mergeStrategyCursor = 0
+
+	var resultModel tea.Model = m
+	for i := 0; i < len(mergeOptions)+2; i++ {
+		resultModel, _ = resultModel.(Model).handleMergeStrategyModalInput(tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	if resultModel.(Model).mergeStrategyCursor != len(mergeOptions)-1 {
+		t.Errorf("Expected cursor %d, got %d", len(mergeOptions)-1, resultModel.(Model).mergeStrategyCursor)
+	}
+	if mergeOptions[len(mergeOptions)-1].action != "disable-auto" {
+		t.Errorf("Expected last option to disable auto-merge, got %s", mergeOptions[len(mergeOptions)-1].action)
+	}
+}
+
+// TestMergeStrategyModal_DisableWithoutAutoMerge tests that disabling is a no-op when nothing is pending
+func TestMergeStrategyModal_DisableWithoutAutoMerge(t *testing.T) {
+	m := setupTestModel()
+	m.modal = mergeStrategyModal
+	m.mergeStrategyCursor = len(mergeOptions) - 1
+	m.selectedPRForMerge = "https://github.com/owner/repo/pull/1"
+	m.worktrees = []git.Worktree{{
+		Path:   "/tmp/repo/.workspaces/feature",
+		Branch: "feature",
+		PRs:    []config.PRInfo{{URL: "https://github.com/owner/repo/pull/1", Status: "open"}},
+	}}
+
+	resultModel, _ := m.handleMergeStrategyModalInput(tea.KeyMsg{Type: tea.KeyEnter})
+	result := resultModel.(Model)
+
+	if result.modal != noModal {
+		t.Errorf("Expected modal to close, got %v", result.modal)
+	}
+	if result.notification == nil || result.notification.Message != "Auto-merge is not enabled for this PR" {
+		t.Errorf("Expected info notification about auto-merge not being enabled, got %+v", result.notification)
+	}
+}
+
+// TestPendingAutoMerge tests the auto-merge indicator state for open and merged PRs
+func TestPendingAutoMerge(t *testing.T) {
+	wt := git.Worktree{PRs: []config.PRInfo{
+		{URL: "https://github.com/owner/repo/pull/1", Status: "merged", AutoMerge: "squash"},
+		{URL: "https://github.com/owner/repo/pull/2", Status: "open", AutoMerge: "queue"},
+	}}
+
+	if state := pendingAutoMerge(wt); state != "queue" {
+		t.Errorf("Expected queue, got %q", state)
+	}
+	if state := pendingAutoMerge(git.Worktree{}); state != "" {
+		t.Errorf("Expected no pending auto-merge, got %q", state)
+	}
+}
+
+// TestCleanupModal_ToggleAndConfirm tests selecting candidates and moving to the deletion summary
+func TestCleanupModal_ToggleAndConfirm(t *testing.T) {
+	m := setupTestModel()
+	m.modal = cleanupModal
+	m.cleanupCandidates = []cleanupCandidate{
+		{worktree: git.Worktree{Branch: "feature-a"}, reasons: []string{"PR #1 merged"}, selected: true},
+		{worktree: git.Worktree{Branch: "feature-b"}, reasons: []string{"branch deleted on remote"}, hasUncommitted: true},
+	}
+
+	// Toggle all: not everything is selected, so everything becomes selected
+	resultModel, _ := m.handleCleanupModalInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	result := resultModel.(Model)
+	if result.countSelectedCleanupCandidates() != 2 {
+		t.Errorf("Expected 2 selected candidates, got %d", result.countSelectedCleanupCandidates())
+	}
+
+	// Space toggles only the candidate under the cursor
+	resultModel, _ = result.handleCleanupModalInput(tea.KeyMsg{Type: tea.KeySpace})
+	result = resultModel.(Model)
+	if result.cleanupCandidates[0].selected {
+		t.Errorf("Expected first candidate to be deselected")
+	}
+
+	resultModel, _ = result.handleCleanupModalInput(tea.KeyMsg{Type: tea.KeyEnter})
+	result = resultModel.(Model)
+	if !result.cleanupConfirming {
+		t.Errorf("Expected deletion summary to be shown")
+	}
+	if result.modal != cleanupModal {
+		t.Errorf("Expected cleanup modal to stay open, got %v", result.modal)
+	}
+}
+
+// TestCleanupModal_EnterWithoutSelection tests that nothing is confirmed when no candidate is selected
+func TestCleanupModal_EnterWithoutSelection(t *testing.T) {
+	m := setupTestModel()
+	m.modal = cleanupModal
+	m.cleanupCandidates = []cleanupCandidate{
+		{worktree: git.Worktree{Branch: "feature-a"}, reasons: []string{"merged into main"}},
+	}
+
+	resultModel, _ := m.handleCleanupModalInput(tea.KeyMsg{Type: tea.KeyEnter})
+	result := resultModel.(Model)
+
+	if result.cleanupConfirming {
+		t.Errorf("Expected summary not to be shown without a selection")
+	}
+	if result.notification == nil || result.notification.Type != NotificationWarning {
+		t.Errorf("Expected warning notification, got %+v", result.notification)
+	}
+}
+
+// TestSuggestCleanup tests that merged/closed PRs are suggested for cleanup only once
+func TestSuggestCleanup(t *testing.T) {
+	m := setupTestModel()
+	m.worktrees = []git.Worktree{
+		{Branch: "main", IsCurrent: true, Protected: true},
+		{Branch: "merged", PRs: []config.PRInfo{{PRNumber: 1, Status: "merged"}}},
+		{Branch: "develop", Protected: true, PRs: []config.PRInfo{{PRNumber: 4, Status: "merged"}}},
+		{Branch: "reopened", PRs: []config.PRInfo{{PRNumber: 2, Status: "closed"}, {PRNumber: 3, Status: "open"}}},
+	}
+
+	if cmd := m.suggestCleanup(); cmd == nil {
+		t.Fatalf("Expected a cleanup suggestion")
+	}
+	if m.notification == nil || !strings.HasPrefix(m.notification.Message, "1 worktree(s)") {
+		t.Errorf("Expected suggestion for 1 worktree, got %+v", m.notification)
+	}
+	if cmd := m.suggestCleanup(); cmd != nil {
+		t.Errorf("Expected no repeated suggestion for the same branch")
+	}
+}
+
+// TestPRContentModal_AddSuggestedReviewers tests adding CODEOWNERS suggestions without duplicates
+func TestPRContentModal_AddSuggestedReviewers(t *testing.T) {
+	m := setupTestModel()
+	m.modal = prContentModal
+	m.prReviewersInput = textinput.New()
+	m.prReviewersInput.SetValue("alice")
+	m.prSuggestedReviewers = []string{"org/core", "alice"}
+	m.prModalFocused = prFieldReviewers
+
+	resultModel, _ := m.handlePRContentModalInput(tea.KeyMsg{Type: tea.KeyCtrlO})
+	result := resultModel.(Model)
+
+	if got := result.prReviewersInput.Value(); got != "alice, org/core" {
+		t.Errorf("Expected reviewers %q, got %q", "alice, org/core", got)
+	}
+}
+
+// TestPRContentModal_SwitchTemplate tests cycling through PR templates from the buttons
+func TestPRContentModal_SwitchTemplate(t *testing.T) {
+	m := setupTestModel()
+	m.modal = prContentModal
+	m.prModalWorktreePath = "/repo/feature"
+	m.prTemplates = []git.PRTemplate{
+		{Name: "bugfix.md", Content: "## Bug"},
+		{Name: "feature.md", Content: "## Feature"},
+	}
+	m.prModalFocused = prButtonCreate
+
+	resultModel, _ := m.handlePRContentModalInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	result := resultModel.(Model)
+	if got := result.prTemplateFor("/repo/feature"); got != "## Feature" {
+		t.Errorf("Expected second template to be selected, got %q", got)
+	}
+
+	resultModel, _ = result.handlePRContentModalInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	result = resultModel.(Model)
+	if result.prTemplateIndex != 0 {
+		t.Errorf("Expected selection to wrap around, got index %d", result.prTemplateIndex)
+	}
+}
+
+// TestSplitList tests parsing of comma-separated metadata inputs
+func TestSplitList(t *testing.T) {
+	got := splitList(" @alice, org/core,,alice , bug ")
+	want := []string{"alice", "org/core", "bug"}
+
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestDiffLines tests the line diff shown before syncing a PR description
+func TestDiffLines(t *testing.T) {
+	got := diffLines("Summary\nOld line\nNotes", "Summary\nNew line\nNotes\n- Add tests")
+	want := []string{"  Summary", "- Old line", "+ New line", "  Notes", "+ - Add tests"}
+
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestPRDescriptionSyncModal_Keys tests scrolling and skipping the description sync confirmation
+func TestPRDescriptionSyncModal_Keys(t *testing.T) {
+	m := setupTestModel()
+	m.modal = prDescriptionSyncModal
+	m.prSyncOldBody = "a"
+	m.prSyncNewBody = "b"
+
+	// Two diff lines: scrolling stops at the last one
+	resultModel, _ := m.handlePRDescriptionSyncModalInput(tea.KeyMsg{Type: tea.KeyDown})
+	result := resultModel.(Model)
+	resultModel, _ = result.handlePRDescriptionSyncModalInput(tea.KeyMsg{Type: tea.KeyDown})
+	result = resultModel.(Model)
+	if result.prSyncScroll != 1 {
+		t.Errorf("Expected scroll offset 1, got %d", result.prSyncScroll)
+	}
+
+	resultModel, cmd := result.handlePRDescriptionSyncModalInput(tea.KeyMsg{Type: tea.KeyEsc})
+	result = resultModel.(Model)
+	if result.modal != noModal {
+		t.Errorf("Expected modal to close on escape, got %v", result.modal)
+	}
+	if cmd != nil {
+		t.Errorf("Expected no update command when skipping")
+	}
+}
+
+// TestUpdateSessions_WaitingNotification tests that only transitions into the waiting state raise a notification
+func TestUpdateSessions_WaitingNotification(t *testing.T) {
+	m := setupTestModel()
+	m.worktrees = []git.Worktree{{Branch: "feature/login", ClaudeSessionName: "jean-repo-feature-login"}}
+	m.sessions = []session.Session{{Name: "jean-repo-feature-login", AgentState: session.AgentStateBusy}}
+
+	waiting := []session.Session{{Name: "jean-repo-feature-login", AgentState: session.AgentStateWaiting}}
+	if cmd := m.updateSessions(waiting); cmd == nil {
+		t.Fatal("Expected a notification when the session starts waiting")
+	}
+	if m.notification == nil || !strings.Contains(m.notification.Message, "feature/login") {
+		t.Errorf("Expected notification to name the branch, got %+v", m.notification)
+	}
+	if got := m.agentStateFor(m.worktrees[0]); got != session.AgentStateWaiting {
+		t.Errorf("Expected agent state %q, got %q", session.AgentStateWaiting, got)
+	}
+
+	// Still waiting: no new notification
+	if cmd := m.updateSessions(waiting); cmd != nil {
+		t.Error("Expected no notification while the session keeps waiting")
+	}
+}
+
+// TestUpdateSessions_ForgetsEndedSessions tests that sessions closed while jean runs aren't offered for restoring
+func TestUpdateSessions_ForgetsEndedSessions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configManager, err := config.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := setupTestModel()
+	m.configManager = configManager
+	m.repoPath = "/repo"
+	for _, name := range []string{"jean-repo-closed", "jean-repo-running"} {
+		if err := configManager.SetSessionRecord(m.repoPath, name, config.SessionRecord{Branch: name, Path: "/repo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.sessions = []session.Session{{Name: "jean-repo-closed"}, {Name: "jean-repo-running"}}
+
+	m.updateSessions([]session.Session{{Name: "jean-repo-running"}})
+	records := configManager.GetSessionRecords(m.repoPath)
+	if _, ok := records["jean-repo-closed"]; ok {
+		t.Error("Expected the closed session to be forgotten")
+	}
+	if _, ok := records["jean-repo-running"]; !ok {
+		t.Error("Expected the running session to stay recorded")
+	}
+}
+
+// TestPromptComposer_BroadcastAndHistory tests target selection and history browsing in the prompt composer
+func TestPromptComposer_BroadcastAndHistory(t *testing.T) {
+	m := setupTestModel()
+	m.promptInput = textarea.New()
+	m.worktrees = []git.Worktree{
+		{Branch: "feature/a", ClaudeSessionName: "jean-repo-feature-a"},
+		{Branch: "feature/b", ClaudeSessionName: "jean-repo-feature-b"},
+		{Branch: "feature/c", ClaudeSessionName: "jean-repo-feature-c"},
+	}
+	m.sessions = []session.Session{
+		{Name: "jean-repo-feature-a", AgentState: session.AgentStateIdle},
+		{Name: "jean-repo-feature-b", AgentState: session.AgentStateBusy},
+		{Name: "jean-repo-feature-c", AgentState: session.AgentStateExited},
+	}
+	m.openPromptComposer(m.worktrees[0])
+	m.promptHistory = []string{"newest", "oldest"}
+	m.promptInput.SetValue("draft")
+
+	// Exited sessions are not offered as targets
+	if len(m.promptTargets) != 2 {
+		t.Fatalf("Expected 2 targets, got %d", len(m.promptTargets))
+	}
+
+	key := func(m Model, k tea.KeyMsg) Model {
+		result, _ := m.handlePromptComposerModalInput(k)
+		return result.(Model)
+	}
+
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if m.promptInput.Value() != "oldest" {
+		t.Errorf("Expected oldest history entry, got %q", m.promptInput.Value())
+	}
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	if m.promptInput.Value() != "draft" {
+		t.Errorf("Expected draft to be restored, got %q", m.promptInput.Value())
+	}
+
+	// Without broadcast only the worktree the composer was opened for is targeted
+	if got := m.selectedPromptTargets(); len(got) != 1 || got[0].Branch != "feature/a" {
+		t.Errorf("Expected feature/a only, got %v", got)
+	}
+
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = key(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = key(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = key(m, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if got := m.selectedPromptTargets(); len(got) != 2 {
+		t.Errorf("Expected 2 broadcast targets, got %v", got)
+	}
+
+	m = key(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if got := m.selectedPromptTargets(); len(got) != 0 {
+		t.Errorf("Expected 'a' to clear a full selection, got %v", got)
+	}
+}
+
 // Helper function to set up a basic test model
 func setupTestModel() Model {
 	return Model{
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/coollabsio/jean-tui/config"
+	"github.com/coollabsio/jean-tui/git"
 	"github.com/coollabsio/jean-tui/internal/version"
+	"github.com/coollabsio/jean-tui/session"
 )
 
 // View renders the TUI
@@ -67,8 +70,12 @@ func (m Model) View() string {
 func (m Model) renderWorktreeList() string {
 	var b strings.Builder
 
-	repoName := filepath.Base(m.repoPath)
+	repoName := git.RepoName(m.repoPath)
 	b.WriteString(titleStyle.Render(fmt.Sprintf("📁 %s", repoName)))
+	if m.offline {
+		// Auto-fetch couldn't reach the remote, behind counts may be stale
+		b.WriteString(normalItemStyle.Copy().Foreground(warningColor).Render(" ⚠ offline"))
+	}
 	b.WriteString("\n")
 
 	// Show base branch info
@@ -120,9 +127,14 @@ func (m Model) renderWorktreeList() string {
 		var line string
 		if wt.IsCurrent {
 			line = fmt.Sprintf("%sroot (branch: %s)", icon, branch)
+		} else if wt.Detached {
+			// Show the tag, commit, or PR it was created at, which its directory is named after
+			line = fmt.Sprintf("%s%s", icon, filepath.Base(wt.Path))
+			line += normalItemStyle.Copy().Foreground(mutedColor).Render(" ⌖ detached @" + wt.Commit[:min(7, len(wt.Commit))])
 		} else {
 			line = fmt.Sprintf("%s%s", icon, branch)
-
+		}
+		if !wt.IsCurrent {
 			// Show uncommitted changes indicator
 			if wt.HasUncommitted {
 				uncommittedIndicator := " ●"
@@ -134,6 +146,28 @@ func (m Model) renderWorktreeList() string {
 				behindIndicator := fmt.Sprintf(" ↓%d", wt.BehindCount)
 				line += normalItemStyle.Copy().Foreground(warningColor).Render(behindIndicator)
 			}
+
+			// Show pending auto-merge / merge queue indicator
+			if autoMerge := pendingAutoMerge(wt); autoMerge != "" {
+				autoMergeIndicator := " ⏳auto"
+				if autoMerge == "queue" {
+					autoMergeIndicator = " ⏳queued"
+				}
+				line += normalItemStyle.Copy().Foreground(accentColor).Render(autoMergeIndicator)
+			}
+
+			// Show Claude session state
+			if badge, color := agentStateBadge(m.agentStateFor(wt)); badge != "" {
+				line += normalItemStyle.Copy().Foreground(color).Render(" " + badge)
+			}
+
+			// Show locked and missing worktrees
+			if wt.Locked {
+				line += normalItemStyle.Copy().Foreground(mutedColor).Render(" 🔒")
+			}
+			if wt.Prunable {
+				line += normalItemStyle.Copy().Foreground(errorColor).Render(" ⚠ missing")
+			}
 		}
 
 
@@ -144,6 +178,21 @@ func (m Model) renderWorktreeList() string {
 	return b.String()
 }
 
+// agentStateBadge returns the worktree list badge and color for a Claude session state
+func agentStateBadge(state session.AgentState) (string, lipgloss.Color) {
+	switch state {
+	case session.AgentStateBusy:
+		return "⚙ working", accentColor
+	case session.AgentStateWaiting:
+		return "? waiting", warningColor
+	case session.AgentStateIdle:
+		return "✓ idle", mutedColor
+	case session.AgentStateExited:
+		return "✗ exited", errorColor
+	}
+	return "", mutedColor
+}
+
 func (m Model) renderDetails() string {
 	var b strings.Builder
 
@@ -160,6 +209,17 @@ func (m Model) renderDetails() string {
 	b.WriteString(detailKeyStyle.Render("Branch: "))
 	b.WriteString(detailValueStyle.Render(wt.Branch))
 	b.WriteString("\n")
+	if wt.Detached {
+		b.WriteString(normalItemStyle.Copy().Foreground(mutedColor).Render("  No branch to commit to or push, press 'W' to promote it to one"))
+		b.WriteString("\n")
+	}
+
+	// Show Claude session state
+	if badge, color := agentStateBadge(m.agentStateFor(*wt)); badge != "" {
+		b.WriteString(detailKeyStyle.Render("Claude: "))
+		b.WriteString(detailValueStyle.Copy().Foreground(color).Render(badge))
+		b.WriteString("\n")
+	}
 
 	// Show base branch right after branch
 	if m.baseBranch != "" {
@@ -167,7 +227,7 @@ func (m Model) renderDetails() string {
 		b.WriteString(detailValueStyle.Render(m.baseBranch))
 
 		// Show status on the same line if branch differs from base branch
-		if wt.Branch != m.baseBranch && !strings.HasPrefix(wt.Branch, "(detached") {
+		if wt.Branch != m.baseBranch && !wt.Detached {
 			b.WriteString("  ")
 			// Show ahead/behind counts
 			if wt.AheadCount > 0 || wt.BehindCount > 0 {
@@ -181,7 +241,7 @@ func (m Model) renderDetails() string {
 				b.WriteString(strings.Join(statusParts, ", "))
 
 				// Add pull hint directly on the same line if behind
-				if wt.BehindCount > 0 && !wt.IsCurrent && strings.Contains(wt.Path, ".workspaces") {
+				if wt.BehindCount > 0 && !wt.IsCurrent && m.gitManager.IsWorkspacePath(wt.Path) {
 					b.WriteString(normalItemStyle.Copy().Foreground(mutedColor).Render(" (press 'u' to pull)"))
 				}
 			} else {
@@ -205,6 +265,24 @@ func (m Model) renderDetails() string {
 		b.WriteString("\n")
 	}
 
+	// Show lock and missing directory states
+	if wt.Locked {
+		reason := wt.LockReason
+		if reason == "" {
+			reason = "yes"
+		}
+		b.WriteString(detailKeyStyle.Render("Locked: "))
+		b.WriteString(detailValueStyle.Render(reason))
+		b.WriteString("\n")
+	}
+	if wt.Prunable {
+		b.WriteString(detailKeyStyle.Render("Missing: "))
+		b.WriteString(normalItemStyle.Copy().Foreground(errorColor).Render(wt.PrunableReason))
+		b.WriteString("\n")
+		b.WriteString(normalItemStyle.Copy().Foreground(accentColor).Render("  Press 'W' to repair (if moved) or prune it"))
+		b.WriteString("\n")
+	}
+
 	// Show uncommitted changes status
 	if wt.HasUncommitted {
 		b.WriteString("\n")
@@ -239,6 +317,14 @@ func (m Model) renderDetails() string {
 			if pr.Status != "" {
 				prDisplay = fmt.Sprintf("%s (%s)", prDisplay, pr.Status)
 			}
+			// Add pending auto-merge state
+			if pr.AutoMerge != "" && pr.Status == "open" {
+				if pr.AutoMerge == "queue" {
+					prDisplay += " [in merge queue]"
+				} else {
+					prDisplay += fmt.Sprintf(" [auto-merge: %s]", pr.AutoMerge)
+				}
+			}
 
 			b.WriteString("  ")
 			// Render PR as underlined text with OSC 8 hyperlink support for modern terminals
@@ -357,6 +443,7 @@ func (m Model) renderMinimalHelpBar() string {
 		"↑/↓ nav",
 		"n/a/N new/existing/PR",
 		"enter/t cli/terminal",
+		"i prompt",
 		"c commit",
 		"p push",
 		"P create PR",
@@ -443,6 +530,32 @@ func (m Model) renderModal() string {
 		return m.renderOnboardingModal()
 	case gitInitModal:
 		return m.renderGitInitModal()
+	case cleanupModal:
+		return m.renderCleanupModal()
+	case prDescriptionSyncModal:
+		return m.renderPRDescriptionSyncModal()
+	case remotesModal:
+		return m.renderRemotesModal()
+	case promptComposerModal:
+		return m.renderPromptComposerModal()
+	case agentSelectModal:
+		return m.renderAgentSelectModal()
+	case restoreSessionsModal:
+		return m.renderRestoreSessionsModal()
+	case transcriptsModal:
+		return m.renderTranscriptsModal()
+	case worktreeLocationModal:
+		return m.renderWorktreeLocationModal()
+	case branchNamingModal:
+		return m.renderBranchNamingModal()
+	case includeModal:
+		return m.renderIncludeModal()
+	case diskUsageModal:
+		return m.renderDiskUsageModal()
+	case worktreeMaintenanceModal:
+		return m.renderWorktreeMaintenanceModal()
+	case detachedWorktreeModal:
+		return m.renderDetachedWorktreeModal()
 	}
 	return ""
 }
@@ -473,7 +586,8 @@ func (m Model) renderCreateModal() string {
 	}
 
 	// Show info about auto-generated workspace location
-	b.WriteString(helpStyle.Render("Workspace location: .workspaces/<random-name>"))
+	location := strings.TrimPrefix(m.gitManager.PathTemplate(), "{root}/")
+	b.WriteString(helpStyle.Render("Workspace location: " + strings.ReplaceAll(location, "{branch}", "<random-name>")))
 	b.WriteString("\n\n")
 
 	// Buttons (now only 2 buttons: Create and Cancel)
@@ -525,7 +639,12 @@ func (m Model) renderCreateWithNameModal() string {
 	}
 	b.WriteString("\n")
 	b.WriteString(helpStyle.Render("(leave empty for random name, or type a custom name)"))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if template := m.gitManager.BranchTemplate(); template != "" {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Template %s: type e.g. 'fix PROJ-12 login crash'", template)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Show info about what will be created
 	sessionName := m.sessionNameInput.Value()
@@ -538,7 +657,7 @@ func (m Model) renderCreateWithNameModal() string {
 		b.WriteString(helpStyle.Render(fmt.Sprintf("  Branch: <random name will be generated>")))
 	} else {
 		// Custom name provided
-		sanitizedName := m.sessionManager.SanitizeBranchName(sessionName)
+		sanitizedName := m.branchNameFromInput(sessionName)
 		b.WriteString(helpStyle.Render(fmt.Sprintf("  Branch: %s", sanitizedName)))
 
 		// Show sanitization notice if name was changed
@@ -546,6 +665,12 @@ func (m Model) renderCreateWithNameModal() string {
 			b.WriteString("\n")
 			b.WriteString(helpStyle.Render(fmt.Sprintf("  (sanitized from '%s')", sessionName)))
 		}
+
+		// Show the naming convention the name breaks
+		if err := m.gitManager.ValidateBranchName(sanitizedName); err != nil {
+			b.WriteString("\n")
+			b.WriteString(errorStyle.Render("  " + err.Error()))
+		}
 	}
 
 	b.WriteString("\n")
@@ -856,7 +981,7 @@ func (m Model) renderSessionListModal() string {
 	if len(m.sessions) == 0 {
 		b.WriteString(normalItemStyle.Render("No active sessions found"))
 		b.WriteString("\n\n")
-		b.WriteString(helpStyle.Render("Press Esc to close"))
+		b.WriteString(helpStyle.Render("r restore missing sessions • Esc close"))
 	} else {
 		// Show sessions
 		maxVisible := 10
@@ -904,7 +1029,7 @@ func (m Model) renderSessionListModal() string {
 		b.WriteString(helpStyle.Render(fmt.Sprintf("Showing %d-%d of %d sessions", start+1, end, len(m.sessions))))
 		b.WriteString("\n\n")
 
-		b.WriteString(helpStyle.Render("↑↓ navigate • Enter attach • d kill • Esc close"))
+		b.WriteString(helpStyle.Render("↑↓ navigate • Enter attach • l reset layout • c capture transcript • r restore • d kill • Esc close"))
 	}
 
 	return lipgloss.Place(
@@ -950,7 +1075,7 @@ func (m Model) renderRenameModal() string {
 
 	// Show info about what will be renamed
 	if wt := m.selectedWorktree(); wt != nil {
-		if strings.Contains(wt.Path, ".workspaces") {
+		if m.gitManager.IsWorkspacePath(wt.Path) {
 			b.WriteString(helpStyle.Render("ℹ️  This will rename the git branch only"))
 			b.WriteString("\n")
 			b.WriteString(helpStyle.Render("   Directory path stays the same to preserve active sessions"))
@@ -1203,25 +1328,45 @@ func (m Model) renderPRContentModal() string {
 	b.WriteString(modalTitleStyle.Render(title))
 	b.WriteString("\n\n")
 
-	// Title input
-	b.WriteString(inputLabelStyle.Render("Title (required):"))
-	b.WriteString("\n")
-	titleStyle := normalItemStyle
-	if m.prModalFocused == 0 {
-		titleStyle = selectedItemStyle
-	}
-	b.WriteString(titleStyle.Render(m.prTitleInput.View()))
-	b.WriteString("\n\n")
+	// Text fields, in focus order
+	fields := []struct {
+		label string
+		input string
+	}{
+		{"Title (required):", m.prTitleInput.View()},
+		{"Description (optional):", m.prDescriptionInput.View()},
+		{"Reviewers (comma-separated, org/team for teams):", m.prReviewersInput.View()},
+		{"Labels:", m.prLabelsInput.View()},
+		{"Assignees:", m.prAssigneesInput.View()},
+		{"Milestone:", m.prMilestoneInput.View()},
+	}
+	for i, field := range fields {
+		b.WriteString(inputLabelStyle.Render(field.label))
+		b.WriteString("\n")
+		fieldStyle := normalItemStyle
+		if m.prModalFocused == i {
+			fieldStyle = selectedItemStyle
+		}
+		b.WriteString(fieldStyle.Render(field.input))
+		b.WriteString("\n")
 
-	// Description input
-	b.WriteString(inputLabelStyle.Render("Description (optional):"))
-	b.WriteString("\n")
-	descriptionStyle := normalItemStyle
-	if m.prModalFocused == 1 {
-		descriptionStyle = selectedItemStyle
+		// PR template used for AI generation below the description field
+		if i == prFieldDescription && m.prTemplateIndex < len(m.prTemplates) {
+			template := "  Template: " + m.prTemplates[m.prTemplateIndex].Name
+			if len(m.prTemplates) > 1 {
+				template += fmt.Sprintf(" (%d/%d, t to switch)", m.prTemplateIndex+1, len(m.prTemplates))
+			}
+			b.WriteString(helpStyle.Render(template))
+			b.WriteString("\n")
+		}
+
+		// CODEOWNERS suggestions below the reviewers field
+		if i == prFieldReviewers && len(m.prSuggestedReviewers) > 0 {
+			b.WriteString(helpStyle.Render("  Suggested from CODEOWNERS: " + strings.Join(m.prSuggestedReviewers, ", ") + " (ctrl+o to add)"))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
 	}
-	b.WriteString(descriptionStyle.Render(m.prDescriptionInput.View()))
-	b.WriteString("\n\n")
 
 	// Spinner or status message
 	if m.generatingPRContent {
@@ -1246,9 +1391,9 @@ func (m Model) renderPRContentModal() string {
 	createStyle := normalItemStyle
 	cancelStyle := normalItemStyle
 
-	if m.prModalFocused == 2 {
+	if m.prModalFocused == prButtonCreate {
 		createStyle = selectedItemStyle
-	} else if m.prModalFocused == 3 {
+	} else if m.prModalFocused == prButtonCancel {
 		cancelStyle = selectedItemStyle
 	}
 
@@ -1261,7 +1406,11 @@ func (m Model) renderPRContentModal() string {
 	b.WriteString(buttons)
 
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("Tab: next • Enter: confirm • Esc: cancel"))
+	help := "Tab: next • Enter: confirm • d: save metadata as repo defaults • Esc: cancel"
+	if len(m.prTemplates) > 1 {
+		help = "Tab: next • Enter: confirm • t: switch template • d: save metadata as repo defaults • Esc: cancel"
+	}
+	b.WriteString(helpStyle.Render(help))
 
 	// Center the modal
 	modalContent := b.String()
@@ -1466,6 +1615,406 @@ func (m Model) renderEditorSelectModal() string {
 	)
 }
 
+func (m Model) renderWorktreeLocationModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Worktree Location"))
+	b.WriteString("\n\n")
+
+	if len(m.worktreeMigrations) > 0 {
+		b.WriteString(normalItemStyle.Render(fmt.Sprintf("Move %d existing worktree(s) to the new location?", len(m.worktreeMigrations))))
+		b.WriteString("\n\n")
+		for _, migration := range m.worktreeMigrations {
+			b.WriteString(normalItemStyle.Render("• " + migration.worktree.Branch + " → " + migration.newPath))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("Worktrees with a running session are left where they are"))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("y/enter move • n/esc keep them where they are"))
+	} else {
+		b.WriteString(inputLabelStyle.Render("Path template for new worktrees:"))
+		b.WriteString("\n")
+		b.WriteString(selectedItemStyle.Render(m.worktreePathInput.View()))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("{repo} = repository name • {branch} = branch • {root} = main checkout"))
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("Relative paths start at the main checkout, e.g. ../{repo}-{branch}"))
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("Leave empty to use worktree_path_template from the config, or .workspaces"))
+		b.WriteString("\n\n")
+		if path, err := m.gitManager.GetDefaultPath("my-feature"); err == nil {
+			b.WriteString(helpStyle.Render("Current location: " + path))
+			b.WriteString("\n\n")
+		}
+		b.WriteString(helpStyle.Render("Enter: save • Esc: back"))
+	}
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderBranchNamingModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Branch Naming"))
+	b.WriteString("\n\n")
+
+	fields := []struct {
+		label string
+		input string
+	}{
+		{"Template for new branch names:", m.branchTemplateInput.View()},
+		{"Pattern branch names must match (regex):", m.branchPatternInput.View()},
+	}
+	for i, field := range fields {
+		b.WriteString(inputLabelStyle.Render(field.label))
+		b.WriteString("\n")
+		fieldStyle := normalItemStyle
+		if m.branchNamingFocused == i {
+			fieldStyle = selectedItemStyle
+		}
+		b.WriteString(fieldStyle.Render(field.input))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(helpStyle.Render("{user} = git user • {type} = feat, fix, ... • {ticket} = e.g. PROJ-12 • {slug} • {date}"))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Empty template: random names • empty pattern: any name"))
+	b.WriteString("\n")
+	if template := strings.TrimSpace(m.branchTemplateInput.Value()); strings.Contains(template, "{slug}") {
+		example := git.ExpandBranchTemplate(template, git.BranchTemplateValues{User: "alice", Type: "fix", Ticket: "PROJ-12", Slug: "login crash", Date: time.Now().Format("2006-01-02")})
+		b.WriteString(helpStyle.Render("Example: " + example))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Tab: switch field • Enter: save • Esc: back"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderIncludeModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Resync Includes: " + m.includeBranch))
+	b.WriteString("\n\n")
+	b.WriteString(normalItemStyle.Render("From the root checkout, as declared in jean.json:"))
+	b.WriteString("\n\n")
+
+	for _, action := range m.includeActions {
+		path := action.Path
+		if action.IsDir {
+			path += "/"
+		}
+		status := "new"
+		if action.Exists {
+			status = "replaces existing"
+		}
+		b.WriteString(normalItemStyle.Render(fmt.Sprintf("• %s (%s, %s)", path, action.Mode, status)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Changes made to these files in the worktree are overwritten"))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("y/enter resync • n/esc cancel"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderDiskUsageModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Disk Usage"))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("  %-32s %10s %12s %12s", "Worktree", "Size", "Dependencies", "Reclaimable")))
+	b.WriteString("\n")
+
+	var total, reclaimable int64
+	for i, entry := range m.diskUsage {
+		name := entry.worktree.Branch
+		if name == "" {
+			name = filepath.Base(entry.worktree.Path)
+		}
+		if len(name) > 32 {
+			name = name[:31] + "…"
+		}
+
+		var line string
+		if entry.err != nil {
+			line = fmt.Sprintf("%-32s %s", name, "failed: "+entry.err.Error())
+		} else if !m.gitManager.IsWorkspacePath(entry.worktree.Path) {
+			// Deleting the main checkout isn't an option
+			line = fmt.Sprintf("%-32s %10s %12s %12s", name, git.FormatBytes(entry.usage.Size), git.FormatBytes(entry.usage.Dependencies), "-")
+		} else {
+			line = fmt.Sprintf("%-32s %10s %12s %12s", name, git.FormatBytes(entry.usage.Size), git.FormatBytes(entry.usage.Dependencies), git.FormatBytes(entry.usage.Reclaimable))
+			reclaimable += entry.usage.Reclaimable
+		}
+		total += entry.usage.Size
+
+		if i == m.diskUsageIndex {
+			b.WriteString(selectedItemStyle.Render("› " + line))
+		} else {
+			b.WriteString(normalItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(normalItemStyle.Render(fmt.Sprintf("Total %s • reclaimable by deleting worktrees %s", git.FormatBytes(total), git.FormatBytes(reclaimable))))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("Reclaimable: freed by deleting the worktree; files hardlinked from other checkouts aren't counted"))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓ navigate • Esc close"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderWorktreeMaintenanceModal() string {
+	var b strings.Builder
+
+	wt := m.maintenanceWorktree
+	name := wt.Branch
+	if name == "" {
+		name = filepath.Base(wt.Path)
+	}
+	b.WriteString(modalTitleStyle.Render("Worktree Maintenance: " + name))
+	b.WriteString("\n\n")
+	if wt.Prunable {
+		b.WriteString(errorStyle.Render("⚠ " + wt.PrunableReason))
+		b.WriteString("\n\n")
+	}
+
+	if m.maintenanceAction != "" {
+		label := "Lock reason:"
+		help := "Enter lock • Esc back"
+		switch m.maintenanceAction {
+		case "repair":
+			label = "Where the worktree is now:"
+			help = "Enter repair • Esc back"
+		case "promote":
+			label = "Branch name:"
+			help = "Enter create branch • Esc back"
+		}
+		b.WriteString(inputLabelStyle.Render(label))
+		b.WriteString("\n")
+		b.WriteString(m.maintenanceInput.View())
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render(help))
+	} else {
+		for i, action := range m.maintenanceActions() {
+			if i == m.maintenanceIndex {
+				b.WriteString(selectedItemStyle.Render("› " + action.name))
+			} else {
+				b.WriteString(normalItemStyle.Render("  " + action.name))
+			}
+			b.WriteString("\n")
+			b.WriteString(helpStyle.Render("    " + action.description))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("↑/↓ navigate • Enter run • Esc close"))
+	}
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderDetachedWorktreeModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Create Detached Worktree"))
+	b.WriteString("\n\n")
+	b.WriteString(normalItemStyle.Render("Check out a release, commit, or PR without a branch,"))
+	b.WriteString("\n")
+	b.WriteString(normalItemStyle.Render("e.g. to review or bisect. Promote it to a branch later with 'W'."))
+	b.WriteString("\n\n")
+	b.WriteString(inputLabelStyle.Render("Tag, commit, or PR (#123):"))
+	b.WriteString("\n")
+	b.WriteString(m.detachedRefInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("Enter create • Esc cancel"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderTranscriptsModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Agent Transcripts"))
+	b.WriteString("\n\n")
+	b.WriteString(m.transcriptSearch.View())
+	b.WriteString("\n\n")
+
+	transcripts := m.filteredTranscripts()
+	if len(transcripts) == 0 {
+		b.WriteString(normalItemStyle.Render("No transcript matches"))
+		b.WriteString("\n")
+	}
+
+	// Show transcripts
+	maxVisible := 6
+	start := m.transcriptIndex - maxVisible/2
+	if start < 0 {
+		start = 0
+	}
+	end := min(start+maxVisible, len(transcripts))
+	for i := start; i < end; i++ {
+		transcript := transcripts[i]
+		line := fmt.Sprintf("%s · %d capture(s) · %s", transcript.Branch, transcript.Captures, transcript.Updated.Format("2006-01-02 15:04"))
+		if i == m.transcriptIndex {
+			b.WriteString(selectedItemStyle.Render("› " + line))
+		} else {
+			b.WriteString(normalItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	// Preview the matching lines of the selected transcript, or the end of its latest capture
+	if m.transcriptIndex >= 0 && m.transcriptIndex < len(transcripts) {
+		query := m.transcriptSearch.Value()
+		b.WriteString("\n")
+		if strings.TrimSpace(query) == "" {
+			b.WriteString(helpStyle.Render("Last lines:"))
+		} else {
+			b.WriteString(helpStyle.Render("Matching lines:"))
+		}
+		b.WriteString("\n")
+		for _, line := range transcriptMatches(transcripts[m.transcriptIndex].content, query, 10) {
+			if len(line) > 80 {
+				line = line[:77] + "..."
+			}
+			b.WriteString(normalItemStyle.Render("  " + line))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Type to search • ↑↓ navigate • Enter open in editor • Esc close"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderRestoreSessionsModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Restore Sessions"))
+	b.WriteString("\n\n")
+	b.WriteString(normalItemStyle.Render(fmt.Sprintf("%d session(s) of this repository are no longer running:", len(m.restoreSessions))))
+	b.WriteString("\n\n")
+
+	for _, sess := range m.restoreSessions {
+		windows := sess.record.Windows
+		if sess.record.Layout != nil {
+			windows = nil
+			for _, window := range sess.record.Layout.Windows {
+				windows = append(windows, window.Name)
+			}
+		}
+		line := "• " + sess.record.Branch
+		if len(windows) > 0 {
+			line += " (" + strings.Join(windows, ", ") + ")"
+		}
+		if sess.record.AgentInitialized && sess.record.Agent != "" {
+			line += " - resumes " + sess.record.Agent
+		}
+		b.WriteString(normalItemStyle.Render(line))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Enter restore all • d forget them • Esc later (S → r)"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderAgentSelectModal() string {
+	var b strings.Builder
+
+	if m.agentSelectBranch == "" {
+		b.WriteString(modalTitleStyle.Render("Select Agent"))
+	} else {
+		b.WriteString(modalTitleStyle.Render("Select Agent for " + m.agentSelectBranch))
+	}
+	b.WriteString("\n\n")
+
+	profiles := config.AgentPresets
+	repoAgent := config.DefaultAgent
+	if m.configManager != nil {
+		profiles = m.configManager.GetAgentProfiles()
+		repoAgent = m.configManager.GetAgent(m.repoPath, "")
+	}
+
+	for i, name := range m.agentOptions {
+		label := name
+		if name == "" {
+			label = fmt.Sprintf("Repository default (%s)", repoAgent)
+		} else {
+			for _, profile := range profiles {
+				if profile.Name == name {
+					label = fmt.Sprintf("%s - %s", name, describeAgentProfile(profile))
+					break
+				}
+			}
+		}
+		if i == m.agentIndex {
+			b.WriteString(selectedItemStyle.Render("› " + label))
+		} else {
+			b.WriteString(normalItemStyle.Render("  " + label))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Custom profiles go in agent_profiles in ~/.config/jean/config.json"))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑↓ navigate • Enter to select • Esc to cancel"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+// describeAgentProfile returns the command line an agent profile starts, for display
+func describeAgentProfile(profile config.AgentProfile) string {
+	if profile.Command == "" {
+		return "plain shell"
+	}
+	return strings.TrimSpace(profile.Command + " " + strings.Join(profile.Args, " "))
+}
+
 func (m Model) renderThemeSelectModal() string {
 	var b strings.Builder
 
@@ -1551,7 +2100,7 @@ func (m Model) renderSettingsModal() string {
 			description: "Add/remove jean tmux config to ~/.tmux.conf",
 			getCurrent: func() string {
 				if m.sessionManager != nil {
-					hasConfig, err := m.sessionManager.HasJeanTmuxConfig()
+					hasConfig, err := m.tmuxManager.HasJeanTmuxConfig()
 					if err == nil && hasConfig {
 						return "Installed"
 					}
@@ -1598,6 +2147,138 @@ func (m Model) renderSettingsModal() string {
 				return "Ready for Review"
 			},
 		},
+		{
+			name:        "PR Description Sync",
+			key:         "y",
+			description: "After pushing, regenerate or append to the PR description (Enter to cycle)",
+			getCurrent: func() string {
+				if m.configManager != nil {
+					switch m.configManager.GetPRDescriptionSync(m.repoPath) {
+					case "regenerate":
+						return "Regenerate"
+					case "append":
+						return "Append commits"
+					}
+				}
+				return "Off"
+			},
+		},
+		{
+			name:        "Remotes",
+			key:         "f",
+			description: "Push branches to a fork and open PRs against the upstream repository",
+			getCurrent: func() string {
+				if m.gitManager.IsForkWorkflow() {
+					return fmt.Sprintf("Push to %s, PRs against %s", m.gitManager.PushRemote(), m.gitManager.UpstreamRemote())
+				}
+				return "origin"
+			},
+		},
+		{
+			name:        "Agent",
+			key:         "g",
+			description: "CLI agent started in the claude window (Shift+A picks one per worktree)",
+			getCurrent: func() string {
+				if m.configManager == nil {
+					return config.DefaultAgent
+				}
+				return m.configManager.GetAgent(m.repoPath, "")
+			},
+		},
+		{
+			name:        "Multiplexer",
+			key:         "m",
+			description: "Terminal multiplexer hosting worktree sessions (Enter to cycle tmux/zellij)",
+			getCurrent: func() string {
+				return m.sessionManager.Name()
+			},
+		},
+		{
+			name:        "Session Scrollback",
+			key:         "b",
+			description: "Capture tmux scrollback every 30s and show it again when sessions are restored",
+			getCurrent: func() string {
+				if m.configManager != nil && m.configManager.GetSessionScrollback() {
+					return "Enabled"
+				}
+				return "Disabled"
+			},
+		},
+		{
+			name:        "PR Transcript Summary",
+			key:         "r",
+			description: "Add an AI summary of the branch's latest agent transcript to generated PR descriptions",
+			getCurrent: func() string {
+				if m.configManager != nil && m.configManager.GetPRTranscriptSummary(m.repoPath) {
+					return "Enabled"
+				}
+				return "Disabled"
+			},
+		},
+		{
+			name:        "Worktree Location",
+			key:         "w",
+			description: "Where new worktrees are created, e.g. ~/worktrees/{repo}/{branch} instead of .workspaces",
+			getCurrent: func() string {
+				if m.configManager != nil {
+					if template := m.configManager.GetWorktreePathTemplate(m.repoPath); template != "" {
+						return template
+					}
+				}
+				return ".workspaces (default)"
+			},
+		},
+		{
+			name:        "Branch Naming",
+			key:         "n",
+			description: "Template for new branch names, e.g. {user}/{type}/{ticket}-{slug}, and a regex names must match",
+			getCurrent: func() string {
+				if template := m.gitManager.BranchTemplate(); template != "" {
+					return template
+				}
+				return "Random names (default)"
+			},
+		},
+		{
+			name:        "Dependency Seeding",
+			key:         "s",
+			description: "Seed node_modules, target, vendor, ... of new worktrees from a checkout with the same lockfile (Enter to cycle)",
+			getCurrent: func() string {
+				mode := ""
+				if m.configManager != nil {
+					mode = m.configManager.GetDependencySeeding(m.repoPath)
+				}
+				switch mode {
+				case config.SeedReflink:
+					return "Copy-on-write clones"
+				case config.SeedHardlink:
+					return "Clones, else hardlinks"
+				}
+				return "Off"
+			},
+		},
+		{
+			name:        "Auto-Fetch",
+			key:         "i",
+			description: "Fetch the remotes in the background to keep behind counts current (Enter to cycle)",
+			getCurrent: func() string {
+				seconds := 10
+				if m.configManager != nil {
+					seconds = m.configManager.GetAutoFetchInterval(m.repoPath)
+				}
+				if seconds <= 0 {
+					return "Off"
+				}
+				interval := (time.Duration(seconds) * time.Second).String()
+				if seconds >= 60 && seconds%60 == 0 {
+					interval = strings.TrimSuffix(interval, "0s")
+				}
+				if m.offline {
+					return "Every " + interval + " (offline, retrying less often)"
+				}
+				return "Every " + interval
+			},
+		},
 	}
 
 	// Render settings list
@@ -1894,7 +2575,7 @@ func (m Model) renderTmuxConfigModal() string {
 	// Check current status
 	hasConfig := false
 	if m.sessionManager != nil {
-		installed, err := m.sessionManager.HasJeanTmuxConfig()
+		installed, err := m.tmuxManager.HasJeanTmuxConfig()
 		if err == nil {
 			hasConfig = installed
 		}
@@ -2023,10 +2704,16 @@ func (m Model) renderHelperModal() string {
 				{"↓", "Move cursor down"},
 				{"n", "Create new worktree (with AI)"},
 				{"a", "Create new worktree (from existing branch)"},
+				{"R", "Create detached worktree (tag, commit, or PR)"},
 				{"enter", "Open CLI (Claude for now)"},
 				{"t", "Open terminal"},
+				{"i", "Send prompt to Claude (or broadcast)"},
+				{"A", "Choose agent for this worktree"},
 				{"o", "Open default editor"},
 				{"d", "Delete selected worktree"},
+				{"I", "Resync jean.json includes from root"},
+				{"D", "Show disk usage of worktrees"},
+				{"W", "Promote, lock, unlock, repair, or prune worktrees"},
 			},
 		},
 		{
@@ -2050,10 +2737,12 @@ func (m Model) renderHelperModal() string {
 				key         string
 				description string
 			}{
-				{"P", "Create new PR on GitHub"},
+				{"P", "Create new PR (GitHub, GitLab, Gitea)"},
 				{"N", "Create worktree from existing PR"},
 				{"L", "Local merge (worktree → base branch)"},
 				{"v", "Open PR in default browser"},
+				{"M", "Merge PR (now, auto-merge, or merge queue)"},
+				{"C", "Clean up merged/closed worktrees"},
 			},
 		},
 		{
@@ -2065,6 +2754,7 @@ func (m Model) renderHelperModal() string {
 				{"s", "Open settings"},
 				{"e", "Select default editor"},
 				{"S", "View tmux sessions"},
+				{"T", "View agent transcripts"},
 				{"h", "Show this help"},
 				{"q", "Quit application"},
 			},
@@ -2159,19 +2849,16 @@ func (m Model) renderMergeStrategyModal() string {
 	b.WriteString(modalTitleStyle.Render("Select Merge Strategy"))
 	b.WriteString("\n\n")
 
-	// Merge strategy options with descriptions
-	strategies := []struct {
-		name        string
-		description string
-	}{
-		{"Squash and merge", "All commits squashed into one commit on the base branch"},
-		{"Create a merge commit", "Preserves all commits from the PR in the history with a merge commit"},
-		{"Rebase and merge", "Replays commits from PR onto base branch without a merge commit"},
-	}
-
-	for i, strategy := range strategies {
+	// Merge strategy options with descriptions (shared with the input handler)
+	for i, strategy := range mergeOptions {
 		isSelected := i == m.mergeStrategyCursor
 
+		// Separate immediate merges from the deferred (auto-merge / queue) options
+		if i > 0 && strategy.action != "merge" && mergeOptions[i-1].action == "merge" {
+			b.WriteString(detailKeyStyle.Render("When checks pass"))
+			b.WriteString("\n\n")
+		}
+
 		var strategyText string
 		if isSelected {
 			strategyText = selectedItemStyle.Render("▶ " + strategy.name)
@@ -2323,6 +3010,297 @@ func (m Model) renderPostMergeCleanupModal() string {
 	)
 }
 
+func (m Model) renderCleanupModal() string {
+	var b strings.Builder
+
+	descStyle := normalItemStyle.Copy().Foreground(mutedColor)
+	warnStyle := normalItemStyle.Copy().Foreground(warningColor)
+
+	if m.cleanupConfirming {
+		b.WriteString(modalTitleStyle.Render("Confirm Cleanup"))
+		b.WriteString("\n\n")
+		b.WriteString(normalItemStyle.Render(fmt.Sprintf("The following will be deleted for %d worktree(s):", m.countSelectedCleanupCandidates())))
+		b.WriteString("\n\n")
+
+		for _, c := range m.cleanupCandidates {
+			if !c.selected {
+				continue
+			}
+			b.WriteString(selectedItemStyle.Render(c.worktree.Branch))
+			b.WriteString("\n")
+			b.WriteString(descStyle.Render("  • Worktree: " + c.worktree.Path))
+			b.WriteString("\n")
+			b.WriteString(descStyle.Render("  • Local branch: " + c.worktree.Branch))
+			b.WriteString("\n")
+			b.WriteString(descStyle.Render("  • Tmux session: " + m.worktreeSessionName(c.worktree.Branch)))
+			b.WriteString("\n")
+			b.WriteString(descStyle.Render("  • Saved PRs and Claude state in jean config"))
+			b.WriteString("\n")
+			if c.hasUncommitted {
+				b.WriteString(warnStyle.Render("  ⚠ Uncommitted changes will be discarded"))
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+
+		b.WriteString(helpStyle.Render("enter delete • esc back"))
+	} else {
+		b.WriteString(modalTitleStyle.Render("Clean Up Worktrees"))
+		b.WriteString("\n\n")
+		b.WriteString(normalItemStyle.Render("These worktrees look finished:"))
+		b.WriteString("\n\n")
+
+		for i, c := range m.cleanupCandidates {
+			checkbox := "[ ]"
+			if c.selected {
+				checkbox = "[x]"
+			}
+
+			if i == m.cleanupCursor {
+				b.WriteString(selectedItemStyle.Render("▶ " + checkbox + " " + c.worktree.Branch))
+			} else {
+				b.WriteString(normalItemStyle.Render("  " + checkbox + " " + c.worktree.Branch))
+			}
+			b.WriteString("\n")
+
+			b.WriteString(descStyle.Render("      " + strings.Join(c.reasons, ", ")))
+			b.WriteString("\n")
+			if c.hasUncommitted {
+				b.WriteString(warnStyle.Render("      ⚠ Has uncommitted changes"))
+				b.WriteString("\n")
+			}
+		}
+
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("↑/↓ navigate • space toggle • a toggle all • enter review • esc cancel"))
+	}
+
+	// Center the modal
+	content := modalStyle.Width(m.width - 4).Render(b.String())
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+// diffLines returns a line diff of two texts, prefixing unchanged lines with "  ",
+// removed lines with "- ", and added lines with "+ "
+func diffLines(oldText, newText string) []string {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+ "+b[j])
+	}
+	return lines
+}
+
+func (m Model) renderRemotesModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Remotes"))
+	b.WriteString("\n\n")
+
+	if m.remotesConfirmFork {
+		b.WriteString(normalItemStyle.Render(fmt.Sprintf("Remote '%s' does not exist.", m.gitManager.PushRemote())))
+		b.WriteString("\n\n")
+		b.WriteString(normalItemStyle.Render(fmt.Sprintf("Fork the %s repository and add the fork as '%s'?", m.gitManager.UpstreamRemote(), m.gitManager.PushRemote())))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("y/enter create fork • n/esc skip"))
+	} else {
+		fields := []struct {
+			label string
+			input string
+		}{
+			{"Push remote (your fork):", m.pushRemoteInput.View()},
+			{"Upstream remote (PRs are opened against):", m.upstreamRemoteInput.View()},
+		}
+		for i, field := range fields {
+			b.WriteString(inputLabelStyle.Render(field.label))
+			b.WriteString("\n")
+			fieldStyle := normalItemStyle
+			if m.remotesFocused == i {
+				fieldStyle = selectedItemStyle
+			}
+			b.WriteString(fieldStyle.Render(field.input))
+			b.WriteString("\n\n")
+		}
+
+		if remotes, err := m.gitManager.ListRemotes(); err == nil && len(remotes) > 0 {
+			b.WriteString(helpStyle.Render("Configured remotes: " + strings.Join(remotes, ", ")))
+			b.WriteString("\n")
+		}
+		b.WriteString(helpStyle.Render("Leave both empty to push to and open PRs against origin"))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("Tab: switch field • Enter: save • Esc: back"))
+	}
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		modalStyle.Render(b.String()),
+	)
+}
+
+func (m Model) renderPromptComposerModal() string {
+	var b strings.Builder
+
+	descStyle := normalItemStyle.Copy().Foreground(mutedColor)
+
+	if m.promptBroadcast {
+		b.WriteString(modalTitleStyle.Render("Broadcast Prompt"))
+		b.WriteString("\n\n")
+		b.WriteString(descStyle.Render(fmt.Sprintf("Sending to %d of %d running Claude sessions", len(m.selectedPromptTargets()), len(m.promptTargets))))
+	} else {
+		b.WriteString(modalTitleStyle.Render("Send Prompt"))
+		b.WriteString("\n\n")
+		b.WriteString(descStyle.Render("Sending to the Claude session of " + m.promptBranch))
+	}
+	b.WriteString("\n\n")
+
+	label := "Prompt:"
+	if m.promptHistoryIndex >= 0 {
+		label = fmt.Sprintf("Prompt (history %d/%d):", m.promptHistoryIndex+1, len(m.promptHistory))
+	}
+	b.WriteString(inputLabelStyle.Render(label))
+	b.WriteString("\n")
+	b.WriteString(m.promptInput.View())
+	b.WriteString("\n\n")
+
+	if m.promptBroadcast {
+		b.WriteString(inputLabelStyle.Render("Worktrees:"))
+		b.WriteString("\n")
+		for i, t := range m.promptTargets {
+			checkbox := "[ ]"
+			if t.selected {
+				checkbox = "[x]"
+			}
+			line := checkbox + " " + t.worktree.Branch
+			if m.promptFocus == 1 && i == m.promptTargetCursor {
+				b.WriteString(selectedItemStyle.Render("▶ " + line))
+			} else {
+				b.WriteString(normalItemStyle.Render("  " + line))
+			}
+			if badge, color := agentStateBadge(m.agentStateFor(t.worktree)); badge != "" {
+				b.WriteString(normalItemStyle.Copy().Foreground(color).Render(" " + badge))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if m.promptBroadcast && m.promptFocus == 1 {
+		b.WriteString(helpStyle.Render("↑/↓ navigate • space toggle • a toggle all • tab edit prompt • ctrl+s send • esc cancel"))
+	} else if m.promptBroadcast {
+		b.WriteString(helpStyle.Render("ctrl+s send • tab select worktrees • ctrl+b single • ctrl+p/ctrl+n history • esc cancel"))
+	} else {
+		b.WriteString(helpStyle.Render("ctrl+s send • ctrl+b broadcast • ctrl+p/ctrl+n history • esc cancel"))
+	}
+
+	// Center the modal
+	content := modalStyle.Width(m.width - 4).Render(b.String())
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+func (m Model) renderPRDescriptionSyncModal() string {
+	var b strings.Builder
+
+	b.WriteString(modalTitleStyle.Render("Update PR Description?"))
+	b.WriteString("\n\n")
+	b.WriteString(normalItemStyle.Render(m.prSyncURL))
+	b.WriteString("\n\n")
+
+	removedStyle := normalItemStyle.Copy().Foreground(errorColor)
+	addedStyle := normalItemStyle.Copy().Foreground(successColor)
+	unchangedStyle := normalItemStyle.Copy().Foreground(mutedColor)
+
+	// Leave room for the title, URL, and help lines
+	lines := diffLines(m.prSyncOldBody, m.prSyncNewBody)
+	maxLines := m.height - 14
+	if maxLines < 5 {
+		maxLines = 5
+	}
+	start := m.prSyncScroll
+	if start > len(lines)-1 {
+		start = len(lines) - 1
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	for _, line := range lines[start:end] {
+		switch {
+		case strings.HasPrefix(line, "- "):
+			b.WriteString(removedStyle.Render(line))
+		case strings.HasPrefix(line, "+ "):
+			b.WriteString(addedStyle.Render(line))
+		default:
+			b.WriteString(unchangedStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	if end < len(lines) {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("… %d more line(s)", len(lines)-end)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓ scroll • enter/y update • esc/n skip"))
+
+	// Center the modal
+	content := modalStyle.Width(m.width - 4).Render(b.String())
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
 func (m Model) renderOnboardingModal() string {
 	var b strings.Builder
 
@@ -2358,7 +3336,7 @@ func (m Model) renderOnboardingModal() string {
 	b.WriteString("\n\n")
 
 	// Check if tmux config is already installed
-	hasTmuxConfig, _ := m.sessionManager.HasJeanTmuxConfig()
+	hasTmuxConfig, _ := m.tmuxManager.HasJeanTmuxConfig()
 	if hasTmuxConfig {
 		b.WriteString(helpStyle.Render("Note: Tmux config is already installed. You can update it or skip."))
 		b.WriteString("\n\n")